@@ -1,16 +1,122 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type Config struct {
-	HTTPAddress string
-	LogLevel    string
+	HTTPAddress        string
+	HTTPRequireIfMatch bool
+	LogLevel           string
+
+	// HTTPDebug mounts the /debug endpoints, which expose internal state.
+	HTTPDebug bool
+
+	// CursorSecret signs pagination cursors. When empty a random key is used,
+	// which invalidates cursors on restart and across replicas.
+	CursorSecret string
+
+	StorageDriver   string
+	StoragePath     string
+	WALSyncPolicy   string
+	WALSyncInterval time.Duration
+
+	SnapshotInterval time.Duration
+	SnapshotRetain   int
+	// SnapshotSchedule is a cron expression for the file storage snapshots.
+	// When set the scheduler takes snapshots instead of SnapshotInterval.
+	SnapshotSchedule string
+
+	SQLDSN         string
+	SQLAutoMigrate bool
+
+	// ProjectDeletePolicy is refuse, cascade or orphan; see
+	// usecase.ProjectDeletePolicy.
+	ProjectDeletePolicy string
+	// SubtaskCompletePolicy is allow, refuse or cascade; see
+	// usecase.SubtaskCompletePolicy.
+	SubtaskCompletePolicy string
+
+	// ReminderSchedule is how often due reminders are looked for, as a cron
+	// expression; see scheduler.ParseCron.
+	ReminderSchedule    string
+	ReminderMaxAttempts int
+	WebhookTimeout      time.Duration
+
+	// Webhook* tune the delivery of todo events to the /webhooks
+	// subscribers; see webhook.Config.
+	WebhookQueueSize   int
+	WebhookSenders     int
+	WebhookMaxAttempts int
+	WebhookBackoff     time.Duration
+	WebhookMaxBackoff  time.Duration
+
+	// FeedBufferSize is how many todo events GET /todos/events keeps for
+	// clients resuming with Last-Event-ID.
+	FeedBufferSize int
+	SSEHeartbeat   time.Duration
+
+	// WS* tune /ws; see adapterhttp.SocketConfig.
+	WSPingInterval   time.Duration
+	WSSendBuffer     int
+	WSAllowedOrigins []string
+
+	// SMTPAddress enables the email channel; it is host:port.
+	SMTPAddress  string
+	SMTPFrom     string
+	SMTPUsername string
+	SMTPPassword string
 }
 
 func Load() *Config {
 	return &Config{
-		HTTPAddress: getEnv("HTTP_ADDRESS", ":8080"),
-		LogLevel:    getEnv("LOG_LEVEL", "INFO"),
+		HTTPAddress:        getEnv("HTTP_ADDRESS", ":8080"),
+		HTTPRequireIfMatch: getEnvBool("HTTP_REQUIRE_IF_MATCH", false),
+		LogLevel:           getEnv("LOG_LEVEL", "INFO"),
+
+		HTTPDebug: getEnvBool("HTTP_DEBUG", false),
+
+		CursorSecret: getEnv("CURSOR_SECRET", ""),
+
+		StorageDriver:   getEnv("STORAGE_DRIVER", "memory"),
+		StoragePath:     getEnv("STORAGE_PATH", "data"),
+		WALSyncPolicy:   getEnv("WAL_SYNC_POLICY", "always"),
+		WALSyncInterval: getEnvDuration("WAL_SYNC_INTERVAL", time.Second),
+
+		SnapshotInterval: getEnvDuration("SNAPSHOT_INTERVAL", 5*time.Minute),
+		SnapshotRetain:   getEnvInt("SNAPSHOT_RETAIN", 3),
+		SnapshotSchedule: getEnv("SNAPSHOT_SCHEDULE", ""),
+
+		SQLDSN:         getEnv("SQL_DSN", "file:data/todos.db?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)"),
+		SQLAutoMigrate: getEnvBool("SQL_AUTO_MIGRATE", true),
+
+		ProjectDeletePolicy:   getEnv("PROJECT_DELETE_POLICY", "refuse"),
+		SubtaskCompletePolicy: getEnv("SUBTASK_COMPLETE_POLICY", "refuse"),
+
+		ReminderSchedule:    getEnv("REMINDER_SCHEDULE", "@every 30s"),
+		ReminderMaxAttempts: getEnvInt("REMINDER_MAX_ATTEMPTS", 5),
+		WebhookTimeout:      getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+
+		WebhookQueueSize:   getEnvInt("WEBHOOK_QUEUE_SIZE", 1024),
+		WebhookSenders:     getEnvInt("WEBHOOK_SENDERS", 8),
+		WebhookMaxAttempts: getEnvInt("WEBHOOK_MAX_ATTEMPTS", 6),
+		WebhookBackoff:     getEnvDuration("WEBHOOK_BACKOFF", time.Second),
+		WebhookMaxBackoff:  getEnvDuration("WEBHOOK_MAX_BACKOFF", 5*time.Minute),
+
+		FeedBufferSize: getEnvInt("FEED_BUFFER_SIZE", 1024),
+		SSEHeartbeat:   getEnvDuration("SSE_HEARTBEAT", 15*time.Second),
+		WSPingInterval: getEnvDuration("WS_PING_INTERVAL", 30*time.Second),
+		WSSendBuffer:   getEnvInt("WS_SEND_BUFFER", 64),
+
+		WSAllowedOrigins: getEnvList("WS_ALLOWED_ORIGINS"),
+
+		SMTPAddress:  getEnv("SMTP_ADDRESS", ""),
+		SMTPFrom:     getEnv("SMTP_FROM", "todo@localhost"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
 	}
 }
 
@@ -21,3 +127,38 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvList splits a comma-separated value, dropping empty items.
+func getEnvList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
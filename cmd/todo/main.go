@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
@@ -11,11 +14,21 @@ import (
 	"strings"
 	"syscall"
 	"time"
+	// Recurrence time zones must resolve in images that ship no zoneinfo.
+	_ "time/tzdata"
 
 	"todo-api/cmd/todo/config"
 	adapterhttp "todo-api/internal/adapter/in/http"
+	"todo-api/internal/adapter/in/scheduler"
+	"todo-api/internal/adapter/out/clock"
+	"todo-api/internal/adapter/out/notify"
+	"todo-api/internal/adapter/out/sqlstore"
 	adapterstore "todo-api/internal/adapter/out/storage"
+	"todo-api/internal/adapter/out/webhook"
+	"todo-api/internal/app/cursor"
+	"todo-api/internal/app/eventbus"
 	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/port"
 )
 
 const (
@@ -44,30 +57,303 @@ func newLogger(level string) *slog.Logger {
 	}))
 }
 
-func buildRouter(logger *slog.Logger) http.Handler {
-	storage := adapterstore.NewDataStorage()
+// store is what every storage driver provides.
+type store interface {
+	port.DataStorage
+	port.ProjectStorage
+	port.ReminderStorage
+	port.WebhookStorage
+}
+
+// worker is a background component that runs from Start until Stop.
+type worker interface {
+	Start(ctx context.Context)
+	Stop(ctx context.Context) error
+}
+
+func newStorage(ctx context.Context, logger *slog.Logger, cfg config.Config) (store, func() error, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.StorageDriver)) {
+	case "memory", "":
+		return adapterstore.NewDataStorage(), func() error { return nil }, nil
+	case "file":
+		policy, err := adapterstore.ParseSyncPolicy(cfg.WALSyncPolicy)
+		if err != nil {
+			return nil, nil, err
+		}
+		snapshotInterval := cfg.SnapshotInterval
+		if cfg.SnapshotSchedule != "" {
+			snapshotInterval = 0
+		}
+		storage, err := adapterstore.NewFileStorage(adapterstore.FileStorageConfig{
+			Dir:          cfg.StoragePath,
+			SyncPolicy:   policy,
+			SyncInterval: cfg.WALSyncInterval,
+
+			SnapshotInterval: snapshotInterval,
+			SnapshotRetain:   cfg.SnapshotRetain,
+
+			Logger: logger,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return storage, storage.Close, nil
+	case "sql":
+		if err := os.MkdirAll(cfg.StoragePath, 0o755); err != nil {
+			return nil, nil, err
+		}
+		storage, err := sqlstore.Open(cfg.SQLDSN)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cfg.SQLAutoMigrate {
+			if err := storage.Migrate(ctx); err != nil {
+				_ = storage.Close()
+				return nil, nil, err
+			}
+		}
+		return storage, storage.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown storage driver %q", cfg.StorageDriver)
+	}
+}
+
+func newCursorCodec(logger *slog.Logger, secret string) (*cursor.Codec, error) {
+	if secret != "" {
+		return cursor.NewCodec([]byte(secret)), nil
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generate cursor secret: %w", err)
+	}
+	logger.Warn("CURSOR_SECRET is not set, pagination cursors will not survive a restart")
+
+	return cursor.NewCodec(key), nil
+}
+
+// newNotifiers returns the reminder channels; email needs SMTP_ADDRESS.
+func newNotifiers(logger *slog.Logger, cfg config.Config) map[string]port.Notifier {
+	notifiers := map[string]port.Notifier{
+		notify.ChannelWebhook: notify.NewWebhook(&http.Client{Timeout: cfg.WebhookTimeout}),
+		notify.ChannelLog:     notify.NewLog(logger),
+	}
+	if cfg.SMTPAddress != "" {
+		notifiers[notify.ChannelEmail] = notify.NewSMTP(cfg.SMTPAddress, cfg.SMTPFrom, cfg.SMTPUsername, cfg.SMTPPassword)
+	}
+	return notifiers
+}
+
+// newScheduler registers the background jobs the configuration asks for.
+func newScheduler(
+	logger *slog.Logger,
+	cfg config.Config,
+	storage store,
+	clock port.Clock,
+	fireReminders *usecase.FireRemindersUC,
+) (*scheduler.Scheduler, error) {
+	jobs := scheduler.New(logger, clock)
+
+	reminderSchedule, err := scheduler.ParseCron(cfg.ReminderSchedule)
+	if err != nil {
+		return nil, fmt.Errorf("REMINDER_SCHEDULE: %w", err)
+	}
+	err = jobs.Add(scheduler.Job{
+		Name:     "reminders",
+		Schedule: reminderSchedule,
+		Run: func(ctx context.Context) error {
+			result, err := fireReminders.Execute(ctx)
+			if result.Sent > 0 || result.Failed > 0 {
+				logger.InfoContext(ctx, "fired reminders",
+					slog.Int("sent", result.Sent),
+					slog.Int("failed", result.Failed),
+				)
+			}
+			return err
+		},
+		Retries: 2,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshotter, ok := storage.(interface{ Snapshot() error }); ok && cfg.SnapshotSchedule != "" {
+		schedule, err := scheduler.ParseCron(cfg.SnapshotSchedule)
+		if err != nil {
+			return nil, fmt.Errorf("SNAPSHOT_SCHEDULE: %w", err)
+		}
+		err = jobs.Add(scheduler.Job{
+			Name:     "snapshot",
+			Schedule: schedule,
+			Run:      func(context.Context) error { return snapshotter.Snapshot() },
+			Retries:  3,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return jobs, nil
+}
+
+// jobStats serves the scheduler metrics as JSON.
+func jobStats(jobs *scheduler.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(jobs.Stats())
+	}
+}
+
+// buildRouter returns the workers in the order they are started; they are
+// stopped in reverse.
+func buildRouter(ctx context.Context, logger *slog.Logger, cfg config.Config) (http.Handler, []worker, func() error, error) {
+	storage, closeStorage, err := newStorage(ctx, logger, cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	systemClock := clock.System{}
+
+	webhooks := webhook.NewDispatcher(logger, &http.Client{Timeout: cfg.WebhookTimeout}, storage, systemClock, webhook.Config{
+		QueueSize:   cfg.WebhookQueueSize,
+		Senders:     cfg.WebhookSenders,
+		MaxAttempts: cfg.WebhookMaxAttempts,
+		Backoff:     cfg.WebhookBackoff,
+		MaxBackoff:  cfg.WebhookMaxBackoff,
+	})
+	feed := adapterhttp.NewFeed(cfg.FeedBufferSize)
+
+	// Both subscribers only queue the event, so they can run inside the use
+	// case.
+	events := eventbus.New(logger, eventbus.Config{})
+	events.Subscribe("webhooks", eventbus.Sync, webhooks.Handle)
+	events.Subscribe("feed", eventbus.Sync, feed.Handle)
 
-	createTodoUC := usecase.NewCreateTodoUC(storage)
+	notifiers := newNotifiers(logger, cfg)
+	fireReminders := usecase.NewFireRemindersUC(storage, storage, systemClock, notifiers, cfg.ReminderMaxAttempts)
+
+	jobs, err := newScheduler(logger, cfg, storage, systemClock, fireReminders)
+	if err != nil {
+		return nil, nil, nil, errors.Join(err, closeStorage())
+	}
+
+	createTodoUC := usecase.NewCreateTodoUC(storage, storage, systemClock, events)
 	getTodoUC := usecase.NewGetTodoUC(storage)
-	updateTodoUC := usecase.NewUpdateTodoUC(storage)
-	deleteTodoUC := usecase.NewDeleteTodoUC(storage)
-	getTodoListUC := usecase.NewGetTodoListUC(storage)
+	subtaskPolicy, err := usecase.ParseSubtaskCompletePolicy(cfg.SubtaskCompletePolicy)
+	if err != nil {
+		return nil, nil, nil, errors.Join(err, closeStorage())
+	}
+	updateTodoUC := usecase.NewUpdateTodoUC(storage, storage, systemClock, events, subtaskPolicy)
+	deleteTodoUC := usecase.NewDeleteTodoUC(storage, events)
+	cursors, err := newCursorCodec(logger, cfg.CursorSecret)
+	if err != nil {
+		return nil, nil, nil, errors.Join(err, closeStorage())
+	}
 
-	todoHandler := adapterhttp.NewTodoHandler(
+	getTodoListUC := usecase.NewGetTodoListUC(storage, cursors, systemClock)
+	patchTodoUC := usecase.NewPatchTodoUC(storage, storage, systemClock, events, subtaskPolicy)
+	getNextTodosUC := usecase.NewGetNextTodosUC(storage)
+	addTodoTagUC := usecase.NewAddTodoTagUC(storage, systemClock, events)
+	removeTodoTagUC := usecase.NewRemoveTodoTagUC(storage, systemClock, events)
+	addTodoBlockerUC := usecase.NewAddTodoBlockerUC(storage, systemClock, events)
+	removeTodoBlockerUC := usecase.NewRemoveTodoBlockerUC(storage, systemClock, events)
+
+	todoHandler := adapterhttp.NewTodoHandler(logger, adapterhttp.TodoUseCases{
+		Create:        createTodoUC,
+		Get:           getTodoUC,
+		Update:        updateTodoUC,
+		Delete:        deleteTodoUC,
+		List:          getTodoListUC,
+		Patch:         patchTodoUC,
+		Next:          getNextTodosUC,
+		AddTag:        addTodoTagUC,
+		RemoveTag:     removeTodoTagUC,
+		Tree:          usecase.NewGetTodoTreeUC(storage),
+		AddBlocker:    addTodoBlockerUC,
+		RemoveBlocker: removeTodoBlockerUC,
+		CriticalPath:  usecase.NewGetCriticalPathUC(storage),
+		Occurrences:   usecase.NewGetOccurrencesUC(storage),
+	})
+	todoHandler.RequireIfMatch = cfg.HTTPRequireIfMatch
+
+	renameTagUC := usecase.NewRenameTagUC(storage, systemClock, events)
+	tagHandler := adapterhttp.NewTagHandler(
 		logger,
-		createTodoUC,
-		getTodoUC,
-		updateTodoUC,
-		deleteTodoUC,
+		usecase.NewGetTagsUC(storage),
+		renameTagUC,
+	)
+
+	deletePolicy, err := usecase.ParseProjectDeletePolicy(cfg.ProjectDeletePolicy)
+	if err != nil {
+		return nil, nil, nil, errors.Join(err, closeStorage())
+	}
+
+	deleteProjectUC := usecase.NewDeleteProjectUC(storage, storage, systemClock, events, deletePolicy)
+
+	projectHandler := adapterhttp.NewProjectHandler(
+		logger,
+		usecase.NewCreateProjectUC(storage, systemClock),
+		usecase.NewGetProjectUC(storage),
+		usecase.NewGetProjectListUC(storage),
+		usecase.NewUpdateProjectUC(storage, systemClock),
+		deleteProjectUC,
 		getTodoListUC,
 	)
 
-	return adapterhttp.NewRouter(todoHandler).InitRoutes()
+	reminderHandler := adapterhttp.NewReminderHandler(
+		logger,
+		usecase.NewCreateReminderUC(storage, storage, systemClock, notifiers),
+		usecase.NewGetRemindersUC(storage, storage),
+		usecase.NewDeleteReminderUC(storage),
+	)
+
+	webhookHandler := adapterhttp.NewWebhookHandler(
+		logger,
+		usecase.NewCreateWebhookUC(storage, systemClock),
+		usecase.NewGetWebhookUC(storage),
+		usecase.NewGetWebhookListUC(storage),
+		usecase.NewUpdateWebhookUC(storage, systemClock),
+		usecase.NewDeleteWebhookUC(storage),
+		usecase.NewGetDeadLettersUC(storage),
+		usecase.NewReplayDeadLetterUC(storage, webhooks),
+		usecase.NewDeleteDeadLetterUC(storage),
+	)
+
+	eventHandler := adapterhttp.NewEventHandler(logger, feed, cfg.SSEHeartbeat)
+	socketHandler := adapterhttp.NewSocketHandler(logger, feed, createTodoUC, updateTodoUC, deleteTodoUC, adapterhttp.SocketConfig{
+		PingInterval:   cfg.WSPingInterval,
+		SendBuffer:     cfg.WSSendBuffer,
+		AllowedOrigins: cfg.WSAllowedOrigins,
+	})
+
+	handlers := adapterhttp.Handlers{
+		Todo:     todoHandler,
+		Tag:      tagHandler,
+		Project:  projectHandler,
+		Reminder: reminderHandler,
+		Webhook:  webhookHandler,
+		Events:   eventHandler,
+		Socket:   socketHandler,
+	}
+	if cfg.HTTPDebug {
+		handlers.Jobs = jobStats(jobs)
+	}
+
+	return adapterhttp.NewRouter(handlers).InitRoutes(), []worker{webhooks, events, jobs}, closeStorage, nil
 }
 
 func run(ctx context.Context, cfg config.Config) error {
 	logger := newLogger(cfg.LogLevel)
-	router := buildRouter(logger)
+	router, workers, closeStorage, err := buildRouter(ctx, logger, cfg)
+	if err != nil {
+		logger.Error("failed to build router", slog.Any("err", err))
+		return err
+	}
+	defer func() {
+		if err := closeStorage(); err != nil {
+			logger.Error("failed to close storage", slog.Any("err", err))
+		}
+	}()
 
 	srv := &http.Server{
 		Addr:    cfg.HTTPAddress,
@@ -78,6 +364,20 @@ func run(ctx context.Context, cfg config.Config) error {
 		},
 	}
 
+	for _, w := range workers {
+		w.Start(ctx)
+	}
+	stopWorkers := func(ctx context.Context) []error {
+		var errs []error
+		for i := len(workers) - 1; i >= 0; i-- {
+			if err := workers[i].Stop(ctx); err != nil {
+				logger.Error("failed to stop worker", slog.Any("err", err))
+				errs = append(errs, err)
+			}
+		}
+		return errs
+	}
+
 	errCh := make(chan error, 1)
 
 	go func() {
@@ -89,10 +389,19 @@ func run(ctx context.Context, cfg config.Config) error {
 		errCh <- nil
 	}()
 
+	// The workers share the shutdown deadline with the server and are
+	// stopped before the deferred storage close.
+	shutdown := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(context.Background(), shutdownTimeout)
+	}
+
 	select {
 	case <-ctx.Done():
 		logger.Info("shutdown signal received")
 	case err := <-errCh:
+		shutdownCtx, cancel := shutdown()
+		defer cancel()
+		_ = stopWorkers(shutdownCtx)
 		if err != nil {
 			logger.Error("server failed", slog.Any("err", err))
 			return err
@@ -101,13 +410,18 @@ func run(ctx context.Context, cfg config.Config) error {
 		return nil
 	}
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	shutdownCtx, cancel := shutdown()
 	defer cancel()
 
+	var errs []error
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		logger.Error("graceful shutdown failed", slog.Any("err", err))
 		_ = srv.Close() // fallback
-		return err
+		errs = append(errs, err)
+	}
+	errs = append(errs, stopWorkers(shutdownCtx)...)
+	if len(errs) > 0 {
+		return errors.Join(errs...)
 	}
 
 	logger.Info("server exited properly")
@@ -120,6 +434,14 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, *cfg, os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := run(ctx, *cfg); err != nil {
 		os.Exit(1)
 	}
@@ -13,7 +13,31 @@ func HttpError(err error) (int, string, error) {
 			uc_errors.GetTodoError,
 			uc_errors.GetTodoListError,
 			uc_errors.UpdateTodoError,
-			uc_errors.DeleteTodoError:
+			uc_errors.DeleteTodoError,
+			uc_errors.PatchTodoError,
+			uc_errors.GetTodoTreeError,
+			uc_errors.BlockTodoError,
+			uc_errors.GetCriticalPathError,
+			uc_errors.GetOccurrencesError,
+			uc_errors.CreateReminderError,
+			uc_errors.GetRemindersError,
+			uc_errors.DeleteReminderError,
+			uc_errors.CreateWebhookError,
+			uc_errors.GetWebhookError,
+			uc_errors.GetWebhookListError,
+			uc_errors.UpdateWebhookError,
+			uc_errors.DeleteWebhookError,
+			uc_errors.GetDeadLettersError,
+			uc_errors.ReplayDeadLetterError,
+			uc_errors.DeleteDeadLetterError,
+			uc_errors.TagTodoError,
+			uc_errors.GetTagsError,
+			uc_errors.RenameTagError,
+			uc_errors.CreateProjectError,
+			uc_errors.GetProjectError,
+			uc_errors.GetProjectListError,
+			uc_errors.UpdateProjectError,
+			uc_errors.DeleteProjectError:
 			return http.StatusInternalServerError, w.Public.Error(), w.Reason
 		default:
 			return http.StatusInternalServerError, "internal error", w.Reason
@@ -21,13 +45,52 @@ func HttpError(err error) (int, string, error) {
 	}
 
 	switch {
-	case errors.Is(err, uc_errors.TodoNotFoundError):
+	case errors.Is(err, uc_errors.TodoNotFoundError),
+		errors.Is(err, uc_errors.TagNotFoundError),
+		errors.Is(err, uc_errors.ProjectNotFoundError),
+		errors.Is(err, uc_errors.ReminderNotFoundError),
+		errors.Is(err, uc_errors.WebhookNotFoundError),
+		errors.Is(err, uc_errors.DeadLetterNotFoundError):
 		return http.StatusNotFound, err.Error(), nil
+	case errors.Is(err, uc_errors.TodoVersionConflictError),
+		errors.Is(err, uc_errors.ProjectVersionConflictError),
+		errors.Is(err, uc_errors.ProjectNotEmptyError),
+		errors.Is(err, uc_errors.ParentCycleError),
+		errors.Is(err, uc_errors.OpenSubtasksError),
+		errors.Is(err, uc_errors.TodoHasSubtasksError),
+		errors.Is(err, uc_errors.DependencyCycleError),
+		errors.Is(err, uc_errors.BlockedTodoError),
+		errors.Is(err, uc_errors.PatchTestFailedError):
+		return http.StatusConflict, err.Error(), nil
+	case errors.Is(err, uc_errors.UnsupportedPatchError):
+		return http.StatusUnsupportedMediaType, err.Error(), nil
 	case errors.Is(err, uc_errors.TodoAlreadyExistsError),
 		errors.Is(err, uc_errors.EmptyTitleError),
+		errors.Is(err, uc_errors.InvalidScheduleError),
+		errors.Is(err, uc_errors.InvalidPriorityError),
+		errors.Is(err, uc_errors.InvalidRecurrenceError),
+		errors.Is(err, uc_errors.InvalidRangeError),
+		errors.Is(err, uc_errors.InvalidReminderError),
+		errors.Is(err, uc_errors.InvalidReminderIDError),
+		errors.Is(err, uc_errors.InvalidWebhookError),
+		errors.Is(err, uc_errors.InvalidWebhookIDError),
+		errors.Is(err, uc_errors.InvalidDeadLetterIDError),
+		errors.Is(err, uc_errors.InvalidNextCountError),
+		errors.Is(err, uc_errors.InvalidTagError),
+		errors.Is(err, uc_errors.TooManyTagsError),
+		errors.Is(err, uc_errors.InvalidProjectIDError),
+		errors.Is(err, uc_errors.EmptyProjectNameError),
+		errors.Is(err, uc_errors.UnknownProjectError),
+		errors.Is(err, uc_errors.UnknownParentError),
+		errors.Is(err, uc_errors.UnknownBlockerError),
 		errors.Is(err, uc_errors.InvalidTodoIDError),
 		errors.Is(err, uc_errors.InvalidLimitError),
-		errors.Is(err, uc_errors.InvalidOffsetError):
+		errors.Is(err, uc_errors.InvalidOffsetError),
+		errors.Is(err, uc_errors.InvalidFilterError),
+		errors.Is(err, uc_errors.InvalidSortError),
+		errors.Is(err, uc_errors.InvalidCursorError),
+		errors.Is(err, uc_errors.InvalidPatchError),
+		errors.Is(err, uc_errors.ReadOnlyFieldError):
 		return http.StatusBadRequest, err.Error(), nil
 	}
 
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"todo-api/internal/app/dto"
+)
+
+// todoETag is a strong validator: it changes whenever any byte of the todo's
+// JSON representation changes, including its version and the fields computed
+// from its subtasks and blockers.
+func todoETag(todo dto.GetTodoResponse) string {
+	return `"` + hashJSON(todo) + `"`
+}
+
+// listETag is weak because a page is only semantically equivalent between
+// requests, e.g. the same items may be encoded with different whitespace.
+func listETag(list dto.GetTodoListResponse) string {
+	return `W/"` + hashJSON(list) + `"`
+}
+
+func hashJSON(v any) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:16])
+}
+
+// etagMatches implements the If-Match (strong) and If-None-Match (weak)
+// comparisons from RFC 9110 section 8.8.3.2.
+func etagMatches(header, etag string, weak bool) bool {
+	if etag == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+
+		if weak {
+			if strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+			continue
+		}
+
+		if !strings.HasPrefix(candidate, "W/") && !strings.HasPrefix(etag, "W/") && candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" || !etagMatches(header, etag, true) {
+		return false
+	}
+
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
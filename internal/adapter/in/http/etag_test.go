@@ -0,0 +1,298 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	adapterhttp "todo-api/internal/adapter/in/http"
+	"todo-api/internal/adapter/out/clock"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/cursor"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/entity"
+)
+
+func newConditionalHandler(store *storage.DataStorage) *adapterhttp.TodoHandler {
+	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return adapterhttp.NewTodoHandler(testLogger, adapterhttp.TodoUseCases{
+		Create:        usecase.NewCreateTodoUC(store, store, clock.System{}, nil),
+		Get:           usecase.NewGetTodoUC(store),
+		Update:        usecase.NewUpdateTodoUC(store, store, clock.System{}, nil, usecase.SubtaskCompleteAllow),
+		Delete:        usecase.NewDeleteTodoUC(store, nil),
+		List:          usecase.NewGetTodoListUC(store, cursor.NewCodec([]byte("secret")), clock.System{}),
+		Patch:         usecase.NewPatchTodoUC(store, store, clock.System{}, nil, usecase.SubtaskCompleteAllow),
+		Next:          usecase.NewGetNextTodosUC(store),
+		AddTag:        usecase.NewAddTodoTagUC(store, clock.System{}, nil),
+		RemoveTag:     usecase.NewRemoveTodoTagUC(store, clock.System{}, nil),
+		Tree:          usecase.NewGetTodoTreeUC(store),
+		AddBlocker:    usecase.NewAddTodoBlockerUC(store, clock.System{}, nil),
+		RemoveBlocker: usecase.NewRemoveTodoBlockerUC(store, clock.System{}, nil),
+		CriticalPath:  usecase.NewGetCriticalPathUC(store),
+		Occurrences:   usecase.NewGetOccurrencesUC(store),
+	})
+}
+
+func serve(mux http.Handler, method, target, body string, headers map[string]string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	for k, v := range headers {
+		request.Header.Set(k, v)
+	}
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestTH_ETag(t *testing.T) {
+	store := storage.NewDataStorage()
+	todo := &entity.Todo{Title: "Learn math"}
+	_ = store.CreateTodo(context.Background(), todo)
+
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store)}).InitRoutes()
+	target := fmt.Sprintf("/todos/%d", todo.ID)
+
+	first := serve(mux, "GET", target, "", nil)
+	etag := first.Header().Get("ETag")
+	if etag == "" || strings.HasPrefix(etag, "W/") {
+		t.Fatalf("expected strong ETag, got %q", etag)
+	}
+
+	t.Run("If-None-Match hit", func(t *testing.T) {
+		recorder := serve(mux, "GET", target, "", map[string]string{"If-None-Match": etag})
+		if recorder.Code != http.StatusNotModified {
+			t.Errorf("expected status 304, got %v", recorder.Code)
+		}
+		if recorder.Body.Len() != 0 {
+			t.Errorf("expected empty body, got %q", recorder.Body.String())
+		}
+	})
+
+	t.Run("If-None-Match miss", func(t *testing.T) {
+		recorder := serve(mux, "GET", target, "", map[string]string{"If-None-Match": `"other"`})
+		if recorder.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("If-Match mismatch on PUT", func(t *testing.T) {
+		recorder := serve(mux, "PUT", target, `{"title": "Learn physics"}`, map[string]string{"If-Match": `"other"`})
+		if recorder.Code != http.StatusPreconditionFailed {
+			t.Errorf("expected status 412, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("If-Match hit on PUT", func(t *testing.T) {
+		recorder := serve(mux, "PUT", target, `{"title": "Learn physics"}`, map[string]string{"If-Match": etag})
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v", recorder.Code)
+		}
+
+		updated := recorder.Header().Get("ETag")
+
+		recorder = serve(mux, "GET", target, "", map[string]string{"If-None-Match": etag})
+		if recorder.Code != http.StatusOK || recorder.Header().Get("ETag") == etag {
+			t.Errorf("expected a new representation, got %v with ETag %q", recorder.Code, recorder.Header().Get("ETag"))
+		}
+		if recorder.Header().Get("ETag") != updated {
+			t.Errorf("expected the PUT ETag %q, got %q", updated, recorder.Header().Get("ETag"))
+		}
+	})
+
+	t.Run("Stale If-Match on DELETE", func(t *testing.T) {
+		recorder := serve(mux, "DELETE", target, "", map[string]string{"If-Match": etag})
+		if recorder.Code != http.StatusPreconditionFailed {
+			t.Errorf("expected status 412, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("Wildcard If-Match on DELETE", func(t *testing.T) {
+		recorder := serve(mux, "DELETE", target, "", map[string]string{"If-Match": "*"})
+		if recorder.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("Wildcard If-Match on a missing todo", func(t *testing.T) {
+		for _, method := range []string{"PUT", "DELETE"} {
+			recorder := serve(mux, method, target, `{"title": "Learn physics"}`, map[string]string{"If-Match": "*"})
+			if recorder.Code != http.StatusPreconditionFailed {
+				t.Errorf("expected status 412 on %s, got %v", method, recorder.Code)
+			}
+		}
+	})
+}
+
+func TestTH_ListETag(t *testing.T) {
+	store := storage.NewDataStorage()
+	_ = store.CreateTodo(context.Background(), &entity.Todo{Title: "Learn math"})
+
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store)}).InitRoutes()
+
+	etag := serve(mux, "GET", "/todos", "", nil).Header().Get("ETag")
+	if !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("expected weak ETag, got %q", etag)
+	}
+
+	if recorder := serve(mux, "GET", "/todos", "", map[string]string{"If-None-Match": etag}); recorder.Code != http.StatusNotModified {
+		t.Errorf("expected status 304, got %v", recorder.Code)
+	}
+
+	_ = store.CreateTodo(context.Background(), &entity.Todo{Title: "Learn english"})
+
+	if recorder := serve(mux, "GET", "/todos", "", map[string]string{"If-None-Match": etag}); recorder.Code != http.StatusOK {
+		t.Errorf("expected status 200 after a change, got %v", recorder.Code)
+	}
+}
+
+func TestTH_ETagComputedFields(t *testing.T) {
+	store := storage.NewDataStorage()
+	for _, todo := range []entity.Todo{
+		{Title: "Learn math"},
+		{Title: "Buy a textbook"},
+		{Title: "Solve exercises", ParentID: 1},
+	} {
+		_ = store.CreateTodo(context.Background(), &todo)
+	}
+
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store)}).InitRoutes()
+
+	t.Run("Blocker completed", func(t *testing.T) {
+		recorder := serve(mux, "PUT", "/todos/1/blockers/2", "", nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+		etag := recorder.Header().Get("ETag")
+
+		if recorder := serve(mux, "PUT", "/todos/2", `{"title": "Buy a textbook", "completed": true}`, nil); recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+
+		recorder = serve(mux, "GET", "/todos/1", "", map[string]string{"If-None-Match": etag})
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v", recorder.Code)
+		}
+		var got dto.GetTodoResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&got)
+		if got.Blocked {
+			t.Error("expected the todo no longer blocked")
+		}
+	})
+
+	t.Run("Subtask completed", func(t *testing.T) {
+		etag := serve(mux, "GET", "/todos/1", "", nil).Header().Get("ETag")
+
+		if recorder := serve(mux, "PUT", "/todos/3", `{"title": "Solve exercises", "parent_id": 1, "completed": true}`, nil); recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+
+		recorder := serve(mux, "GET", "/todos/1", "", map[string]string{"If-None-Match": etag})
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v", recorder.Code)
+		}
+		var got dto.GetTodoResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&got)
+		if got.Progress == nil || got.Progress.Done != 1 {
+			t.Errorf("expected the completed subtask counted, got %+v", got.Progress)
+		}
+	})
+}
+
+func TestTH_RequireIfMatch(t *testing.T) {
+	store := storage.NewDataStorage()
+	todo := &entity.Todo{Title: "Learn math"}
+	_ = store.CreateTodo(context.Background(), todo)
+
+	handler := newConditionalHandler(store)
+	handler.RequireIfMatch = true
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: handler}).InitRoutes()
+	target := fmt.Sprintf("/todos/%d", todo.ID)
+
+	if recorder := serve(mux, "PUT", target, `{"title": "Learn physics"}`, nil); recorder.Code != http.StatusPreconditionRequired {
+		t.Errorf("expected status 428, got %v", recorder.Code)
+	}
+	if recorder := serve(mux, "DELETE", target, "", nil); recorder.Code != http.StatusPreconditionRequired {
+		t.Errorf("expected status 428, got %v", recorder.Code)
+	}
+}
+
+func TestTH_Patch(t *testing.T) {
+	store := storage.NewDataStorage()
+	todo := &entity.Todo{Title: "Learn math", Description: "algebra"}
+	_ = store.CreateTodo(context.Background(), todo)
+
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store)}).InitRoutes()
+	target := fmt.Sprintf("/todos/%d", todo.ID)
+
+	t.Run("Merge patch", func(t *testing.T) {
+		recorder := serve(mux, "PATCH", target, `{"completed": true}`, map[string]string{"Content-Type": "application/merge-patch+json"})
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+
+		var got dto.PatchTodoResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&got)
+		if !got.Completed || got.Title != "Learn math" || got.Description != "algebra" {
+			t.Errorf("expected only completed to change, got %+v", got.Todo)
+		}
+		etag := recorder.Header().Get("ETag")
+		if etag == "" {
+			t.Error("expected ETag header")
+		}
+		if recorder := serve(mux, "GET", target, "", map[string]string{"If-None-Match": etag}); recorder.Code != http.StatusNotModified {
+			t.Errorf("expected the ETag of the stored representation, got status %v", recorder.Code)
+		}
+	})
+
+	t.Run("JSON patch", func(t *testing.T) {
+		body := `[{"op": "replace", "path": "/title", "value": "Learn physics"}]`
+		recorder := serve(mux, "PATCH", target, body, map[string]string{"Content-Type": "application/json-patch+json"})
+		if recorder.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+	})
+
+	t.Run("Failed test op", func(t *testing.T) {
+		body := `[{"op": "test", "path": "/title", "value": "Learn math"}]`
+		recorder := serve(mux, "PATCH", target, body, map[string]string{"Content-Type": "application/json-patch+json"})
+		if recorder.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("Malformed patch", func(t *testing.T) {
+		recorder := serve(mux, "PATCH", target, `{"op"`, map[string]string{"Content-Type": "application/json-patch+json"})
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("Unsupported media type", func(t *testing.T) {
+		recorder := serve(mux, "PATCH", target, `{"completed": false}`, map[string]string{"Content-Type": "application/json"})
+		if recorder.Code != http.StatusUnsupportedMediaType {
+			t.Errorf("expected status 415, got %v", recorder.Code)
+		}
+		if recorder.Header().Get("Accept-Patch") == "" {
+			t.Error("expected Accept-Patch header")
+		}
+	})
+
+	t.Run("Stale If-Match", func(t *testing.T) {
+		headers := map[string]string{"Content-Type": "application/merge-patch+json", "If-Match": `"stale"`}
+		if recorder := serve(mux, "PATCH", target, `{"completed": false}`, headers); recorder.Code != http.StatusPreconditionFailed {
+			t.Errorf("expected status 412, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		recorder := serve(mux, "PATCH", "/todos/1000", `{}`, map[string]string{"Content-Type": "application/merge-patch+json"})
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %v", recorder.Code)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// feedLag is how many events a stream may fall behind before it is cut
+// off; the client then reconnects and catches up from the feed's buffer.
+const feedLag = 256
+
+// defaultHeartbeat replaces a heartbeat interval that is not positive.
+const defaultHeartbeat = 15 * time.Second
+
+type EventHandler struct {
+	log       *slog.Logger
+	feed      *Feed
+	heartbeat time.Duration
+}
+
+func NewEventHandler(log *slog.Logger, feed *Feed, heartbeat time.Duration) *EventHandler {
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeat
+	}
+	return &EventHandler{log: log, feed: feed, heartbeat: heartbeat}
+}
+
+// StreamTodoEvents sends the todo changes as Server-Sent Events. A client
+// resuming with Last-Event-ID first gets what it missed; when that is no
+// longer buffered it gets a "reset" event and should reload the list. The
+// stream ends with the request context, which includes server shutdown.
+func (h *EventHandler) StreamTodoEvents(w http.ResponseWriter, r *http.Request) {
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = r.URL.Query().Get("last_event_id")
+	}
+
+	sub, backlog, ok := h.feed.Subscribe(lastID, feedLag)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	if err := rc.Flush(); err != nil {
+		h.log.ErrorContext(r.Context(), "event stream is not supported", slog.Any("err", err))
+		return
+	}
+
+	if !ok {
+		if err := writeReset(w, sub.Head); err != nil {
+			return
+		}
+	}
+	for _, event := range backlog {
+		if err := writeEvent(w, event); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			_, err = io.WriteString(w, ": ping\n\n")
+		case event, open := <-sub.C:
+			if !open {
+				if sub.Lagged() {
+					h.log.WarnContext(r.Context(), "event stream fell behind, closing")
+				}
+				return
+			}
+			err = writeEvent(w, event)
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w io.Writer, event FeedEvent) error {
+	data, err := json.Marshal(event.Todo)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
+
+// writeReset tells the client its Last-Event-ID cannot be resumed. The id
+// moves it to the current head so that a reconnect does not reset again.
+func writeReset(w io.Writer, head string) error {
+	_, err := fmt.Fprintf(w, "id: %s\nevent: reset\ndata: {}\n\n", head)
+	return err
+}
@@ -0,0 +1,150 @@
+package http_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+	adapterhttp "todo-api/internal/adapter/in/http"
+	"todo-api/internal/domain/entity"
+)
+
+// nextEvent reads the next event of an event stream, skipping heartbeats.
+func nextEvent(t *testing.T, r *bufio.Reader) []string {
+	t.Helper()
+	for {
+		if event := readEvent(t, r); len(event) != 1 || event[0] != ": ping" {
+			return event
+		}
+	}
+}
+
+// readEvent reads the next event or comment block of an event stream.
+func readEvent(t *testing.T, r *bufio.Reader) []string {
+	t.Helper()
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestTH_Events(t *testing.T) {
+	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	feed := adapterhttp.NewFeed(16)
+	events := adapterhttp.NewEventHandler(testLogger, feed, 50*time.Millisecond)
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Events: events}).InitRoutes()
+
+	baseCtx, shutdown := context.WithCancel(context.Background())
+	defer shutdown()
+	server := httptest.NewUnstartedServer(mux)
+	server.Config.BaseContext = func(net.Listener) context.Context { return baseCtx }
+	server.Start()
+	defer server.Close()
+
+	open := func(lastID string) (*http.Response, *bufio.Reader) {
+		request, _ := http.NewRequest("GET", server.URL+"/todos/events", nil)
+		if lastID != "" {
+			request.Header.Set("Last-Event-ID", lastID)
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		return response, bufio.NewReader(response.Body)
+	}
+
+	var lastID string
+
+	t.Run("Success", func(t *testing.T) {
+		response, stream := open("")
+		defer response.Body.Close()
+		if ct := response.Header.Get("Content-Type"); ct != "text/event-stream" {
+			t.Fatalf("expected text/event-stream, got %q", ct)
+		}
+
+		emitTodos(feed, entity.TodoEventCreated, 1)
+		event := nextEvent(t, stream)
+		if len(event) != 3 || !strings.HasPrefix(event[0], "id: ") || event[1] != "event: todo.created" ||
+			!strings.Contains(event[2], `"id":1`) {
+			t.Fatalf("expected a todo.created event, got %q", event)
+		}
+		lastID = strings.TrimPrefix(event[0], "id: ")
+
+		if heartbeat := readEvent(t, stream); len(heartbeat) != 1 || heartbeat[0] != ": ping" {
+			t.Errorf("expected a heartbeat, got %q", heartbeat)
+		}
+	})
+
+	t.Run("Success - resume", func(t *testing.T) {
+		emitTodos(feed, entity.TodoEventUpdated, 1)
+		emitTodos(feed, entity.TodoEventDeleted, 1)
+
+		response, stream := open(lastID)
+		defer response.Body.Close()
+		if event := nextEvent(t, stream); len(event) != 3 || event[1] != "event: todo.updated" {
+			t.Errorf("expected the missed todo.updated, got %q", event)
+		}
+		if event := nextEvent(t, stream); len(event) != 3 || event[1] != "event: todo.deleted" {
+			t.Errorf("expected the missed todo.deleted, got %q", event)
+		}
+	})
+
+	t.Run("Error - cannot resume", func(t *testing.T) {
+		response, stream := open("0-1")
+		defer response.Body.Close()
+		event := nextEvent(t, stream)
+		if len(event) != 3 || !strings.HasPrefix(event[0], "id: ") || event[1] != "event: reset" {
+			t.Errorf("expected a reset, got %q", event)
+		}
+	})
+
+	t.Run("Success - shutdown ends the stream", func(t *testing.T) {
+		response, stream := open("")
+		defer response.Body.Close()
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := io.Copy(io.Discard, stream)
+			done <- err
+		}()
+		shutdown()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected the stream to end")
+		}
+	})
+}
+
+func TestTH_EventsWithoutHeartbeat(t *testing.T) {
+	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	events := adapterhttp.NewEventHandler(testLogger, adapterhttp.NewFeed(16), 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	recorder := httptest.NewRecorder()
+	events.StreamTodoEvents(recorder, httptest.NewRequest("GET", "/todos/events", nil).WithContext(ctx))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %v", recorder.Code)
+	}
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/event"
+)
+
+// FeedEvent is one todo change as the live endpoints send it.
+type FeedEvent struct {
+	ID   string
+	Type entity.TodoEventType
+	Todo dto.Todo
+}
+
+// Feed is the event bus subscriber behind the live endpoints. It numbers the
+// changes, keeps the last few in a ring buffer for clients that reconnect,
+// and hands them to the subscribers without ever waiting for one.
+//
+// Event ids are "<epoch>-<seq>", the epoch being when the feed started, so
+// an id from before a restart is told apart from a current one.
+type Feed struct {
+	mu     sync.Mutex
+	epoch  string
+	seq    uint64
+	ring   []FeedEvent
+	next   int // where the next event goes in ring
+	filled bool
+	subs   map[*FeedSubscription]struct{}
+}
+
+// FeedSubscription delivers live events on C. C is closed when the
+// subscriber falls more than its buffer behind, or on Close; Lagged tells
+// the two apart. Head is the id of the last event before the subscription,
+// empty if there was none.
+type FeedSubscription struct {
+	C      <-chan FeedEvent
+	Head   string
+	c      chan FeedEvent
+	feed   *Feed
+	lagged bool
+}
+
+func NewFeed(size int) *Feed {
+	return &Feed{
+		epoch: strconv.FormatInt(time.Now().UnixMilli(), 36),
+		ring:  make([]FeedEvent, max(size, 1)),
+		subs:  make(map[*FeedSubscription]struct{}),
+	}
+}
+
+// Handle skips TodoCompleted: the TodoUpdated before it already carries
+// the completed todo.
+func (f *Feed) Handle(_ context.Context, e event.Event) {
+	if _, ok := e.(event.TodoCompleted); ok {
+		return
+	}
+	subject := e.Subject()
+	todo := mappers.MapDomainTodoToTodoDTO(&subject)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	fe := FeedEvent{ID: f.id(f.seq), Type: e.Type(), Todo: todo}
+	f.ring[f.next] = fe
+	f.next = (f.next + 1) % len(f.ring)
+	if f.next == 0 {
+		f.filled = true
+	}
+
+	for sub := range f.subs {
+		select {
+		case sub.c <- fe:
+		default:
+			sub.lagged = true
+			f.drop(sub)
+		}
+	}
+}
+
+// Subscribe starts a subscription with room for buffer events. With a
+// lastID it also returns the buffered events after it; ok is false when
+// they are not all buffered any more, and the client has to reload.
+func (f *Feed) Subscribe(lastID string, buffer int) (sub *FeedSubscription, backlog []FeedEvent, ok bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ok = true
+	if lastID != "" {
+		backlog, ok = f.since(lastID)
+	}
+
+	c := make(chan FeedEvent, max(buffer, 1))
+	sub = &FeedSubscription{C: c, c: c, feed: f}
+	if f.seq > 0 {
+		sub.Head = f.id(f.seq)
+	}
+	f.subs[sub] = struct{}{}
+	return sub, backlog, ok
+}
+
+// since returns the buffered events after lastID.
+func (f *Feed) since(lastID string) ([]FeedEvent, bool) {
+	epoch, n, found := strings.Cut(lastID, "-")
+	seq, err := strconv.ParseUint(n, 10, 64)
+	if !found || err != nil || epoch != f.epoch || seq > f.seq {
+		return nil, false
+	}
+
+	buffered := uint64(f.next)
+	if f.filled {
+		buffered = uint64(len(f.ring))
+	}
+	missed := f.seq - seq
+	if missed > buffered {
+		return nil, false
+	}
+
+	events := make([]FeedEvent, 0, missed)
+	for i := missed; i > 0; i-- {
+		events = append(events, f.ring[(f.next-int(i)+len(f.ring))%len(f.ring)])
+	}
+	return events, true
+}
+
+func (f *Feed) id(seq uint64) string {
+	return f.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+func (f *Feed) drop(sub *FeedSubscription) {
+	if _, ok := f.subs[sub]; ok {
+		delete(f.subs, sub)
+		close(sub.c)
+	}
+}
+
+func (s *FeedSubscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	s.feed.drop(s)
+}
+
+// Lagged reports whether the feed dropped the subscription because it fell
+// behind; it is only meaningful once C is closed.
+func (s *FeedSubscription) Lagged() bool {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	return s.lagged
+}
@@ -0,0 +1,104 @@
+package http_test
+
+import (
+	"context"
+	"testing"
+	adapterhttp "todo-api/internal/adapter/in/http"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/event"
+)
+
+func emitTodos(feed *adapterhttp.Feed, eventType entity.TodoEventType, ids ...int64) {
+	for _, id := range ids {
+		todo := entity.Todo{ID: id, Title: "Todo"}
+		var e event.Event
+		switch eventType {
+		case entity.TodoEventCreated:
+			e = event.TodoCreated{Todo: todo}
+		case entity.TodoEventUpdated:
+			e = event.TodoUpdated{Before: todo, After: todo}
+		case entity.TodoEventCompleted:
+			e = event.TodoCompleted{Todo: todo}
+		case entity.TodoEventDeleted:
+			e = event.TodoDeleted{Todo: todo}
+		}
+		feed.Handle(context.Background(), e)
+	}
+}
+
+func TestFeed(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		feed := adapterhttp.NewFeed(4)
+		emitTodos(feed, entity.TodoEventCreated, 1)
+		sub, _, _ := feed.Subscribe("", 8)
+		defer sub.Close()
+
+		emitTodos(feed, entity.TodoEventCompleted, 1)
+		emitTodos(feed, entity.TodoEventUpdated, 1)
+		emitTodos(feed, entity.TodoEventDeleted, 1)
+
+		first := <-sub.C
+		second := <-sub.C
+		if first.Type != entity.TodoEventUpdated || second.Type != entity.TodoEventDeleted || second.Todo.ID != 1 {
+			t.Fatalf("expected updated and deleted, got %+v %+v", first, second)
+		}
+
+		resumed, backlog, ok := feed.Subscribe(sub.Head, 8)
+		defer resumed.Close()
+		if !ok || len(backlog) != 2 || backlog[0].ID != first.ID || backlog[1].ID != second.ID {
+			t.Errorf("expected to resume with the 2 events, got %v %+v", ok, backlog)
+		}
+	})
+
+	t.Run("Success - nothing missed", func(t *testing.T) {
+		feed := adapterhttp.NewFeed(4)
+		emitTodos(feed, entity.TodoEventCreated, 1)
+		sub, _, _ := feed.Subscribe("", 1)
+		defer sub.Close()
+
+		resumed, backlog, ok := feed.Subscribe(sub.Head, 1)
+		defer resumed.Close()
+		if !ok || len(backlog) != 0 {
+			t.Errorf("expected an empty backlog, got %v %+v", ok, backlog)
+		}
+	})
+
+	t.Run("Error - not buffered any more", func(t *testing.T) {
+		feed := adapterhttp.NewFeed(2)
+		emitTodos(feed, entity.TodoEventCreated, 1)
+		sub, _, _ := feed.Subscribe("", 1)
+		sub.Close()
+		emitTodos(feed, entity.TodoEventCreated, 2, 3, 4)
+
+		resumed, backlog, ok := feed.Subscribe(sub.Head, 1)
+		defer resumed.Close()
+		if ok || backlog != nil {
+			t.Errorf("expected a reset, got %v %+v", ok, backlog)
+		}
+	})
+
+	t.Run("Error - id from another run", func(t *testing.T) {
+		feed := adapterhttp.NewFeed(4)
+		emitTodos(feed, entity.TodoEventCreated, 1, 2)
+
+		for _, id := range []string{"0-1", "garbage", "-"} {
+			sub, _, ok := feed.Subscribe(id, 1)
+			sub.Close()
+			if ok {
+				t.Errorf("%s: expected a reset", id)
+			}
+		}
+	})
+
+	t.Run("Error - subscriber falls behind", func(t *testing.T) {
+		feed := adapterhttp.NewFeed(4)
+		sub, _, _ := feed.Subscribe("", 1)
+		emitTodos(feed, entity.TodoEventCreated, 1, 2)
+
+		<-sub.C
+		if _, open := <-sub.C; open || !sub.Lagged() {
+			t.Errorf("expected the subscription to be dropped")
+		}
+		sub.Close()
+	})
+}
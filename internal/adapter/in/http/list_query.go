@@ -0,0 +1,466 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/query"
+)
+
+// maxFilterDepth bounds nesting in ?filter= so a hostile query cannot recurse
+// the parser arbitrarily deep.
+const maxFilterDepth = 32
+
+// parseListQuery reads the paging, filtering and sorting parameters of
+// GET /todos. All given filters must match:
+//
+//	limit=10&offset=0        limit defaults to 10
+//	cursor=token             next_cursor or prev_cursor of a previous page
+//	completed=true|false
+//	q=text                   title or description contains text
+//	filter=expr              e.g. completed=false and (title~"milk" or priority>=high)
+//	sort=-id,title           "-" sorts descending
+//	due_before=RFC 3339 time
+//	due_within=48h           due from now until now+48h
+//	overdue=true|false       past due and not completed
+//	tag=a&tag=b              has every tag, or any of them with tag_mode=any
+//
+// A cursor from next_cursor or prev_cursor must be sent with the same sort.
+func parseListQuery(values url.Values) (dto.GetTodoList, error) {
+	var (
+		input   dto.GetTodoList
+		filters query.And
+	)
+
+	input.Limit, _ = strconv.Atoi(values.Get("limit"))
+	if input.Limit == 0 {
+		input.Limit = 10
+	}
+	input.Offset, _ = strconv.Atoi(values.Get("offset"))
+	input.Cursor = values.Get("cursor")
+
+	if completed := values.Get("completed"); completed != "" {
+		value, err := strconv.ParseBool(completed)
+		if err != nil {
+			return input, fmt.Errorf("%w: completed must be true or false", uc_errors.InvalidFilterError)
+		}
+		filters = append(filters, query.Predicate{Field: query.FieldCompleted, Op: query.OpEq, Value: value})
+	}
+
+	if q := values.Get("q"); q != "" {
+		filters = append(filters, query.Or{
+			query.Predicate{Field: query.FieldTitle, Op: query.OpContains, Value: q},
+			query.Predicate{Field: query.FieldDescription, Op: query.OpContains, Value: q},
+		})
+	}
+
+	if expr := values.Get("filter"); expr != "" {
+		filter, err := parseFilter(expr)
+		if err != nil {
+			return input, err
+		}
+		filters = append(filters, filter)
+	}
+
+	switch len(filters) {
+	case 0:
+	case 1:
+		input.Filter = filters[0]
+	default:
+		input.Filter = filters
+	}
+
+	input.Tags = values["tag"]
+	switch mode := values.Get("tag_mode"); mode {
+	case "", "all":
+	case "any":
+		input.AnyTag = true
+	default:
+		return input, fmt.Errorf("%w: tag_mode must be any or all", uc_errors.InvalidFilterError)
+	}
+
+	if dueBefore := values.Get("due_before"); dueBefore != "" {
+		t, err := time.Parse(time.RFC3339, dueBefore)
+		if err != nil {
+			return input, fmt.Errorf("%w: due_before must be an RFC 3339 time", uc_errors.InvalidFilterError)
+		}
+		input.DueBefore = t.UTC()
+	}
+
+	if dueWithin := values.Get("due_within"); dueWithin != "" {
+		d, err := time.ParseDuration(dueWithin)
+		if err != nil || d <= 0 {
+			return input, fmt.Errorf("%w: due_within must be a positive duration such as 48h", uc_errors.InvalidFilterError)
+		}
+		input.DueWithin = d
+	}
+
+	if overdue := values.Get("overdue"); overdue != "" {
+		value, err := strconv.ParseBool(overdue)
+		if err != nil {
+			return input, fmt.Errorf("%w: overdue must be true or false", uc_errors.InvalidFilterError)
+		}
+		input.Overdue = &value
+	}
+
+	if sort := values.Get("sort"); sort != "" {
+		keys, err := parseSort(sort)
+		if err != nil {
+			return input, err
+		}
+		input.Sort = keys
+	}
+
+	return input, nil
+}
+
+func parseSort(s string) ([]query.SortKey, error) {
+	var keys []query.SortKey
+	seen := make(map[query.Field]bool)
+
+	for _, term := range strings.Split(s, ",") {
+		term = strings.TrimSpace(term)
+
+		var key query.SortKey
+		switch {
+		case strings.HasPrefix(term, "-"):
+			key.Desc = true
+			term = term[1:]
+		case strings.HasPrefix(term, "+"):
+			term = term[1:]
+		}
+		key.Field = query.Field(term)
+
+		if _, ok := key.Field.Kind(); !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", uc_errors.InvalidSortError, term)
+		}
+		if seen[key.Field] {
+			return nil, fmt.Errorf("%w: field %q given twice", uc_errors.InvalidSortError, term)
+		}
+		seen[key.Field] = true
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// parseFilter parses the ?filter= expression language:
+//
+//	expr      = term { "or" term }
+//	term      = factor { "and" factor }
+//	factor    = "not" factor | "(" expr ")" | predicate
+//	predicate = field ( "=" | "!=" | "<" | "<=" | ">" | ">=" | "~" ) value
+//	value     = word | quoted string
+//
+// "~" is a case-insensitive substring match on text fields.
+func parseFilter(expr string) (query.Filter, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens}
+	filter, err := p.expr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorf(tok, "unexpected %q", tok.text)
+	}
+
+	return filter, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case c == '"':
+			quoted, err := strconv.QuotedPrefix(expr[i:])
+			if err != nil {
+				return nil, fmt.Errorf("%w: unterminated string at %d", uc_errors.InvalidFilterError, i)
+			}
+			text, _ := strconv.Unquote(quoted)
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: i})
+			i += len(quoted)
+		case strings.ContainsRune("=!<>~", rune(c)):
+			op := expr[i : i+1]
+			if i+1 < len(expr) && expr[i+1] == '=' && c != '=' && c != '~' {
+				op = expr[i : i+2]
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("%w: unexpected \"!\" at %d", uc_errors.InvalidFilterError, i)
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: i})
+			i += len(op)
+		case isWordByte(c):
+			start := i
+			for i < len(expr) && isWordByte(expr[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: expr[start:i], pos: start})
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q at %d", uc_errors.InvalidFilterError, c, i)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(expr)}), nil
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '-' || c == '+' || c == '.' || c == ':' ||
+		'0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c >= 0x80
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *filterParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *filterParser) keyword(word string) bool {
+	tok := p.peek()
+	if tok.kind == tokenWord && strings.EqualFold(tok.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) errorf(tok token, format string, args ...any) error {
+	return fmt.Errorf("%w: %s at %d", uc_errors.InvalidFilterError, fmt.Sprintf(format, args...), tok.pos)
+}
+
+func (p *filterParser) expr(depth int) (query.Filter, error) {
+	return p.list(depth, "or", p.term, func(fs []query.Filter) query.Filter { return query.Or(fs) })
+}
+
+func (p *filterParser) term(depth int) (query.Filter, error) {
+	return p.list(depth, "and", p.factor, func(fs []query.Filter) query.Filter { return query.And(fs) })
+}
+
+func (p *filterParser) list(
+	depth int,
+	sep string,
+	operand func(int) (query.Filter, error),
+	join func([]query.Filter) query.Filter,
+) (query.Filter, error) {
+	first, err := operand(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []query.Filter{first}
+	for p.keyword(sep) {
+		f, err := operand(depth)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+
+	if len(filters) == 1 {
+		return first, nil
+	}
+	return join(filters), nil
+}
+
+func (p *filterParser) factor(depth int) (query.Filter, error) {
+	if depth > maxFilterDepth {
+		return nil, p.errorf(p.peek(), "expression nested too deeply")
+	}
+
+	if p.keyword("not") {
+		f, err := p.factor(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return query.Not{Filter: f}, nil
+	}
+
+	if tok := p.peek(); tok.kind == tokenLParen {
+		p.next()
+		f, err := p.expr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, p.errorf(closing, "expected \")\"")
+		}
+		return f, nil
+	}
+
+	return p.predicate()
+}
+
+func (p *filterParser) predicate() (query.Filter, error) {
+	fieldTok := p.next()
+	if fieldTok.kind != tokenWord {
+		return nil, p.errorf(fieldTok, "expected field name")
+	}
+	field := query.Field(fieldTok.text)
+	kind, ok := field.Kind()
+	if !ok {
+		return nil, p.errorf(fieldTok, "unknown field %q", fieldTok.text)
+	}
+
+	opTok := p.next()
+	op := query.Op(opTok.text)
+	if opTok.kind != tokenOp {
+		return nil, p.errorf(opTok, "expected operator after %q", fieldTok.text)
+	}
+	if !op.Supports(kind) {
+		return nil, p.errorf(opTok, "operator %q is not supported for %q", opTok.text, fieldTok.text)
+	}
+
+	valueTok := p.next()
+	if valueTok.kind != tokenWord && valueTok.kind != tokenString {
+		return nil, p.errorf(valueTok, "expected value for %q", fieldTok.text)
+	}
+
+	var value any
+	switch kind {
+	case query.KindInt:
+		if p, ok := entity.ParsePriority(valueTok.text); ok && field == query.FieldPriority {
+			value = int64(p)
+			break
+		}
+		n, err := strconv.ParseInt(valueTok.text, 10, 64)
+		if err != nil {
+			return nil, p.errorf(valueTok, "%q must be an integer", fieldTok.text)
+		}
+		value = n
+	case query.KindBool:
+		b, err := strconv.ParseBool(valueTok.text)
+		if err != nil {
+			return nil, p.errorf(valueTok, "%q must be true or false", fieldTok.text)
+		}
+		value = b
+	case query.KindTime:
+		t, err := time.Parse(time.RFC3339, valueTok.text)
+		if err != nil {
+			return nil, p.errorf(valueTok, "%q must be an RFC 3339 time", fieldTok.text)
+		}
+		value = t.UTC()
+	default:
+		value = valueTok.text
+	}
+
+	return query.Predicate{Field: field, Op: op, Value: value}, nil
+}
+
+// writeTodoList sends a page of todos with its validators and Link header.
+func writeTodoList(w http.ResponseWriter, r *http.Request, response dto.GetTodoListResponse) {
+	etag := listETag(response)
+	if notModified(w, r, etag) {
+		return
+	}
+
+	if links := paginationLinks(r.URL, response); links != "" {
+		w.Header().Set("Link", links)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// paginationLinks builds an RFC 8288 Link header value with first, prev, next
+// and last relations for a page of GET /todos. Cursor pages link prev/next
+// through their cursors; everything else is addressed by offset. Filters and
+// sorting from the request are kept.
+func paginationLinks(u *url.URL, page dto.GetTodoListResponse) string {
+	if page.Limit <= 0 {
+		return ""
+	}
+
+	link := func(rel string, set func(url.Values)) string {
+		values := u.Query()
+		values.Del("cursor")
+		values.Del("offset")
+		values.Set("limit", strconv.Itoa(page.Limit))
+		set(values)
+		return fmt.Sprintf("<%s?%s>; rel=%q", u.Path, values.Encode(), rel)
+	}
+	offset := func(n int) func(url.Values) {
+		return func(values url.Values) {
+			if n > 0 {
+				values.Set("offset", strconv.Itoa(n))
+			}
+		}
+	}
+	cursor := func(c string) func(url.Values) {
+		return func(values url.Values) { values.Set("cursor", c) }
+	}
+
+	last := 0
+	if page.Total > 0 {
+		last = (page.Total - 1) / page.Limit * page.Limit
+	}
+
+	links := []string{link("first", offset(0))}
+
+	switch {
+	case page.PrevCursor != "" && u.Query().Get("cursor") != "":
+		links = append(links, link("prev", cursor(page.PrevCursor)))
+	case page.Offset > 0:
+		links = append(links, link("prev", offset(max(page.Offset-page.Limit, 0))))
+	}
+
+	switch {
+	case page.NextCursor != "" && u.Query().Get("cursor") != "":
+		links = append(links, link("next", cursor(page.NextCursor)))
+	case u.Query().Get("cursor") == "" && page.Offset+page.Limit < page.Total:
+		links = append(links, link("next", offset(page.Offset+page.Limit)))
+	}
+
+	links = append(links, link("last", offset(last)))
+
+	return strings.Join(links, ", ")
+}
@@ -0,0 +1,321 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	adapterhttp "todo-api/internal/adapter/in/http"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/domain/entity"
+)
+
+func TestTH_ListFilter(t *testing.T) {
+	store := storage.NewDataStorage()
+	for _, todo := range []entity.Todo{
+		{Title: "Buy milk", Description: "2 liters"},
+		{Title: "Walk the dog", Completed: true},
+		{Title: "Buy bread", Priority: entity.PriorityHigh},
+		{Title: "Call mom", Description: "about the milk", Completed: true, Priority: entity.PriorityUrgent},
+	} {
+		_ = store.CreateTodo(context.Background(), &todo)
+	}
+
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store)}).InitRoutes()
+
+	list := func(t *testing.T, params url.Values) []int64 {
+		t.Helper()
+
+		recorder := serve(mux, "GET", "/todos?"+params.Encode(), "", nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+
+		var response dto.GetTodoListResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&response)
+
+		ids := make([]int64, len(response.Todos))
+		for i, todo := range response.Todos {
+			ids[i] = todo.ID
+		}
+		return ids
+	}
+
+	cases := []struct {
+		name     string
+		params   url.Values
+		expected []int64
+	}{
+		{"Completed", url.Values{"completed": {"true"}}, []int64{2, 4}},
+		{"Search", url.Values{"q": {"MILK"}}, []int64{1, 4}},
+		{"Search and completed", url.Values{"q": {"milk"}, "completed": {"false"}}, []int64{1}},
+		{"Sort", url.Values{"sort": {"-completed,title"}}, []int64{4, 2, 3, 1}},
+		{"Expression", url.Values{"filter": {`not completed=true and (title~"bread" or id<2)`}}, []int64{1, 3}},
+		{"Expression with keywords in any case", url.Values{"filter": {`id>=2 AND id!=3 OR title="Buy milk"`}}, []int64{1, 2, 4}},
+		{"Paged", url.Values{"sort": {"-id"}, "limit": {"2"}, "offset": {"1"}}, []int64{3, 2}},
+		{"Priority by name", url.Values{"filter": {"priority>=high"}, "sort": {"-priority"}}, []int64{4, 3}},
+		{"Sort by timestamps", url.Values{"sort": {"-completed_at,created_at,updated_at"}}, []int64{1, 2, 3, 4}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := list(t, tc.params); fmt.Sprint(got) != fmt.Sprint(tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	invalid := []url.Values{
+		{"completed": {"maybe"}},
+		{"sort": {"owner"}},
+		{"sort": {"id,-id"}},
+		{"filter": {"owner=me"}},
+		{"filter": {"completed>false"}},
+		{"filter": {"id~1"}},
+		{"filter": {"id=one"}},
+		{"filter": {"(id=1"}},
+		{"filter": {"id=1 id=2"}},
+		{"filter": {`title="unterminated`}},
+		{"filter": {"title!"}},
+		{"filter": {"priority=asap"}},
+	}
+
+	for _, params := range invalid {
+		t.Run("Error - "+params.Encode(), func(t *testing.T) {
+			recorder := serve(mux, "GET", "/todos?"+params.Encode(), "", nil)
+			if recorder.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %v", recorder.Code)
+			}
+		})
+	}
+
+	t.Run("Error - nested too deeply", func(t *testing.T) {
+		expr := ""
+		for range 100 {
+			expr += "not "
+		}
+		params := url.Values{"filter": {expr + "id=1"}}
+		if recorder := serve(mux, "GET", "/todos?"+params.Encode(), "", nil); recorder.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %v", recorder.Code)
+		}
+	})
+}
+
+func TestTH_ListCursor(t *testing.T) {
+	store := storage.NewDataStorage()
+	for _, title := range []string{"Learn math", "Learn english", "Learn JS"} {
+		_ = store.CreateTodo(context.Background(), &entity.Todo{Title: title})
+	}
+
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store)}).InitRoutes()
+
+	page := func(t *testing.T, target string) dto.GetTodoListResponse {
+		t.Helper()
+
+		recorder := serve(mux, "GET", target, "", nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+
+		var response dto.GetTodoListResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&response)
+		return response
+	}
+
+	first := page(t, "/todos?limit=2&sort=-title")
+	if first.NextCursor == "" {
+		t.Fatal("expected next_cursor")
+	}
+
+	second := page(t, "/todos?limit=2&sort=-title&cursor="+url.QueryEscape(first.NextCursor))
+	if len(second.Todos) != 1 || second.Todos[0].Title != "Learn JS" || second.NextCursor != "" || second.PrevCursor == "" {
+		t.Errorf("expected the last page, got %+v", second)
+	}
+
+	for _, target := range []string{
+		"/todos?cursor=forged",
+		"/todos?cursor=" + url.QueryEscape(first.NextCursor),
+		"/todos?sort=-title&offset=1&cursor=" + url.QueryEscape(first.NextCursor),
+	} {
+		if recorder := serve(mux, "GET", target, "", nil); recorder.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %v", target, recorder.Code)
+		}
+	}
+}
+
+func TestTH_ListPagination(t *testing.T) {
+	store := storage.NewDataStorage()
+	for i := range 7 {
+		_ = store.CreateTodo(context.Background(), &entity.Todo{Title: fmt.Sprintf("Todo %d", i), Completed: i < 5})
+	}
+
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store)}).InitRoutes()
+
+	t.Run("Metadata", func(t *testing.T) {
+		recorder := serve(mux, "GET", "/todos?completed=true&limit=2&offset=2", "", nil)
+
+		var response dto.GetTodoListResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&response)
+		if response.Total != 5 || response.Limit != 2 || response.Offset != 2 {
+			t.Errorf("expected total 5, limit 2, offset 2, got %d, %d, %d", response.Total, response.Limit, response.Offset)
+		}
+
+		expected := `</todos?completed=true&limit=2>; rel="first", ` +
+			`</todos?completed=true&limit=2>; rel="prev", ` +
+			`</todos?completed=true&limit=2&offset=4>; rel="next", ` +
+			`</todos?completed=true&limit=2&offset=4>; rel="last"`
+		if got := recorder.Header().Get("Link"); got != expected {
+			t.Errorf("expected Link %s, got %s", expected, got)
+		}
+	})
+
+	t.Run("Last page has no next", func(t *testing.T) {
+		recorder := serve(mux, "GET", "/todos?limit=3&offset=6", "", nil)
+
+		expected := `</todos?limit=3>; rel="first", ` +
+			`</todos?limit=3&offset=3>; rel="prev", ` +
+			`</todos?limit=3&offset=6>; rel="last"`
+		if got := recorder.Header().Get("Link"); got != expected {
+			t.Errorf("expected Link %s, got %s", expected, got)
+		}
+	})
+
+	t.Run("Cursor pages link by cursor", func(t *testing.T) {
+		var first dto.GetTodoListResponse
+		_ = json.NewDecoder(serve(mux, "GET", "/todos?limit=3", "", nil).Body).Decode(&first)
+
+		recorder := serve(mux, "GET", "/todos?limit=3&cursor="+url.QueryEscape(first.NextCursor), "", nil)
+
+		var second dto.GetTodoListResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&second)
+		if second.Total != 7 {
+			t.Errorf("expected total 7, got %d", second.Total)
+		}
+
+		link := recorder.Header().Get("Link")
+		for _, want := range []string{
+			`</todos?cursor=` + url.QueryEscape(second.PrevCursor) + `&limit=3>; rel="prev"`,
+			`</todos?cursor=` + url.QueryEscape(second.NextCursor) + `&limit=3>; rel="next"`,
+		} {
+			if !strings.Contains(link, want) {
+				t.Errorf("expected Link to contain %s, got %s", want, link)
+			}
+		}
+	})
+}
+
+func TestTH_ListDue(t *testing.T) {
+	store := storage.NewDataStorage()
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store)}).InitRoutes()
+
+	for _, body := range []string{
+		`{"title": "Pay rent", "due_at": "2020-01-01T10:00:00+03:00"}`,
+		`{"title": "Plan vacation", "start_at": "2099-01-01T00:00:00Z", "due_at": "2099-02-01T00:00:00Z"}`,
+		`{"title": "Someday"}`,
+	} {
+		if recorder := serve(mux, "POST", "/todos", body, nil); recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %v: %s", recorder.Code, recorder.Body)
+		}
+	}
+
+	t.Run("Round trip", func(t *testing.T) {
+		var todo dto.GetTodoResponse
+		_ = json.NewDecoder(serve(mux, "GET", "/todos/1", "", nil).Body).Decode(&todo)
+		if todo.DueAt == nil || todo.DueAt.Format(time.RFC3339) != "2020-01-01T07:00:00Z" || todo.StartAt != nil {
+			t.Errorf("expected due_at in UTC and no start_at, got %v, %v", todo.DueAt, todo.StartAt)
+		}
+	})
+
+	titles := func(t *testing.T, target string) []string {
+		t.Helper()
+
+		recorder := serve(mux, "GET", target, "", nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+
+		var response dto.GetTodoListResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&response)
+		result := make([]string, len(response.Todos))
+		for i, todo := range response.Todos {
+			result[i] = todo.Title
+		}
+		return result
+	}
+
+	cases := map[string]string{
+		"/todos?overdue=true":                    "[Pay rent]",
+		"/todos?due_before=2030-01-01T00:00:00Z": "[Pay rent]",
+		"/todos?due_within=876000h":              "[Plan vacation]",
+		"/todos?sort=-due_at":                    "[Plan vacation Pay rent Someday]",
+		`/todos?filter=` + url.QueryEscape(`start_at>2000-01-01T00:00:00Z`): "[Plan vacation]",
+	}
+	for target, expected := range cases {
+		t.Run(target, func(t *testing.T) {
+			if got := titles(t, target); fmt.Sprint(got) != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+
+	for _, target := range []string{
+		"/todos?due_before=tomorrow",
+		"/todos?due_within=-1h",
+		"/todos?overdue=sometimes",
+		"/todos?filter=" + url.QueryEscape("due_at<2026"),
+		"/todos?filter=" + url.QueryEscape(`due_at~"2026"`),
+	} {
+		t.Run("Error - "+target, func(t *testing.T) {
+			if recorder := serve(mux, "GET", target, "", nil); recorder.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %v", recorder.Code)
+			}
+		})
+	}
+
+	t.Run("Error - start after due", func(t *testing.T) {
+		body := `{"title": "Backwards", "start_at": "2026-02-01T00:00:00Z", "due_at": "2026-01-01T00:00:00Z"}`
+		if recorder := serve(mux, "POST", "/todos", body, nil); recorder.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %v", recorder.Code)
+		}
+	})
+}
+
+func TestTH_NextTodos(t *testing.T) {
+	store := storage.NewDataStorage()
+	for _, todo := range []entity.Todo{
+		{Title: "Buy milk", Priority: entity.PriorityLow},
+		{Title: "Pay rent", Priority: entity.PriorityUrgent},
+		{Title: "Walk the dog", Priority: entity.PriorityUrgent, Completed: true},
+		{Title: "Buy bread"},
+	} {
+		_ = store.CreateTodo(context.Background(), &todo)
+	}
+
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store)}).InitRoutes()
+
+	t.Run("Success", func(t *testing.T) {
+		recorder := serve(mux, "GET", "/todos/next?n=2", "", nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+
+		var response dto.GetNextTodosResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&response)
+		if len(response.Todos) != 2 || response.Todos[0].Title != "Pay rent" || response.Todos[1].Title != "Buy milk" {
+			t.Errorf("expected Pay rent then Buy milk, got %v", response.Todos)
+		}
+	})
+
+	for _, n := range []string{"0", "101", "five"} {
+		t.Run("Error - n="+n, func(t *testing.T) {
+			if recorder := serve(mux, "GET", "/todos/next?n="+n, "", nil); recorder.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %v", recorder.Code)
+			}
+		})
+	}
+}
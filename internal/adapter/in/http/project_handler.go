@@ -0,0 +1,204 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/usecase"
+)
+
+type ProjectHandler struct {
+	log              *slog.Logger
+	createProjectUC  *usecase.CreateProjectUC
+	getProjectUC     *usecase.GetProjectUC
+	getProjectListUC *usecase.GetProjectListUC
+	updateProjectUC  *usecase.UpdateProjectUC
+	deleteProjectUC  *usecase.DeleteProjectUC
+	getTodoListUC    *usecase.GetTodoListUC
+}
+
+func NewProjectHandler(
+	log *slog.Logger,
+	createProjectUC *usecase.CreateProjectUC,
+	getProjectUC *usecase.GetProjectUC,
+	getProjectListUC *usecase.GetProjectListUC,
+	updateProjectUC *usecase.UpdateProjectUC,
+	deleteProjectUC *usecase.DeleteProjectUC,
+	getTodoListUC *usecase.GetTodoListUC,
+) *ProjectHandler {
+	return &ProjectHandler{
+		log:              log,
+		createProjectUC:  createProjectUC,
+		getProjectUC:     getProjectUC,
+		getProjectListUC: getProjectListUC,
+		updateProjectUC:  updateProjectUC,
+		deleteProjectUC:  deleteProjectUC,
+		getTodoListUC:    getTodoListUC,
+	}
+}
+
+func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	var input dto.CreateProject
+	if err := json.NewDecoder(r.Body).Decode(&input.Project); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.createProjectUC.Execute(r.Context(), input)
+	if err != nil {
+		h.fail(w, r, "failed to create project", err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "created project",
+		slog.Int("id", int(response.ID)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.getProjectUC.Execute(r.Context(), dto.GetProject{ID: id})
+	if err != nil {
+		h.fail(w, r, "failed to get project", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *ProjectHandler) GetProjectList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit == 0 {
+		limit = 10
+	}
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	response, err := h.getProjectListUC.Execute(r.Context(), dto.GetProjectList{Limit: limit, Offset: offset})
+	if err != nil {
+		h.fail(w, r, "failed to get project list", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	var input dto.UpdateProject
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	input.ID = id
+
+	response, err := h.updateProjectUC.Execute(r.Context(), input)
+	if err != nil {
+		h.fail(w, r, "failed to update project", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	input := dto.DeleteProject{ID: id}
+	if versionStr := r.URL.Query().Get("version"); versionStr != "" {
+		var err error
+		if input.Version, err = strconv.ParseInt(versionStr, 10, 64); err != nil {
+			http.Error(w, "invalid version format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	response, err := h.deleteProjectUC.Execute(r.Context(), input)
+	if err != nil {
+		h.fail(w, r, "failed to delete project", err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "deleted project",
+		slog.Int("id", int(response.ID)),
+		slog.Int("todos", response.Todos),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// GetProjectTodos lists the todos of one project and takes the same query
+// parameters as GET /todos.
+func (h *ProjectHandler) GetProjectTodos(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.getProjectUC.Execute(r.Context(), dto.GetProject{ID: id}); err != nil {
+		h.fail(w, r, "failed to get project", err)
+		return
+	}
+
+	input, err := parseListQuery(r.URL.Query())
+	if err != nil {
+		status, msg, _ := HttpError(err)
+		http.Error(w, msg, status)
+		return
+	}
+	input.ProjectID = id
+
+	response, err := h.getTodoListUC.Execute(r.Context(), input)
+	if err != nil {
+		h.fail(w, r, "failed to get project todos", err)
+		return
+	}
+
+	writeTodoList(w, r, response)
+}
+
+func (h *ProjectHandler) fail(w http.ResponseWriter, r *http.Request, message string, err error) {
+	status, msg, internalErr := HttpError(err)
+	h.log.ErrorContext(r.Context(), message,
+		slog.Int("status", status),
+		slog.String("public_msg", msg),
+		slog.Any("cause", internalErr),
+	)
+	http.Error(w, msg, status)
+}
+
+// pathID parses the {id} path value.
+func pathID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid id format", http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,87 @@
+package http_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	adapterhttp "todo-api/internal/adapter/in/http"
+	"todo-api/internal/adapter/out/clock"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/cursor"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/usecase"
+)
+
+func TestTH_Projects(t *testing.T) {
+	store := storage.NewDataStorage()
+
+	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	projects := adapterhttp.NewProjectHandler(testLogger,
+		usecase.NewCreateProjectUC(store, clock.System{}),
+		usecase.NewGetProjectUC(store),
+		usecase.NewGetProjectListUC(store),
+		usecase.NewUpdateProjectUC(store, clock.System{}),
+		usecase.NewDeleteProjectUC(store, store, clock.System{}, nil, usecase.ProjectDeleteRefuse),
+		usecase.NewGetTodoListUC(store, cursor.NewCodec([]byte("secret")), clock.System{}),
+	)
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store), Project: projects}).InitRoutes()
+
+	t.Run("Success", func(t *testing.T) {
+		recorder := serve(mux, "POST", "/projects", `{"name": "Home"}`, nil)
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %v: %s", recorder.Code, recorder.Body)
+		}
+		var created dto.CreateProjectResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&created)
+		if created.ID != 1 || created.Version != 1 {
+			t.Errorf("expected project 1 at version 1, got %+v", created)
+		}
+
+		if recorder := serve(mux, "PUT", "/projects/1", `{"name": "House", "version": 1}`, nil); recorder.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+
+		recorder = serve(mux, "GET", "/projects", "", nil)
+		var list dto.GetProjectListResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&list)
+		if list.Total != 1 || list.Projects[0].Name != "House" {
+			t.Errorf("expected [House], got %+v", list)
+		}
+	})
+
+	t.Run("Project todos", func(t *testing.T) {
+		_ = serve(mux, "POST", "/todos", `{"title": "Sweep", "project_id": 1}`, nil)
+		_ = serve(mux, "POST", "/todos", `{"title": "Buy milk"}`, nil)
+
+		recorder := serve(mux, "GET", "/projects/1/todos?completed=false", "", nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+		var response dto.GetTodoListResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&response)
+		if response.Total != 1 || response.Todos[0].Title != "Sweep" {
+			t.Errorf("expected [Sweep], got %+v", response.Todos)
+		}
+
+		if recorder := serve(mux, "GET", "/projects/9/todos", "", nil); recorder.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("Error - unknown project", func(t *testing.T) {
+		if recorder := serve(mux, "POST", "/todos", `{"title": "Call mom", "project_id": 9}`, nil); recorder.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("Error - delete a project with todos", func(t *testing.T) {
+		if recorder := serve(mux, "DELETE", "/projects/1", "", nil); recorder.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %v", recorder.Code)
+		}
+		if recorder := serve(mux, "DELETE", "/projects/1?version=1", "", nil); recorder.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %v", recorder.Code)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/usecase"
+)
+
+type ReminderHandler struct {
+	log              *slog.Logger
+	createReminderUC *usecase.CreateReminderUC
+	getRemindersUC   *usecase.GetRemindersUC
+	deleteReminderUC *usecase.DeleteReminderUC
+}
+
+func NewReminderHandler(
+	log *slog.Logger,
+	createReminderUC *usecase.CreateReminderUC,
+	getRemindersUC *usecase.GetRemindersUC,
+	deleteReminderUC *usecase.DeleteReminderUC,
+) *ReminderHandler {
+	return &ReminderHandler{
+		log:              log,
+		createReminderUC: createReminderUC,
+		getRemindersUC:   getRemindersUC,
+		deleteReminderUC: deleteReminderUC,
+	}
+}
+
+func (h *ReminderHandler) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	todoID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var input dto.CreateReminder
+	if err := json.NewDecoder(r.Body).Decode(&input.Reminder); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	input.TodoID = todoID
+
+	response, err := h.createReminderUC.Execute(r.Context(), input)
+	if err != nil {
+		h.fail(w, r, "failed to create reminder", err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "created reminder",
+		slog.Int("id", int(response.ID)),
+		slog.Int("todo_id", int(response.TodoID)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *ReminderHandler) GetReminders(w http.ResponseWriter, r *http.Request) {
+	todoID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.getRemindersUC.Execute(r.Context(), dto.GetReminders{TodoID: todoID})
+	if err != nil {
+		h.fail(w, r, "failed to get reminders", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *ReminderHandler) DeleteReminder(w http.ResponseWriter, r *http.Request) {
+	todoID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseInt(r.PathValue("reminder"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid reminder id format", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.deleteReminderUC.Execute(r.Context(), dto.DeleteReminder{ID: id, TodoID: todoID})
+	if err != nil {
+		h.fail(w, r, "failed to delete reminder", err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "deleted reminder", slog.Int("id", int(response.ID)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *ReminderHandler) fail(w http.ResponseWriter, r *http.Request, message string, err error) {
+	status, msg, internalErr := HttpError(err)
+	h.log.ErrorContext(r.Context(), message,
+		slog.Int("status", status),
+		slog.String("public_msg", msg),
+		slog.Any("cause", internalErr),
+	)
+	http.Error(w, msg, status)
+}
@@ -0,0 +1,81 @@
+package http_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	adapterhttp "todo-api/internal/adapter/in/http"
+	"todo-api/internal/adapter/out/clock"
+	"todo-api/internal/adapter/out/notify"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/port"
+)
+
+func TestTH_Reminders(t *testing.T) {
+	store := storage.NewDataStorage()
+
+	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	notifiers := map[string]port.Notifier{notify.ChannelLog: notify.NewLog(testLogger)}
+	reminders := adapterhttp.NewReminderHandler(testLogger,
+		usecase.NewCreateReminderUC(store, store, clock.System{}, notifiers),
+		usecase.NewGetRemindersUC(store, store),
+		usecase.NewDeleteReminderUC(store),
+	)
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store), Reminder: reminders}).InitRoutes()
+
+	_ = serve(mux, "POST", "/todos", `{"title": "Pay rent", "due_at": "2026-03-01T09:00:00Z"}`, nil)
+	_ = serve(mux, "POST", "/todos", `{"title": "Buy milk"}`, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		recorder := serve(mux, "POST", "/todos/1/reminders", `{"before": "1h", "channel": "log", "target": "me"}`, nil)
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %v: %s", recorder.Code, recorder.Body)
+		}
+		var created dto.CreateReminderResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&created)
+		if created.ID != 1 || created.Status != "pending" || created.FireAt == nil || created.FireAt.Format("15:04") != "08:00" {
+			t.Errorf("expected a pending reminder at 08:00, got %+v", created.Reminder)
+		}
+
+		recorder = serve(mux, "GET", "/todos/1/reminders", "", nil)
+		var list dto.GetRemindersResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&list)
+		if len(list.Reminders) != 1 || list.Reminders[0].Before != "1h0m0s" {
+			t.Errorf("expected one reminder an hour before, got %+v", list)
+		}
+
+		if recorder := serve(mux, "DELETE", "/todos/2/reminders/1", "", nil); recorder.Code != http.StatusNotFound {
+			t.Errorf("expected status 404 for another todo's reminder, got %v", recorder.Code)
+		}
+		if recorder := serve(mux, "DELETE", "/todos/1/reminders/1", "", nil); recorder.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+		if recorder := serve(mux, "GET", "/todos/1/reminders", "", nil); recorder.Body.String() != "{\"items\":[]}\n" {
+			t.Errorf("expected no reminders, got %s", recorder.Body)
+		}
+	})
+
+	t.Run("Error - invalid reminder", func(t *testing.T) {
+		for _, body := range []string{
+			`{"channel": "log", "target": "me"}`,
+			`{"before": "1h", "channel": "pigeon", "target": "me"}`,
+			`{"before": "soon", "channel": "log", "target": "me"}`,
+			`{"before": `,
+		} {
+			if recorder := serve(mux, "POST", "/todos/1/reminders", body, nil); recorder.Code != http.StatusBadRequest {
+				t.Errorf("%s: expected status 400, got %v: %s", body, recorder.Code, recorder.Body)
+			}
+		}
+	})
+
+	t.Run("Error - not found", func(t *testing.T) {
+		recorder := serve(mux, "POST", "/todos/42/reminders", `{"before": "1h", "channel": "log", "target": "me"}`, nil)
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %v: %s", recorder.Code, recorder.Body)
+		}
+	})
+}
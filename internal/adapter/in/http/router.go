@@ -2,22 +2,84 @@ package http
 
 import "net/http"
 
+// Handlers are the handlers a Router mounts. The routes of a nil handler
+// are left out, so a test only sets the ones it exercises.
+type Handlers struct {
+	Todo     *TodoHandler
+	Tag      *TagHandler
+	Project  *ProjectHandler
+	Reminder *ReminderHandler
+	Webhook  *WebhookHandler
+	Events   *EventHandler
+	Socket   *SocketHandler
+	// Jobs serves GET /debug/jobs.
+	Jobs http.Handler
+}
+
 type Router struct {
-	Todo *TodoHandler
+	Handlers
 }
 
-func NewRouter(todo *TodoHandler) *Router {
-	return &Router{Todo: todo}
+func NewRouter(handlers Handlers) *Router {
+	return &Router{Handlers: handlers}
 }
 
 func (r *Router) InitRoutes() http.Handler {
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("POST /todos", r.Todo.CreateTodo)
-	mux.HandleFunc("GET /todos/{id}", r.Todo.GetTodo)
-	mux.HandleFunc("PUT /todos/{id}", r.Todo.UpdateTodo)
-	mux.HandleFunc("DELETE /todos/{id}", r.Todo.DeleteTodo)
-	mux.HandleFunc("GET /todos", r.Todo.GetTodoList)
+	if r.Todo != nil {
+		mux.HandleFunc("POST /todos", r.Todo.CreateTodo)
+		mux.HandleFunc("GET /todos/next", r.Todo.GetNextTodos)
+		mux.HandleFunc("GET /todos/{id}", r.Todo.GetTodo)
+		mux.HandleFunc("PUT /todos/{id}", r.Todo.UpdateTodo)
+		mux.HandleFunc("PATCH /todos/{id}", r.Todo.PatchTodo)
+		mux.HandleFunc("DELETE /todos/{id}", r.Todo.DeleteTodo)
+		mux.HandleFunc("GET /todos", r.Todo.GetTodoList)
+		mux.HandleFunc("PUT /todos/{id}/tags/{tag}", r.Todo.AddTodoTag)
+		mux.HandleFunc("DELETE /todos/{id}/tags/{tag}", r.Todo.RemoveTodoTag)
+		mux.HandleFunc("GET /todos/{id}/children", r.Todo.GetTodoChildren)
+		mux.HandleFunc("GET /todos/{id}/tree", r.Todo.GetTodoTree)
+		mux.HandleFunc("PUT /todos/{id}/blockers/{blocker}", r.Todo.AddTodoBlocker)
+		mux.HandleFunc("DELETE /todos/{id}/blockers/{blocker}", r.Todo.RemoveTodoBlocker)
+		mux.HandleFunc("GET /todos/{id}/critical-path", r.Todo.GetCriticalPath)
+		mux.HandleFunc("GET /todos/{id}/occurrences", r.Todo.GetOccurrences)
+	}
+	if r.Events != nil {
+		mux.HandleFunc("GET /todos/events", r.Events.StreamTodoEvents)
+	}
+	if r.Reminder != nil {
+		mux.HandleFunc("POST /todos/{id}/reminders", r.Reminder.CreateReminder)
+		mux.HandleFunc("GET /todos/{id}/reminders", r.Reminder.GetReminders)
+		mux.HandleFunc("DELETE /todos/{id}/reminders/{reminder}", r.Reminder.DeleteReminder)
+	}
+	if r.Tag != nil {
+		mux.HandleFunc("GET /tags", r.Tag.GetTags)
+		mux.HandleFunc("PATCH /tags/{tag}", r.Tag.RenameTag)
+	}
+	if r.Project != nil {
+		mux.HandleFunc("POST /projects", r.Project.CreateProject)
+		mux.HandleFunc("GET /projects", r.Project.GetProjectList)
+		mux.HandleFunc("GET /projects/{id}", r.Project.GetProject)
+		mux.HandleFunc("PUT /projects/{id}", r.Project.UpdateProject)
+		mux.HandleFunc("DELETE /projects/{id}", r.Project.DeleteProject)
+		mux.HandleFunc("GET /projects/{id}/todos", r.Project.GetProjectTodos)
+	}
+	if r.Webhook != nil {
+		mux.HandleFunc("POST /webhooks", r.Webhook.CreateWebhook)
+		mux.HandleFunc("GET /webhooks", r.Webhook.GetWebhookList)
+		mux.HandleFunc("GET /webhooks/{id}", r.Webhook.GetWebhook)
+		mux.HandleFunc("PUT /webhooks/{id}", r.Webhook.UpdateWebhook)
+		mux.HandleFunc("DELETE /webhooks/{id}", r.Webhook.DeleteWebhook)
+		mux.HandleFunc("GET /webhooks/dead-letters", r.Webhook.GetDeadLetters)
+		mux.HandleFunc("POST /webhooks/dead-letters/{id}/replay", r.Webhook.ReplayDeadLetter)
+		mux.HandleFunc("DELETE /webhooks/dead-letters/{id}", r.Webhook.DeleteDeadLetter)
+	}
+	if r.Socket != nil {
+		mux.HandleFunc("GET /ws", r.Socket.Serve)
+	}
+	if r.Jobs != nil {
+		mux.Handle("GET /debug/jobs", r.Jobs)
+	}
 
 	var handler http.Handler = mux
 	handler = r.withLogger(handler)
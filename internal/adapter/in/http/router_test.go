@@ -0,0 +1,26 @@
+package http_test
+
+import (
+	"net/http"
+	"testing"
+	adapterhttp "todo-api/internal/adapter/in/http"
+)
+
+func TestRouter_Jobs(t *testing.T) {
+	t.Run("Success - behind the middleware", func(t *testing.T) {
+		jobs := http.HandlerFunc(func(http.ResponseWriter, *http.Request) { panic("boom") })
+		mux := adapterhttp.NewRouter(adapterhttp.Handlers{Jobs: jobs}).InitRoutes()
+
+		if recorder := serve(mux, "GET", "/debug/jobs", "", nil); recorder.Code != http.StatusInternalServerError {
+			t.Errorf("expected the recovery middleware to answer 500, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("Error - not mounted", func(t *testing.T) {
+		mux := adapterhttp.NewRouter(adapterhttp.Handlers{}).InitRoutes()
+
+		if recorder := serve(mux, "GET", "/debug/jobs", "", nil); recorder.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %v", recorder.Code)
+		}
+	})
+}
@@ -0,0 +1,345 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/usecase"
+	"unicode/utf8"
+)
+
+type SocketConfig struct {
+	// PingInterval is how often the server pings; a client that sends
+	// nothing, pongs included, for two intervals is disconnected.
+	PingInterval time.Duration
+	// SendBuffer is how many replies and events may wait for a slow client.
+	// Once it is full the connection stops reading commands, and a
+	// subscription that falls further behind is closed with 1013.
+	SendBuffer int
+	// AllowedOrigins lists the origins, besides the server's own, whose
+	// pages may open a connection, e.g. "https://app.example.com".
+	AllowedOrigins []string
+}
+
+// defaultPingInterval replaces a ping interval that is not positive.
+const defaultPingInterval = 30 * time.Second
+
+type SocketHandler struct {
+	log          *slog.Logger
+	feed         *Feed
+	createTodoUC *usecase.CreateTodoUC
+	updateTodoUC *usecase.UpdateTodoUC
+	deleteTodoUC *usecase.DeleteTodoUC
+	cfg          SocketConfig
+}
+
+func NewSocketHandler(
+	log *slog.Logger,
+	feed *Feed,
+	createTodoUC *usecase.CreateTodoUC,
+	updateTodoUC *usecase.UpdateTodoUC,
+	deleteTodoUC *usecase.DeleteTodoUC,
+	cfg SocketConfig,
+) *SocketHandler {
+	if cfg.PingInterval <= 0 {
+		cfg.PingInterval = defaultPingInterval
+	}
+	return &SocketHandler{
+		log:          log,
+		feed:         feed,
+		createTodoUC: createTodoUC,
+		updateTodoUC: updateTodoUC,
+		deleteTodoUC: deleteTodoUC,
+		cfg:          cfg,
+	}
+}
+
+// socketCommand is a client message. ID is echoed in the reply so that a
+// client can have several commands in flight.
+type socketCommand struct {
+	ID          string   `json:"id"`
+	Type        string   `json:"type"`
+	Todo        dto.Todo `json:"todo"`
+	LastEventID string   `json:"last_event_id"`
+}
+
+// socketMessage is a server message: "result" or "error" answers a command,
+// "event" and "reset" come from a subscription.
+type socketMessage struct {
+	ID      string       `json:"id,omitempty"`
+	Type    string       `json:"type"`
+	Result  any          `json:"result,omitempty"`
+	Error   *socketError `json:"error,omitempty"`
+	EventID string       `json:"event_id,omitempty"`
+	Event   string       `json:"event,omitempty"`
+	Todo    *dto.Todo    `json:"todo,omitempty"`
+}
+
+type socketError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+type socketSession struct {
+	h      *SocketHandler
+	conn   *wsConn
+	ctx    context.Context
+	cancel context.CancelFunc
+	out    chan socketMessage
+	pongs  chan []byte
+
+	mu    sync.Mutex
+	sub   *FeedSubscription
+	close *wsCloseError
+}
+
+// Serve upgrades GET /ws to a WebSocket that accepts create, update,
+// delete, subscribe and unsubscribe commands. The connection is closed with
+// 1001 when the server shuts down.
+func (h *SocketHandler) Serve(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgradeWebSocket(w, r, h.cfg.AllowedOrigins)
+	if err != nil {
+		h.log.WarnContext(r.Context(), "failed to upgrade websocket", slog.Any("err", err))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	s := &socketSession{
+		h:      h,
+		conn:   conn,
+		ctx:    ctx,
+		cancel: cancel,
+		out:    make(chan socketMessage, max(h.cfg.SendBuffer, 1)),
+		pongs:  make(chan []byte, 1),
+	}
+
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		s.write()
+	}()
+
+	err = s.read()
+	var closeErr *wsCloseError
+	if !errors.As(err, &closeErr) {
+		closeErr = &wsCloseError{Code: wsCloseGoingAway}
+	}
+	s.shut(closeErr)
+	<-written
+	s.unsubscribe()
+
+	h.log.DebugContext(r.Context(), "websocket closed", slog.Int("code", s.close.Code), slog.Any("err", err))
+}
+
+// shut makes the writer send a close frame with the first code given.
+func (s *socketSession) shut(err *wsCloseError) {
+	s.mu.Lock()
+	if s.close == nil {
+		s.close = err
+	}
+	s.mu.Unlock()
+	s.cancel()
+}
+
+// write owns the outgoing side of the connection and closes it on return,
+// which also ends read.
+func (s *socketSession) write() {
+	defer s.conn.conn.Close()
+
+	ping := time.NewTicker(s.h.cfg.PingInterval)
+	defer ping.Stop()
+
+	for {
+		var err error
+		select {
+		case <-s.ctx.Done():
+			s.mu.Lock()
+			closeErr := s.close
+			s.mu.Unlock()
+			if closeErr == nil {
+				closeErr = &wsCloseError{Code: wsCloseGoingAway, Reason: "server shutting down"}
+			}
+			_ = s.conn.writeClose(closeErr.Code, closeErr.Reason)
+			return
+		case payload := <-s.pongs:
+			err = s.conn.writeFrame(wsPong, payload)
+		case <-ping.C:
+			err = s.conn.writeFrame(wsPing, nil)
+		case msg := <-s.out:
+			var data []byte
+			if data, err = json.Marshal(msg); err == nil {
+				err = s.conn.writeFrame(wsText, data)
+			}
+		}
+		if err != nil {
+			s.cancel()
+			return
+		}
+	}
+}
+
+// read assembles messages and runs the commands in order. It returns the
+// error to close the connection with.
+func (s *socketSession) read() error {
+	var message []byte
+	var fragmented bool
+
+	for {
+		_ = s.conn.conn.SetReadDeadline(time.Now().Add(2 * s.h.cfg.PingInterval))
+		frame, err := s.conn.readFrame(wsMaxMessage - int64(len(message)))
+		if err != nil {
+			return err
+		}
+
+		switch frame.opcode {
+		case wsPing:
+			select {
+			case s.pongs <- frame.payload:
+			default: // a pong for the latest ping is enough
+			}
+			continue
+		case wsPong:
+			continue
+		case wsClose:
+			code := closeCode(frame.payload)
+			return &wsCloseError{Code: code}
+		case wsText, wsBinary:
+			if fragmented {
+				return &wsCloseError{Code: wsCloseProtocolError, Reason: "expected a continuation frame"}
+			}
+			if frame.opcode == wsBinary {
+				return &wsCloseError{Code: wsCloseUnsupported, Reason: "only text messages are accepted"}
+			}
+			message = frame.payload
+		case wsContinuation:
+			if !fragmented {
+				return &wsCloseError{Code: wsCloseProtocolError, Reason: "unexpected continuation frame"}
+			}
+			message = append(message, frame.payload...)
+		default:
+			return &wsCloseError{Code: wsCloseProtocolError, Reason: "unknown opcode"}
+		}
+
+		fragmented = !frame.fin
+		if fragmented {
+			continue
+		}
+		if !utf8.Valid(message) {
+			return &wsCloseError{Code: wsCloseInvalidData, Reason: "invalid utf-8"}
+		}
+
+		var cmd socketCommand
+		if err := json.Unmarshal(message, &cmd); err != nil {
+			s.send(socketMessage{Type: "error", Error: &socketError{Status: http.StatusBadRequest, Message: "invalid message"}})
+		} else {
+			s.execute(cmd)
+		}
+		message = nil
+		if s.ctx.Err() != nil {
+			return s.ctx.Err()
+		}
+	}
+}
+
+// send queues msg, waiting while the client is slow to read.
+func (s *socketSession) send(msg socketMessage) {
+	select {
+	case s.out <- msg:
+	case <-s.ctx.Done():
+	}
+}
+
+func (s *socketSession) execute(cmd socketCommand) {
+	var result any
+	var err error
+
+	switch cmd.Type {
+	case "create":
+		var response dto.CreateTodoResponse
+		if response, err = s.h.createTodoUC.Execute(s.ctx, dto.CreateTodo{Todo: cmd.Todo}); err == nil {
+			s.h.log.InfoContext(s.ctx, "created todo", slog.Int("id", int(response.ID)))
+			result = response
+		}
+	case "update":
+		var response dto.UpdateTodoResponse
+		if response, err = s.h.updateTodoUC.Execute(s.ctx, dto.UpdateTodo{Todo: cmd.Todo}); err == nil {
+			s.h.log.InfoContext(s.ctx, "updated todo", slog.Int("id", int(response.ID)))
+			result = response
+		}
+	case "delete":
+		var response dto.DeleteTodoResponse
+		input := dto.DeleteTodo{ID: cmd.Todo.ID, Version: cmd.Todo.Version}
+		if response, err = s.h.deleteTodoUC.Execute(s.ctx, input); err == nil {
+			s.h.log.InfoContext(s.ctx, "deleted todo", slog.Int("id", int(response.ID)))
+			result = response
+		}
+	case "subscribe":
+		s.subscribe(cmd)
+		return
+	case "unsubscribe":
+		s.unsubscribe()
+		result = map[string]bool{"subscribed": false}
+	default:
+		s.send(socketMessage{ID: cmd.ID, Type: "error", Error: &socketError{Status: http.StatusBadRequest, Message: "unknown command type"}})
+		return
+	}
+
+	if err != nil {
+		status, msg, internalErr := HttpError(err)
+		s.h.log.ErrorContext(s.ctx, "failed to "+cmd.Type+" todo",
+			slog.Int("status", status),
+			slog.String("public_msg", msg),
+			slog.Any("cause", internalErr),
+		)
+		s.send(socketMessage{ID: cmd.ID, Type: "error", Error: &socketError{Status: status, Message: msg}})
+		return
+	}
+	s.send(socketMessage{ID: cmd.ID, Type: "result", Result: result})
+}
+
+// subscribe replaces any earlier subscription. The reply comes first, then
+// the events after cmd.LastEventID or a reset, then the live events.
+func (s *socketSession) subscribe(cmd socketCommand) {
+	s.unsubscribe()
+
+	sub, backlog, ok := s.h.feed.Subscribe(cmd.LastEventID, feedLag)
+	s.mu.Lock()
+	s.sub = sub
+	s.mu.Unlock()
+
+	s.send(socketMessage{ID: cmd.ID, Type: "result", Result: map[string]bool{"subscribed": true}})
+	if !ok {
+		s.send(socketMessage{Type: "reset", EventID: sub.Head})
+	}
+	for _, event := range backlog {
+		s.send(eventMessage(event))
+	}
+
+	go func() {
+		for event := range sub.C {
+			s.send(eventMessage(event))
+		}
+		if sub.Lagged() {
+			s.shut(&wsCloseError{Code: wsCloseTryAgainLater, Reason: "too slow, subscribe again with last_event_id"})
+		}
+	}()
+}
+
+func (s *socketSession) unsubscribe() {
+	s.mu.Lock()
+	sub := s.sub
+	s.sub = nil
+	s.mu.Unlock()
+	if sub != nil {
+		sub.Close()
+	}
+}
+
+func eventMessage(event FeedEvent) socketMessage {
+	return socketMessage{Type: "event", EventID: event.ID, Event: string(event.Type), Todo: &event.Todo}
+}
@@ -0,0 +1,369 @@
+package http_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+	adapterhttp "todo-api/internal/adapter/in/http"
+	"todo-api/internal/adapter/out/clock"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/eventbus"
+	"todo-api/internal/app/usecase"
+)
+
+// wsClient is just enough of an RFC 6455 client to drive /ws.
+type wsClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+type wsReply struct {
+	ID      string          `json:"id"`
+	Type    string          `json:"type"`
+	Result  json.RawMessage `json:"result"`
+	Error   *struct{ Status int }
+	EventID string `json:"event_id"`
+	Event   string `json:"event"`
+	Todo    *struct {
+		ID    int64  `json:"id"`
+		Title string `json:"title"`
+	} `json:"todo"`
+}
+
+func dialWS(t *testing.T, serverURL string) *wsClient {
+	t.Helper()
+	client, response := handshakeWS(t, serverURL, "")
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected status 101, got %v", response.StatusCode)
+	}
+	return client
+}
+
+// handshakeWS sends the opening handshake, with origin unless it is empty,
+// and returns the server's response to it.
+func handshakeWS(t *testing.T, serverURL, origin string) (*wsClient, *http.Response) {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(serverURL, "http://"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	nonce := make([]byte, 16)
+	_, _ = rand.Read(nonce)
+	key := base64.StdEncoding.EncodeToString(nonce)
+	request, _ := http.NewRequest("GET", serverURL+"/ws", nil)
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Upgrade", "websocket")
+	request.Header.Set("Sec-WebSocket-Version", "13")
+	request.Header.Set("Sec-WebSocket-Key", key)
+	if origin != "" {
+		request.Header.Set("Origin", origin)
+	}
+	if err := request.Write(conn); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	r := bufio.NewReader(conn)
+	response, err := http.ReadResponse(r, request)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if response.StatusCode == http.StatusSwitchingProtocols {
+		sum := sha1.Sum([]byte(key + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+		if accept := response.Header.Get("Sec-WebSocket-Accept"); accept != base64.StdEncoding.EncodeToString(sum[:]) {
+			t.Fatalf("expected a valid Sec-WebSocket-Accept, got %q", accept)
+		}
+	}
+	return &wsClient{t: t, conn: conn, r: r}, response
+}
+
+func (c *wsClient) writeFrame(fin bool, opcode byte, payload []byte) {
+	c.t.Helper()
+	head := []byte{opcode, 0x80}
+	if fin {
+		head[0] |= 0x80
+	}
+	switch n := len(payload); {
+	case n <= 125:
+		head[1] |= byte(n)
+	case n <= 0xFFFF:
+		head[1] |= 126
+		head = binary.BigEndian.AppendUint16(head, uint16(n))
+	default:
+		head[1] |= 127
+		head = binary.BigEndian.AppendUint64(head, uint64(n))
+	}
+	mask := []byte{1, 2, 3, 4}
+	masked := make([]byte, len(payload))
+	for i, b := range payload {
+		masked[i] = b ^ mask[i%4]
+	}
+	if _, err := c.conn.Write(append(append(head, mask...), masked...)); err != nil {
+		c.t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func (c *wsClient) send(command string) {
+	c.t.Helper()
+	c.writeFrame(true, 0x1, []byte(command))
+}
+
+// readFrame returns the next frame from the server.
+func (c *wsClient) readFrame() (byte, []byte) {
+	c.t.Helper()
+	_ = c.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var head [2]byte
+	if _, err := io.ReadFull(c.r, head[:]); err != nil {
+		c.t.Fatalf("expected no error, got %v", err)
+	}
+	if head[1]&0x80 != 0 {
+		c.t.Fatalf("expected an unmasked frame")
+	}
+	length := uint64(head[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		_, _ = io.ReadFull(c.r, ext[:])
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		_, _ = io.ReadFull(c.r, ext[:])
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		c.t.Fatalf("expected no error, got %v", err)
+	}
+	return head[0] & 0x0F, payload
+}
+
+// next returns the next message, answering and skipping pings.
+func (c *wsClient) next() wsReply {
+	c.t.Helper()
+	for {
+		opcode, payload := c.readFrame()
+		switch opcode {
+		case 0x9:
+			c.writeFrame(true, 0xA, payload)
+		case 0x1:
+			var reply wsReply
+			if err := json.Unmarshal(payload, &reply); err != nil {
+				c.t.Fatalf("expected no error, got %v", err)
+			}
+			return reply
+		default:
+			c.t.Fatalf("expected a text message, got opcode %x: %q", opcode, payload)
+		}
+	}
+}
+
+// closed waits for the server's close frame and returns its code.
+func (c *wsClient) closed() int {
+	c.t.Helper()
+	for {
+		opcode, payload := c.readFrame()
+		if opcode == 0x8 {
+			if len(payload) < 2 {
+				return 1005
+			}
+			return int(binary.BigEndian.Uint16(payload))
+		}
+	}
+}
+
+func TestTH_Socket(t *testing.T) {
+	store := storage.NewDataStorage()
+	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	feed := adapterhttp.NewFeed(16)
+	bus := eventbus.New(testLogger, eventbus.Config{})
+	bus.Subscribe("feed", eventbus.Sync, feed.Handle)
+
+	createTodoUC := usecase.NewCreateTodoUC(store, store, clock.System{}, bus)
+	updateTodoUC := usecase.NewUpdateTodoUC(store, store, clock.System{}, bus, usecase.SubtaskCompleteAllow)
+	deleteTodoUC := usecase.NewDeleteTodoUC(store, bus)
+
+	socket := adapterhttp.NewSocketHandler(testLogger, feed, createTodoUC, updateTodoUC, deleteTodoUC, adapterhttp.SocketConfig{
+		PingInterval:   100 * time.Millisecond,
+		SendBuffer:     8,
+		AllowedOrigins: []string{"https://app.example.com"},
+	})
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Socket: socket}).InitRoutes()
+
+	baseCtx, shutdown := context.WithCancel(context.Background())
+	defer shutdown()
+	server := httptest.NewUnstartedServer(mux)
+	server.Config.BaseContext = func(net.Listener) context.Context { return baseCtx }
+	server.Start()
+	defer server.Close()
+
+	var lastEventID string
+
+	t.Run("Success", func(t *testing.T) {
+		watcher := dialWS(t, server.URL)
+		watcher.send(`{"id": "s1", "type": "subscribe"}`)
+		if reply := watcher.next(); reply.ID != "s1" || reply.Type != "result" {
+			t.Fatalf("expected the subscribe result, got %+v", reply)
+		}
+
+		writer := dialWS(t, server.URL)
+		writer.send(`{"id": "c1", "type": "create", "todo": {"title": "Pay rent"}}`)
+		if reply := writer.next(); reply.ID != "c1" || reply.Type != "result" || string(reply.Result) != `{"id":1,"version":1}` {
+			t.Fatalf("expected the create result, got %+v", reply)
+		}
+		if reply := watcher.next(); reply.Event != "todo.created" || reply.Todo == nil || reply.Todo.Title != "Pay rent" {
+			t.Fatalf("expected a todo.created event, got %+v", reply)
+		}
+
+		// Fragmented, with a ping in between.
+		command := `{"id": "u1", "type": "update", "todo": {"id": 1, "title": "Pay the rent", "version": 1}}`
+		writer.writeFrame(false, 0x1, []byte(command[:20]))
+		writer.writeFrame(true, 0x9, []byte("hi"))
+		writer.writeFrame(true, 0x0, []byte(command[20:]))
+		if opcode, payload := writer.readFrame(); opcode != 0xA || string(payload) != "hi" {
+			t.Errorf("expected a pong, got %x %q", opcode, payload)
+		}
+		if reply := writer.next(); reply.ID != "u1" || reply.Type != "result" {
+			t.Fatalf("expected the update result, got %+v", reply)
+		}
+		event := watcher.next()
+		if event.Event != "todo.updated" || event.Todo.Title != "Pay the rent" {
+			t.Fatalf("expected a todo.updated event, got %+v", event)
+		}
+		lastEventID = event.EventID
+
+		writer.send(`{"id": "d1", "type": "delete", "todo": {"id": 1}}`)
+		if reply := writer.next(); reply.ID != "d1" || reply.Type != "result" {
+			t.Fatalf("expected the delete result, got %+v", reply)
+		}
+		if reply := watcher.next(); reply.Event != "todo.deleted" {
+			t.Errorf("expected a todo.deleted event, got %+v", reply)
+		}
+
+		watcher.send(`{"id": "s2", "type": "unsubscribe"}`)
+		if reply := watcher.next(); reply.ID != "s2" || string(reply.Result) != `{"subscribed":false}` {
+			t.Errorf("expected the unsubscribe result, got %+v", reply)
+		}
+	})
+
+	t.Run("Success - resume", func(t *testing.T) {
+		client := dialWS(t, server.URL)
+		client.send(`{"id": "s1", "type": "subscribe", "last_event_id": "` + lastEventID + `"}`)
+		if reply := client.next(); reply.ID != "s1" {
+			t.Fatalf("expected the subscribe result, got %+v", reply)
+		}
+		if reply := client.next(); reply.Event != "todo.deleted" {
+			t.Errorf("expected the missed todo.deleted, got %+v", reply)
+		}
+
+		client.send(`{"id": "s2", "type": "subscribe", "last_event_id": "0-1"}`)
+		if reply := client.next(); reply.ID != "s2" {
+			t.Fatalf("expected the subscribe result, got %+v", reply)
+		}
+		if reply := client.next(); reply.Type != "reset" || reply.EventID == "" {
+			t.Errorf("expected a reset, got %+v", reply)
+		}
+	})
+
+	t.Run("Success - keepalive", func(t *testing.T) {
+		client := dialWS(t, server.URL)
+		if opcode, _ := client.readFrame(); opcode != 0x9 {
+			t.Errorf("expected a ping, got %x", opcode)
+		}
+		client.writeFrame(true, 0x8, binary.BigEndian.AppendUint16(nil, 1000))
+		if code := client.closed(); code != 1000 {
+			t.Errorf("expected close 1000, got %v", code)
+		}
+	})
+
+	t.Run("Error - command fails", func(t *testing.T) {
+		client := dialWS(t, server.URL)
+		for command, status := range map[string]int{
+			`{"id": "x", "type": "create", "todo": {"title": ""}}`:                  http.StatusBadRequest,
+			`{"id": "x", "type": "update", "todo": {"id": 42, "title": "Missing"}}`: http.StatusNotFound,
+			`{"id": "x", "type": "rename"}`:                                         http.StatusBadRequest,
+			`{"id": `:                                                               http.StatusBadRequest,
+		} {
+			client.send(command)
+			if reply := client.next(); reply.Type != "error" || reply.Error == nil || reply.Error.Status != status {
+				t.Errorf("%s: expected error %v, got %+v", command, status, reply)
+			}
+		}
+	})
+
+	t.Run("Error - protocol", func(t *testing.T) {
+		client := dialWS(t, server.URL)
+		_, _ = client.conn.Write([]byte{0x81, 0x02, 'h', 'i'}) // unmasked
+		if code := client.closed(); code != 1002 {
+			t.Errorf("expected close 1002, got %v", code)
+		}
+
+		client = dialWS(t, server.URL)
+		client.writeFrame(true, 0x2, []byte{1, 2, 3})
+		if code := client.closed(); code != 1003 {
+			t.Errorf("expected close 1003, got %v", code)
+		}
+	})
+
+	t.Run("Error - not an upgrade", func(t *testing.T) {
+		response, err := http.Get(server.URL + "/ws")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		_ = response.Body.Close()
+		if response.StatusCode != http.StatusUpgradeRequired {
+			t.Errorf("expected status 426, got %v", response.StatusCode)
+		}
+	})
+
+	t.Run("Success - origin", func(t *testing.T) {
+		for _, origin := range []string{server.URL, "https://app.example.com"} {
+			if _, response := handshakeWS(t, server.URL, origin); response.StatusCode != http.StatusSwitchingProtocols {
+				t.Errorf("origin %s: expected status 101, got %v", origin, response.StatusCode)
+			}
+		}
+	})
+
+	t.Run("Error - cross-site origin", func(t *testing.T) {
+		for _, origin := range []string{"https://evil.example.com", "null"} {
+			if _, response := handshakeWS(t, server.URL, origin); response.StatusCode != http.StatusForbidden {
+				t.Errorf("origin %s: expected status 403, got %v", origin, response.StatusCode)
+			}
+		}
+	})
+
+	t.Run("Success - shutdown", func(t *testing.T) {
+		client := dialWS(t, server.URL)
+		shutdown()
+		if code := client.closed(); code != 1001 {
+			t.Errorf("expected close 1001, got %v", code)
+		}
+	})
+}
+
+func TestTH_SocketWithoutPingInterval(t *testing.T) {
+	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	socket := adapterhttp.NewSocketHandler(testLogger, adapterhttp.NewFeed(16), nil, nil, nil, adapterhttp.SocketConfig{})
+	server := httptest.NewServer(adapterhttp.NewRouter(adapterhttp.Handlers{Socket: socket}).InitRoutes())
+	defer server.Close()
+
+	client := dialWS(t, server.URL)
+	client.writeFrame(true, 0x9, []byte("hi"))
+	if opcode, payload := client.readFrame(); opcode != 0xA || string(payload) != "hi" {
+		t.Errorf("expected a pong, got opcode %#x with %q", opcode, payload)
+	}
+}
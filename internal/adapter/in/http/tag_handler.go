@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/usecase"
+)
+
+type TagHandler struct {
+	log         *slog.Logger
+	getTagsUC   *usecase.GetTagsUC
+	renameTagUC *usecase.RenameTagUC
+}
+
+func NewTagHandler(
+	log *slog.Logger,
+	getTagsUC *usecase.GetTagsUC,
+	renameTagUC *usecase.RenameTagUC,
+) *TagHandler {
+	return &TagHandler{
+		log:         log,
+		getTagsUC:   getTagsUC,
+		renameTagUC: renameTagUC,
+	}
+}
+
+func (h *TagHandler) GetTags(w http.ResponseWriter, r *http.Request) {
+	response, err := h.getTagsUC.Execute(r.Context())
+	if err != nil {
+		status, msg, internalErr := HttpError(err)
+		h.log.ErrorContext(r.Context(), "failed to get tags",
+			slog.Int("status", status),
+			slog.String("public_msg", msg),
+			slog.Any("cause", internalErr),
+		)
+		http.Error(w, msg, status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// RenameTag renames the tag in the path to the name in the body on every
+// todo, merging it into that tag if it is already in use.
+func (h *TagHandler) RenameTag(w http.ResponseWriter, r *http.Request) {
+	var input dto.RenameTag
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	input.Tag = r.PathValue("tag")
+
+	response, err := h.renameTagUC.Execute(r.Context(), input)
+	if err != nil {
+		status, msg, internalErr := HttpError(err)
+		h.log.ErrorContext(r.Context(), "failed to rename tag",
+			slog.Int("status", status),
+			slog.String("public_msg", msg),
+			slog.Any("cause", internalErr),
+		)
+		http.Error(w, msg, status)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "renamed tag",
+		slog.String("tag", response.Tag),
+		slog.Int("updated", response.Updated),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
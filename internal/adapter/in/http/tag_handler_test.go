@@ -0,0 +1,116 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	adapterhttp "todo-api/internal/adapter/in/http"
+	"todo-api/internal/adapter/out/clock"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/entity"
+)
+
+func TestTH_Tags(t *testing.T) {
+	store := storage.NewDataStorage()
+	for _, todo := range []entity.Todo{
+		{Title: "Buy milk", Tags: []string{"shop"}},
+		{Title: "Fix sink", Tags: []string{"home"}},
+		{Title: "Buy paint", Tags: []string{"home", "shop"}},
+	} {
+		_ = store.CreateTodo(context.Background(), &todo)
+	}
+
+	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	tags := adapterhttp.NewTagHandler(testLogger,
+		usecase.NewGetTagsUC(store),
+		usecase.NewRenameTagUC(store, clock.System{}, nil),
+	)
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store), Tag: tags}).InitRoutes()
+
+	listIDs := func(t *testing.T, target string) string {
+		t.Helper()
+
+		recorder := serve(mux, "GET", target, "", nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+
+		var response dto.GetTodoListResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&response)
+
+		ids := make([]int64, len(response.Todos))
+		for i, todo := range response.Todos {
+			ids[i] = todo.ID
+		}
+		return fmt.Sprint(ids)
+	}
+
+	t.Run("Filter", func(t *testing.T) {
+		if got := listIDs(t, "/todos?tag=home&tag=Shop"); got != "[3]" {
+			t.Errorf("expected [3], got %v", got)
+		}
+		if got := listIDs(t, "/todos?tag=home&tag=shop&tag_mode=any"); got != "[1 2 3]" {
+			t.Errorf("expected [1 2 3], got %v", got)
+		}
+	})
+
+	t.Run("Tag and untag", func(t *testing.T) {
+		recorder := serve(mux, "PUT", "/todos/1/tags/Dairy", "", nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+		var response dto.TagTodoResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&response)
+		if fmt.Sprint(response.Tags) != "[dairy shop]" {
+			t.Errorf("expected [dairy shop], got %v", response.Tags)
+		}
+		if recorder.Header().Get("ETag") == "" {
+			t.Error("expected an ETag")
+		}
+
+		if recorder := serve(mux, "DELETE", "/todos/1/tags/dairy", "", map[string]string{"If-Match": `"1.1"`}); recorder.Code != http.StatusPreconditionFailed {
+			t.Errorf("expected status 412, got %v", recorder.Code)
+		}
+		if recorder := serve(mux, "DELETE", "/todos/1/tags/dairy", "", nil); recorder.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("Rename and list", func(t *testing.T) {
+		recorder := serve(mux, "PATCH", "/tags/shop", `{"name":"home"}`, nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+
+		recorder = serve(mux, "GET", "/tags", "", nil)
+		var response dto.GetTagsResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&response)
+		if fmt.Sprint(response.Tags) != "[{home 3}]" {
+			t.Errorf("expected [{home 3}], got %v", response.Tags)
+		}
+	})
+
+	errorCases := []struct {
+		method, target, body string
+		status               int
+	}{
+		{"PUT", "/todos/99/tags/home", "", http.StatusNotFound},
+		{"PUT", "/todos/1/tags/%20", "", http.StatusBadRequest},
+		{"PATCH", "/tags/garden", `{"name":"yard"}`, http.StatusNotFound},
+		{"GET", "/todos?tag=home&tag_mode=some", "", http.StatusBadRequest},
+	}
+
+	for _, tc := range errorCases {
+		t.Run("Error - "+tc.method+" "+tc.target, func(t *testing.T) {
+			if recorder := serve(mux, tc.method, tc.target, tc.body, nil); recorder.Code != tc.status {
+				t.Errorf("expected status %v, got %v: %s", tc.status, recorder.Code, recorder.Body)
+			}
+		})
+	}
+}
@@ -1,38 +1,78 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
 	"todo-api/internal/app/usecase"
 )
 
 type TodoHandler struct {
-	log           *slog.Logger
-	createTodoUC  *usecase.CreateTodoUC
-	getTodoUC     *usecase.GetTodoUC
-	updateTodoUC  *usecase.UpdateTodoUC
-	deleteTodoUC  *usecase.DeleteTodoUC
-	getTodoListUC *usecase.GetTodoListUC
-}
-
-func NewTodoHandler(
-	log *slog.Logger,
-	createTodoUC *usecase.CreateTodoUC,
-	getTodoUC *usecase.GetTodoUC,
-	updateTodoUC *usecase.UpdateTodoUC,
-	deleteTodoUC *usecase.DeleteTodoUC,
-	getTodoListUC *usecase.GetTodoListUC,
-) *TodoHandler {
+	log             *slog.Logger
+	createTodoUC    *usecase.CreateTodoUC
+	getTodoUC       *usecase.GetTodoUC
+	updateTodoUC    *usecase.UpdateTodoUC
+	deleteTodoUC    *usecase.DeleteTodoUC
+	getTodoListUC   *usecase.GetTodoListUC
+	patchTodoUC     *usecase.PatchTodoUC
+	getNextUC       *usecase.GetNextTodosUC
+	addTagUC        *usecase.AddTodoTagUC
+	removeTagUC     *usecase.RemoveTodoTagUC
+	getTreeUC       *usecase.GetTodoTreeUC
+	addBlockerUC    *usecase.AddTodoBlockerUC
+	removeBlockerUC *usecase.RemoveTodoBlockerUC
+	criticalPathUC  *usecase.GetCriticalPathUC
+	occurrencesUC   *usecase.GetOccurrencesUC
+
+	// RequireIfMatch makes PUT, PATCH and DELETE fail with 428 unless the client
+	// sends an If-Match header.
+	RequireIfMatch bool
+}
+
+// TodoUseCases are the use cases behind the /todos routes.
+type TodoUseCases struct {
+	Create        *usecase.CreateTodoUC
+	Get           *usecase.GetTodoUC
+	Update        *usecase.UpdateTodoUC
+	Delete        *usecase.DeleteTodoUC
+	List          *usecase.GetTodoListUC
+	Patch         *usecase.PatchTodoUC
+	Next          *usecase.GetNextTodosUC
+	AddTag        *usecase.AddTodoTagUC
+	RemoveTag     *usecase.RemoveTodoTagUC
+	Tree          *usecase.GetTodoTreeUC
+	AddBlocker    *usecase.AddTodoBlockerUC
+	RemoveBlocker *usecase.RemoveTodoBlockerUC
+	CriticalPath  *usecase.GetCriticalPathUC
+	Occurrences   *usecase.GetOccurrencesUC
+}
+
+func NewTodoHandler(log *slog.Logger, uc TodoUseCases) *TodoHandler {
 	return &TodoHandler{
-		log:           log,
-		createTodoUC:  createTodoUC,
-		getTodoUC:     getTodoUC,
-		updateTodoUC:  updateTodoUC,
-		deleteTodoUC:  deleteTodoUC,
-		getTodoListUC: getTodoListUC,
+		log:             log,
+		createTodoUC:    uc.Create,
+		getTodoUC:       uc.Get,
+		updateTodoUC:    uc.Update,
+		deleteTodoUC:    uc.Delete,
+		getTodoListUC:   uc.List,
+		patchTodoUC:     uc.Patch,
+		getNextUC:       uc.Next,
+		addTagUC:        uc.AddTag,
+		removeTagUC:     uc.RemoveTag,
+		getTreeUC:       uc.Tree,
+		addBlockerUC:    uc.AddBlocker,
+		removeBlockerUC: uc.RemoveBlocker,
+		criticalPathUC:  uc.CriticalPath,
+		occurrencesUC:   uc.Occurrences,
 	}
 }
 
@@ -86,33 +126,122 @@ func (h *TodoHandler) GetTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	etag := todoETag(response)
+	if notModified(w, r, etag) {
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag)
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
 
 func (h *TodoHandler) GetTodoList(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query()
+	input, err := parseListQuery(r.URL.Query())
+	if err != nil {
+		status, msg, _ := HttpError(err)
+		http.Error(w, msg, status)
+		return
+	}
 
-	limitStr := query.Get("limit")
-	offsetStr := query.Get("offset")
+	response, err := h.getTodoListUC.Execute(r.Context(), input)
+	if err != nil {
+		status, msg, internalErr := HttpError(err)
+		h.log.ErrorContext(r.Context(), "failed to get todo list",
+			slog.Int("status", status),
+			slog.String("public_msg", msg),
+			slog.Any("cause", internalErr),
+		)
+		http.Error(w, msg, status)
+		return
+	}
 
-	limit, _ := strconv.Atoi(limitStr)
-	if limit == 0 {
-		limit = 10
+	writeTodoList(w, r, response)
+}
+
+// GetTodoChildren lists the direct subtasks of a todo and takes the same query
+// parameters as GET /todos.
+func (h *TodoHandler) GetTodoChildren(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid id format", http.StatusBadRequest)
+		return
 	}
 
-	offset, _ := strconv.Atoi(offsetStr)
+	input, err := parseListQuery(r.URL.Query())
+	if err != nil {
+		status, msg, _ := HttpError(err)
+		http.Error(w, msg, status)
+		return
+	}
+	input.ParentID = id
 
-	input := dto.GetTodoList{
-		Limit:  limit,
-		Offset: offset,
+	if _, err := h.getTodoUC.Execute(r.Context(), dto.GetTodo{ID: id}); err != nil {
+		status, msg, internalErr := HttpError(err)
+		h.log.ErrorContext(r.Context(), "failed to get todo",
+			slog.Int("status", status),
+			slog.String("public_msg", msg),
+			slog.Any("cause", internalErr),
+		)
+		http.Error(w, msg, status)
+		return
 	}
 
 	response, err := h.getTodoListUC.Execute(r.Context(), input)
 	if err != nil {
 		status, msg, internalErr := HttpError(err)
-		h.log.ErrorContext(r.Context(), "failed to get todo list",
+		h.log.ErrorContext(r.Context(), "failed to get todo children",
+			slog.Int("status", status),
+			slog.String("public_msg", msg),
+			slog.Any("cause", internalErr),
+		)
+		http.Error(w, msg, status)
+		return
+	}
+
+	writeTodoList(w, r, response)
+}
+
+func (h *TodoHandler) GetTodoTree(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid id format", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.getTreeUC.Execute(r.Context(), dto.GetTodoTree{ID: id})
+	if err != nil {
+		status, msg, internalErr := HttpError(err)
+		h.log.ErrorContext(r.Context(), "failed to get todo tree",
+			slog.Int("status", status),
+			slog.String("public_msg", msg),
+			slog.Any("cause", internalErr),
+		)
+		http.Error(w, msg, status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *TodoHandler) GetNextTodos(w http.ResponseWriter, r *http.Request) {
+	input := dto.GetNextTodos{N: 5}
+	if nStr := r.URL.Query().Get("n"); nStr != "" {
+		n, err := strconv.Atoi(nStr)
+		if err != nil {
+			http.Error(w, "invalid n", http.StatusBadRequest)
+			return
+		}
+		input.N = n
+	}
+
+	response, err := h.getNextUC.Execute(r.Context(), input)
+	if err != nil {
+		status, msg, internalErr := HttpError(err)
+		h.log.ErrorContext(r.Context(), "failed to get next todos",
 			slog.Int("status", status),
 			slog.String("public_msg", msg),
 			slog.Any("cause", internalErr),
@@ -142,8 +271,20 @@ func (h *TodoHandler) UpdateTodo(w http.ResponseWriter, r *http.Request) {
 
 	input.ID = id
 
+	version, conditional, ok := h.checkIfMatch(w, r, id)
+	if !ok {
+		return
+	}
+	if conditional {
+		input.Version = version
+	}
+
 	response, err := h.updateTodoUC.Execute(r.Context(), input)
 	if err != nil {
+		if conditional && errors.Is(err, uc_errors.TodoVersionConflictError) {
+			http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+			return
+		}
 		status, msg, internalErr := HttpError(err)
 		h.log.ErrorContext(r.Context(), "failed to update todo",
 			slog.Int("status", status),
@@ -159,6 +300,69 @@ func (h *TodoHandler) UpdateTodo(w http.ResponseWriter, r *http.Request) {
 	)
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", todoETag(response.View))
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *TodoHandler) PatchTodo(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "invalid id format", http.StatusBadRequest)
+		return
+	}
+
+	input := dto.PatchTodo{ID: id}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/merge-patch+json":
+		input.Format = dto.PatchFormatMerge
+	case "application/json-patch+json":
+		input.Format = dto.PatchFormatJSON
+	default:
+		w.Header().Set("Accept-Patch", "application/merge-patch+json, application/json-patch+json")
+		http.Error(w, uc_errors.UnsupportedPatchError.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	input.Patch, err = io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	version, conditional, ok := h.checkIfMatch(w, r, id)
+	if !ok {
+		return
+	}
+	if conditional {
+		input.Version = version
+	}
+
+	response, err := h.patchTodoUC.Execute(r.Context(), input)
+	if err != nil {
+		if conditional && errors.Is(err, uc_errors.TodoVersionConflictError) {
+			http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+			return
+		}
+		status, msg, internalErr := HttpError(err)
+		h.log.ErrorContext(r.Context(), "failed to patch todo",
+			slog.Int("status", status),
+			slog.String("public_msg", msg),
+			slog.Any("cause", internalErr),
+		)
+		http.Error(w, msg, status)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "patched todo",
+		slog.Int("id", int(response.ID)),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", todoETag(response.GetTodoResponse))
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -173,8 +377,28 @@ func (h *TodoHandler) DeleteTodo(w http.ResponseWriter, r *http.Request) {
 
 	var input = dto.DeleteTodo{ID: id}
 
+	if versionStr := r.URL.Query().Get("version"); versionStr != "" {
+		input.Version, err = strconv.ParseInt(versionStr, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid version format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	version, conditional, ok := h.checkIfMatch(w, r, id)
+	if !ok {
+		return
+	}
+	if conditional {
+		input.Version = version
+	}
+
 	response, err := h.deleteTodoUC.Execute(r.Context(), input)
 	if err != nil {
+		if conditional && errors.Is(err, uc_errors.TodoVersionConflictError) {
+			http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+			return
+		}
 		status, msg, internalErr := HttpError(err)
 		h.log.ErrorContext(r.Context(), "failed to delete todo",
 			slog.Int("status", status),
@@ -193,3 +417,214 @@ func (h *TodoHandler) DeleteTodo(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
+
+func (h *TodoHandler) AddTodoTag(w http.ResponseWriter, r *http.Request) {
+	h.tagTodo(w, r, "add todo tag", h.addTagUC.Execute)
+}
+
+func (h *TodoHandler) RemoveTodoTag(w http.ResponseWriter, r *http.Request) {
+	h.tagTodo(w, r, "remove todo tag", h.removeTagUC.Execute)
+}
+
+func (h *TodoHandler) tagTodo(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+	execute func(context.Context, dto.TagTodo) (dto.TagTodoResponse, error),
+) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "invalid id format", http.StatusBadRequest)
+		return
+	}
+
+	input := dto.TagTodo{ID: id, Tag: r.PathValue("tag")}
+
+	version, conditional, ok := h.checkIfMatch(w, r, id)
+	if !ok {
+		return
+	}
+	if conditional {
+		input.Version = version
+	}
+
+	response, err := execute(r.Context(), input)
+	if err != nil {
+		if conditional && errors.Is(err, uc_errors.TodoVersionConflictError) {
+			http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+			return
+		}
+		status, msg, internalErr := HttpError(err)
+		h.log.ErrorContext(r.Context(), "failed to "+action,
+			slog.Int("status", status),
+			slog.String("public_msg", msg),
+			slog.Any("cause", internalErr),
+		)
+		http.Error(w, msg, status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", todoETag(response.GetTodoResponse))
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *TodoHandler) AddTodoBlocker(w http.ResponseWriter, r *http.Request) {
+	h.blockTodo(w, r, "add todo blocker", h.addBlockerUC.Execute)
+}
+
+func (h *TodoHandler) RemoveTodoBlocker(w http.ResponseWriter, r *http.Request) {
+	h.blockTodo(w, r, "remove todo blocker", h.removeBlockerUC.Execute)
+}
+
+func (h *TodoHandler) blockTodo(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+	execute func(context.Context, dto.BlockTodo) (dto.BlockTodoResponse, error),
+) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "invalid id format", http.StatusBadRequest)
+		return
+	}
+	blockerID, err := strconv.ParseInt(r.PathValue("blocker"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid blocker id format", http.StatusBadRequest)
+		return
+	}
+
+	input := dto.BlockTodo{ID: id, BlockerID: blockerID}
+
+	version, conditional, ok := h.checkIfMatch(w, r, id)
+	if !ok {
+		return
+	}
+	if conditional {
+		input.Version = version
+	}
+
+	response, err := execute(r.Context(), input)
+	if err != nil {
+		if conditional && errors.Is(err, uc_errors.TodoVersionConflictError) {
+			http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+			return
+		}
+		status, msg, internalErr := HttpError(err)
+		h.log.ErrorContext(r.Context(), "failed to "+action,
+			slog.Int("status", status),
+			slog.String("public_msg", msg),
+			slog.Any("cause", internalErr),
+		)
+		http.Error(w, msg, status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", todoETag(response.GetTodoResponse))
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *TodoHandler) GetCriticalPath(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid id format", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.criticalPathUC.Execute(r.Context(), dto.GetCriticalPath{ID: id})
+	if err != nil {
+		status, msg, internalErr := HttpError(err)
+		h.log.ErrorContext(r.Context(), "failed to get critical path",
+			slog.Int("status", status),
+			slog.String("public_msg", msg),
+			slog.Any("cause", internalErr),
+		)
+		http.Error(w, msg, status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *TodoHandler) GetOccurrences(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid id format", http.StatusBadRequest)
+		return
+	}
+
+	input := dto.GetOccurrences{ID: id}
+	if from := r.URL.Query().Get("from"); from != "" {
+		if input.From, err = time.Parse(time.RFC3339, from); err != nil {
+			http.Error(w, "from must be an RFC 3339 time", http.StatusBadRequest)
+			return
+		}
+	}
+	if to := r.URL.Query().Get("to"); to != "" {
+		if input.To, err = time.Parse(time.RFC3339, to); err != nil {
+			http.Error(w, "to must be an RFC 3339 time", http.StatusBadRequest)
+			return
+		}
+	}
+
+	response, err := h.occurrencesUC.Execute(r.Context(), input)
+	if err != nil {
+		status, msg, internalErr := HttpError(err)
+		h.log.ErrorContext(r.Context(), "failed to get occurrences",
+			slog.Int("status", status),
+			slog.String("public_msg", msg),
+			slog.Any("cause", internalErr),
+		)
+		http.Error(w, msg, status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// checkIfMatch evaluates If-Match against the current todo. On success it
+// returns that todo's version so the write can be guarded against changes
+// made after the check; ok is false once a response has been written.
+func (h *TodoHandler) checkIfMatch(w http.ResponseWriter, r *http.Request, id int64) (version int64, conditional, ok bool) {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		if h.RequireIfMatch {
+			http.Error(w, "If-Match header is required", http.StatusPreconditionRequired)
+			return 0, false, false
+		}
+		return 0, false, true
+	}
+
+	current, err := h.getTodoUC.Execute(r.Context(), dto.GetTodo{ID: id})
+	if err != nil {
+		// "*" matches any current representation, and there is none.
+		if errors.Is(err, uc_errors.TodoNotFoundError) && strings.TrimSpace(header) == "*" {
+			http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+			return 0, true, false
+		}
+		status, msg, internalErr := HttpError(err)
+		h.log.ErrorContext(r.Context(), "failed to check precondition",
+			slog.Int("status", status),
+			slog.String("public_msg", msg),
+			slog.Any("cause", internalErr),
+		)
+		http.Error(w, msg, status)
+		return 0, true, false
+	}
+
+	if !etagMatches(header, todoETag(current), false) {
+		http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+		return 0, true, false
+	}
+
+	return current.Version, true, true
+}
@@ -11,7 +11,9 @@ import (
 	"strings"
 	"testing"
 	adapterhttp "todo-api/internal/adapter/in/http"
+	"todo-api/internal/adapter/out/clock"
 	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/cursor"
 	"todo-api/internal/app/dto"
 	"todo-api/internal/app/usecase"
 	"todo-api/internal/domain/entity"
@@ -19,18 +21,11 @@ import (
 
 func TestTH_Create(t *testing.T) {
 	store := storage.NewDataStorage()
-	uc := usecase.NewCreateTodoUC(store)
+	uc := usecase.NewCreateTodoUC(store, store, clock.System{}, nil)
 	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
-	handler := adapterhttp.NewTodoHandler(
-		testLogger,
-		uc,
-		nil,
-		nil,
-		nil,
-		nil,
-	)
-
-	router := adapterhttp.NewRouter(handler)
+	handler := adapterhttp.NewTodoHandler(testLogger, adapterhttp.TodoUseCases{Create: uc})
+
+	router := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: handler})
 	mux := router.InitRoutes()
 
 	t.Run("Success", func(t *testing.T) {
@@ -114,8 +109,8 @@ func TestTH_Get(t *testing.T) {
 
 	guc := usecase.NewGetTodoUC(store)
 	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
-	handler := adapterhttp.NewTodoHandler(testLogger, nil, guc, nil, nil, nil)
-	router := adapterhttp.NewRouter(handler)
+	handler := adapterhttp.NewTodoHandler(testLogger, adapterhttp.TodoUseCases{Get: guc})
+	router := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: handler})
 	mux := router.InitRoutes()
 
 	t.Run("Success", func(t *testing.T) {
@@ -186,10 +181,10 @@ func TestTH_GetList(t *testing.T) {
 		Description: "using ai tools, youtube videos",
 	})
 
-	gluc := usecase.NewGetTodoListUC(store)
+	gluc := usecase.NewGetTodoListUC(store, cursor.NewCodec([]byte("secret")), clock.System{})
 	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
-	handler := adapterhttp.NewTodoHandler(testLogger, nil, nil, nil, nil, gluc)
-	router := adapterhttp.NewRouter(handler)
+	handler := adapterhttp.NewTodoHandler(testLogger, adapterhttp.TodoUseCases{List: gluc})
+	router := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: handler})
 	mux := router.InitRoutes()
 
 	t.Run("Success", func(t *testing.T) {
@@ -274,10 +269,10 @@ func TestTH_Update(t *testing.T) {
 		Description: "using ai tools, youtube videos",
 	})
 
-	uuc := usecase.NewUpdateTodoUC(store)
+	uuc := usecase.NewUpdateTodoUC(store, store, clock.System{}, nil, usecase.SubtaskCompleteAllow)
 	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
-	handler := adapterhttp.NewTodoHandler(testLogger, nil, nil, uuc, nil, nil)
-	router := adapterhttp.NewRouter(handler)
+	handler := adapterhttp.NewTodoHandler(testLogger, adapterhttp.TodoUseCases{Update: uuc})
+	router := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: handler})
 	mux := router.InitRoutes()
 
 	t.Run("Success", func(t *testing.T) {
@@ -354,6 +349,20 @@ func TestTH_Update(t *testing.T) {
 		}
 	})
 
+	t.Run("Error - Version conflict", func(t *testing.T) {
+		reqBody := `{
+						"title": "Stale Title",
+						"version": 99
+					}`
+		request := httptest.NewRequest("PUT", fmt.Sprintf("/todos/%d", targetID), strings.NewReader(reqBody))
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+
+		if recorder.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %v", recorder.Code)
+		}
+	})
+
 	t.Run("Error - Todo not found", func(t *testing.T) {
 		reqBody := `{
 						"title": "New Title"
@@ -378,10 +387,10 @@ func TestTH_Delete(t *testing.T) {
 		Description: "using ai tools, youtube videos",
 	})
 
-	duc := usecase.NewDeleteTodoUC(store)
+	duc := usecase.NewDeleteTodoUC(store, nil)
 	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
-	handler := adapterhttp.NewTodoHandler(testLogger, nil, nil, nil, duc, nil)
-	router := adapterhttp.NewRouter(handler)
+	handler := adapterhttp.NewTodoHandler(testLogger, adapterhttp.TodoUseCases{Delete: duc})
+	router := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: handler})
 	mux := router.InitRoutes()
 
 	t.Run("Success", func(t *testing.T) {
@@ -424,6 +433,29 @@ func TestTH_Delete(t *testing.T) {
 		}
 	})
 
+	t.Run("Error - Version conflict", func(t *testing.T) {
+		todo := &entity.Todo{Title: "Learn physics"}
+		_ = store.CreateTodo(context.Background(), todo)
+
+		request := httptest.NewRequest("DELETE", fmt.Sprintf("/todos/%d?version=%d", todo.ID, todo.Version+1), nil)
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+
+		if recorder.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("Error - Invalid version format", func(t *testing.T) {
+		request := httptest.NewRequest("DELETE", fmt.Sprintf("/todos/%d?version=abc", targetID), nil)
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %v", recorder.Code)
+		}
+	})
+
 	t.Run("Error - Todo not found", func(t *testing.T) {
 		request := httptest.NewRequest("DELETE", "/todos/50", nil)
 		recorder := httptest.NewRecorder()
@@ -434,3 +466,171 @@ func TestTH_Delete(t *testing.T) {
 		}
 	})
 }
+
+func TestTH_Subtasks(t *testing.T) {
+	store := storage.NewDataStorage()
+	for _, todo := range []entity.Todo{
+		{Title: "Move out"},
+		{Title: "Pack", ParentID: 1},
+		{Title: "Clean", ParentID: 1, Completed: true},
+		{Title: "Pack books", ParentID: 2},
+	} {
+		_ = store.CreateTodo(context.Background(), &todo)
+	}
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store)}).InitRoutes()
+
+	t.Run("Children", func(t *testing.T) {
+		recorder := serve(mux, "GET", "/todos/1/children?completed=false", "", nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+		var response dto.GetTodoListResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&response)
+		if response.Total != 1 || response.Todos[0].Title != "Pack" {
+			t.Errorf("expected [Pack], got %+v", response.Todos)
+		}
+
+		if recorder := serve(mux, "GET", "/todos/42/children", "", nil); recorder.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("Tree", func(t *testing.T) {
+		recorder := serve(mux, "GET", "/todos/1/tree", "", nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+		var response dto.GetTodoTreeResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&response)
+		if len(response.Children) != 2 || len(response.Children[0].Children) != 1 {
+			t.Errorf("expected two children and one grandchild, got %+v", response.TodoNode)
+		}
+	})
+
+	t.Run("Progress", func(t *testing.T) {
+		recorder := serve(mux, "GET", "/todos/1", "", nil)
+		if !strings.Contains(recorder.Body.String(), `"progress":{"done":1,"total":2}`) {
+			t.Errorf("expected progress 1/2, got %s", recorder.Body)
+		}
+	})
+
+	t.Run("Error - cycle", func(t *testing.T) {
+		recorder := serve(mux, "PATCH", "/todos/1", `{"parent_id": 4}`, map[string]string{"Content-Type": "application/merge-patch+json"})
+		if recorder.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %v: %s", recorder.Code, recorder.Body)
+		}
+	})
+
+	t.Run("Error - delete a parent", func(t *testing.T) {
+		if recorder := serve(mux, "DELETE", "/todos/2", "", nil); recorder.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %v", recorder.Code)
+		}
+	})
+}
+
+func TestTH_Blockers(t *testing.T) {
+	store := storage.NewDataStorage()
+	for _, todo := range []entity.Todo{
+		{Title: "Buy paint"},
+		{Title: "Paint walls"},
+		{Title: "Hang pictures", BlockedBy: []int64{2}},
+	} {
+		_ = store.CreateTodo(context.Background(), &todo)
+	}
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store)}).InitRoutes()
+
+	t.Run("Add blocker", func(t *testing.T) {
+		recorder := serve(mux, "PUT", "/todos/2/blockers/1", "", nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+		if recorder.Header().Get("ETag") == "" {
+			t.Error("expected an ETag")
+		}
+
+		recorder = serve(mux, "GET", "/todos/2", "", nil)
+		if !strings.Contains(recorder.Body.String(), `"blocked_by":[1]`) || !strings.Contains(recorder.Body.String(), `"blocked":true`) {
+			t.Errorf("expected todo 2 blocked by 1, got %s", recorder.Body)
+		}
+	})
+
+	t.Run("Critical path", func(t *testing.T) {
+		recorder := serve(mux, "GET", "/todos/3/critical-path", "", nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+		var response dto.GetCriticalPathResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&response)
+		if len(response.Todos) != 3 || response.Todos[0].ID != 1 {
+			t.Errorf("expected path [1 2 3], got %+v", response.Todos)
+		}
+	})
+
+	t.Run("Error - cycle", func(t *testing.T) {
+		if recorder := serve(mux, "PUT", "/todos/1/blockers/3", "", nil); recorder.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("Error - complete a blocked todo", func(t *testing.T) {
+		recorder := serve(mux, "PATCH", "/todos/3", `{"completed": true}`, map[string]string{"Content-Type": "application/merge-patch+json"})
+		if recorder.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %v: %s", recorder.Code, recorder.Body)
+		}
+	})
+
+	t.Run("Remove blocker", func(t *testing.T) {
+		if recorder := serve(mux, "DELETE", "/todos/3/blockers/2", "", nil); recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v", recorder.Code)
+		}
+		recorder := serve(mux, "PATCH", "/todos/3", `{"completed": true}`, map[string]string{"Content-Type": "application/merge-patch+json"})
+		if recorder.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+	})
+}
+
+func TestTH_Recurrence(t *testing.T) {
+	store := storage.NewDataStorage()
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store)}).InitRoutes()
+
+	body := `{"title": "Standup", "due_at": "2026-03-02T10:00:00+03:00", "recurrence": {"rule": "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", "time_zone": "Europe/Moscow"}}`
+	if recorder := serve(mux, "POST", "/todos", body, nil); recorder.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %v: %s", recorder.Code, recorder.Body)
+	}
+
+	t.Run("Occurrences", func(t *testing.T) {
+		recorder := serve(mux, "GET", "/todos/1/occurrences?from=2026-03-05T00:00:00Z&to=2026-03-10T00:00:00Z", "", nil)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+		want := `{"items":["2026-03-05T07:00:00Z","2026-03-06T07:00:00Z","2026-03-09T07:00:00Z"]}`
+		if got := strings.TrimSpace(recorder.Body.String()); got != want {
+			t.Errorf("expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("Complete", func(t *testing.T) {
+		recorder := serve(mux, "PATCH", "/todos/1", `{"completed": true}`, map[string]string{"Content-Type": "application/merge-patch+json"})
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+		if !strings.Contains(recorder.Body.String(), `"next_id":2`) {
+			t.Errorf("expected next_id 2, got %s", recorder.Body)
+		}
+
+		recorder = serve(mux, "GET", "/todos/2", "", nil)
+		if !strings.Contains(recorder.Body.String(), `"due_at":"2026-03-03T07:00:00Z"`) {
+			t.Errorf("expected the next occurrence on Tuesday, got %s", recorder.Body)
+		}
+	})
+
+	t.Run("Error - invalid range", func(t *testing.T) {
+		if recorder := serve(mux, "GET", "/todos/2/occurrences?from=tomorrow", "", nil); recorder.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %v", recorder.Code)
+		}
+		if recorder := serve(mux, "GET", "/todos/2/occurrences?from=2026-03-05T00:00:00Z&to=2026-03-01T00:00:00Z", "", nil); recorder.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %v", recorder.Code)
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/usecase"
+)
+
+type WebhookHandler struct {
+	log                *slog.Logger
+	createWebhookUC    *usecase.CreateWebhookUC
+	getWebhookUC       *usecase.GetWebhookUC
+	getWebhookListUC   *usecase.GetWebhookListUC
+	updateWebhookUC    *usecase.UpdateWebhookUC
+	deleteWebhookUC    *usecase.DeleteWebhookUC
+	getDeadLettersUC   *usecase.GetDeadLettersUC
+	replayDeadLetterUC *usecase.ReplayDeadLetterUC
+	deleteDeadLetterUC *usecase.DeleteDeadLetterUC
+}
+
+func NewWebhookHandler(
+	log *slog.Logger,
+	createWebhookUC *usecase.CreateWebhookUC,
+	getWebhookUC *usecase.GetWebhookUC,
+	getWebhookListUC *usecase.GetWebhookListUC,
+	updateWebhookUC *usecase.UpdateWebhookUC,
+	deleteWebhookUC *usecase.DeleteWebhookUC,
+	getDeadLettersUC *usecase.GetDeadLettersUC,
+	replayDeadLetterUC *usecase.ReplayDeadLetterUC,
+	deleteDeadLetterUC *usecase.DeleteDeadLetterUC,
+) *WebhookHandler {
+	return &WebhookHandler{
+		log:                log,
+		createWebhookUC:    createWebhookUC,
+		getWebhookUC:       getWebhookUC,
+		getWebhookListUC:   getWebhookListUC,
+		updateWebhookUC:    updateWebhookUC,
+		deleteWebhookUC:    deleteWebhookUC,
+		getDeadLettersUC:   getDeadLettersUC,
+		replayDeadLetterUC: replayDeadLetterUC,
+		deleteDeadLetterUC: deleteDeadLetterUC,
+	}
+}
+
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var input dto.CreateWebhook
+	if err := json.NewDecoder(r.Body).Decode(&input.Webhook); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.createWebhookUC.Execute(r.Context(), input)
+	if err != nil {
+		h.fail(w, r, "failed to create webhook", err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "created webhook", slog.Int("id", int(response.ID)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.getWebhookUC.Execute(r.Context(), dto.GetWebhook{ID: id})
+	if err != nil {
+		h.fail(w, r, "failed to get webhook", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *WebhookHandler) GetWebhookList(w http.ResponseWriter, r *http.Request) {
+	response, err := h.getWebhookListUC.Execute(r.Context())
+	if err != nil {
+		h.fail(w, r, "failed to get webhook list", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var input dto.UpdateWebhook
+	if err := json.NewDecoder(r.Body).Decode(&input.Webhook); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	input.ID = id
+
+	response, err := h.updateWebhookUC.Execute(r.Context(), input)
+	if err != nil {
+		h.fail(w, r, "failed to update webhook", err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "updated webhook", slog.Int("id", int(response.ID)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.deleteWebhookUC.Execute(r.Context(), dto.DeleteWebhook{ID: id})
+	if err != nil {
+		h.fail(w, r, "failed to delete webhook", err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "deleted webhook", slog.Int("id", int(response.ID)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *WebhookHandler) GetDeadLetters(w http.ResponseWriter, r *http.Request) {
+	response, err := h.getDeadLettersUC.Execute(r.Context())
+	if err != nil {
+		h.fail(w, r, "failed to get dead letters", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// ReplayDeadLetter answers 202: the delivery runs in the background.
+func (h *WebhookHandler) ReplayDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.replayDeadLetterUC.Execute(r.Context(), dto.ReplayDeadLetter{ID: id})
+	if err != nil {
+		h.fail(w, r, "failed to replay dead letter", err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "replaying dead letter", slog.Int("id", int(response.ID)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *WebhookHandler) DeleteDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := h.deleteDeadLetterUC.Execute(r.Context(), dto.DeleteDeadLetter{ID: id})
+	if err != nil {
+		h.fail(w, r, "failed to delete dead letter", err)
+		return
+	}
+
+	h.log.InfoContext(r.Context(), "deleted dead letter", slog.Int("id", int(response.ID)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h *WebhookHandler) fail(w http.ResponseWriter, r *http.Request, message string, err error) {
+	status, msg, internalErr := HttpError(err)
+	h.log.ErrorContext(r.Context(), message,
+		slog.Int("status", status),
+		slog.String("public_msg", msg),
+		slog.Any("cause", internalErr),
+	)
+	http.Error(w, msg, status)
+}
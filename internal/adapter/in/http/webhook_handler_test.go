@@ -0,0 +1,126 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+	adapterhttp "todo-api/internal/adapter/in/http"
+	"todo-api/internal/adapter/out/clock"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/adapter/out/webhook"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/entity"
+)
+
+func TestTH_Webhooks(t *testing.T) {
+	store := storage.NewDataStorage()
+	ctx := context.Background()
+
+	received := make(chan string, 1)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get(webhook.DeliveryHeader)
+	}))
+	defer target.Close()
+
+	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	dispatcher := webhook.NewDispatcher(testLogger, target.Client(), store, clock.System{}, webhook.Config{Backoff: time.Millisecond})
+	dispatcher.Start(ctx)
+	defer func() { _ = dispatcher.Stop(ctx) }()
+
+	webhooks := adapterhttp.NewWebhookHandler(testLogger,
+		usecase.NewCreateWebhookUC(store, clock.System{}),
+		usecase.NewGetWebhookUC(store),
+		usecase.NewGetWebhookListUC(store),
+		usecase.NewUpdateWebhookUC(store, clock.System{}),
+		usecase.NewDeleteWebhookUC(store),
+		usecase.NewGetDeadLettersUC(store),
+		usecase.NewReplayDeadLetterUC(store, dispatcher),
+		usecase.NewDeleteDeadLetterUC(store),
+	)
+	mux := adapterhttp.NewRouter(adapterhttp.Handlers{Todo: newConditionalHandler(store), Webhook: webhooks}).InitRoutes()
+
+	t.Run("Success", func(t *testing.T) {
+		body := `{"url": "` + target.URL + `", "events": ["todo.completed"], "secret": "s3cret"}`
+		recorder := serve(mux, "POST", "/webhooks", body, nil)
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %v: %s", recorder.Code, recorder.Body)
+		}
+		var created dto.CreateWebhookResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&created)
+		if created.ID != 1 || created.Secret != "s3cret" {
+			t.Errorf("expected webhook 1 with its secret, got %+v", created)
+		}
+
+		recorder = serve(mux, "GET", "/webhooks/1", "", nil)
+		if recorder.Code != http.StatusOK || strings.Contains(recorder.Body.String(), "s3cret") {
+			t.Errorf("expected the webhook without its secret, got %v: %s", recorder.Code, recorder.Body)
+		}
+
+		body = `{"url": "` + target.URL + `", "events": ["todo.created"]}`
+		if recorder := serve(mux, "PUT", "/webhooks/1", body, nil); recorder.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+
+		recorder = serve(mux, "GET", "/webhooks", "", nil)
+		var list dto.GetWebhookListResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&list)
+		if len(list.Webhooks) != 1 || list.Webhooks[0].Events[0] != "todo.created" {
+			t.Errorf("expected the updated webhook, got %+v", list)
+		}
+	})
+
+	t.Run("Dead letters", func(t *testing.T) {
+		letter := entity.DeadLetter{WebhookID: 1, EventID: "e1", Event: entity.TodoEventCreated, Payload: []byte(`{"id":"e1"}`)}
+		_ = store.CreateDeadLetter(ctx, &letter)
+
+		recorder := serve(mux, "GET", "/webhooks/dead-letters", "", nil)
+		var list dto.GetDeadLettersResponse
+		_ = json.NewDecoder(recorder.Body).Decode(&list)
+		if len(list.DeadLetters) != 1 || string(list.DeadLetters[0].Payload) != `{"id":"e1"}` {
+			t.Fatalf("expected the dead letter, got %s", recorder.Body)
+		}
+
+		if recorder := serve(mux, "POST", "/webhooks/dead-letters/1/replay", "", nil); recorder.Code != http.StatusAccepted {
+			t.Fatalf("expected status 202, got %v: %s", recorder.Code, recorder.Body)
+		}
+		select {
+		case id := <-received:
+			if id != "e1" {
+				t.Errorf("expected delivery e1, got %s", id)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the dead letter delivered")
+		}
+		if recorder := serve(mux, "DELETE", "/webhooks/dead-letters/1", "", nil); recorder.Code != http.StatusNotFound {
+			t.Errorf("expected status 404 after the replay, got %v", recorder.Code)
+		}
+	})
+
+	t.Run("Error - invalid webhook", func(t *testing.T) {
+		for _, body := range []string{
+			`{"url": "mailto:bot@example.com"}`,
+			`{"url": "https://example.com", "events": ["todo.renamed"]}`,
+			`{"url": `,
+		} {
+			if recorder := serve(mux, "POST", "/webhooks", body, nil); recorder.Code != http.StatusBadRequest {
+				t.Errorf("%s: expected status 400, got %v: %s", body, recorder.Code, recorder.Body)
+			}
+		}
+	})
+
+	t.Run("Error - not found", func(t *testing.T) {
+		if recorder := serve(mux, "DELETE", "/webhooks/1", "", nil); recorder.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %v: %s", recorder.Code, recorder.Body)
+		}
+		if recorder := serve(mux, "GET", "/webhooks/1", "", nil); recorder.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %v", recorder.Code)
+		}
+	})
+}
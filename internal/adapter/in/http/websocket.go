@@ -0,0 +1,252 @@
+package http
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// The server side of RFC 6455, as much as /ws needs: no extensions or
+// subprotocols, and messages are assembled in memory up to wsMaxMessage.
+
+const wsAcceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	wsContinuation = 0x0
+	wsText         = 0x1
+	wsBinary       = 0x2
+	wsClose        = 0x8
+	wsPing         = 0x9
+	wsPong         = 0xA
+)
+
+const (
+	wsCloseNormal        = 1000
+	wsCloseGoingAway     = 1001
+	wsCloseProtocolError = 1002
+	wsCloseUnsupported   = 1003
+	wsCloseNoStatus      = 1005
+	wsCloseInvalidData   = 1007
+	wsCloseTooBig        = 1009
+	wsCloseTryAgainLater = 1013
+)
+
+const (
+	wsMaxMessage = 1 << 20
+	wsWriteWait  = 10 * time.Second
+)
+
+// wsCloseError ends the connection with a close frame carrying Code.
+type wsCloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *wsCloseError) Error() string {
+	return fmt.Sprintf("websocket closed: %d %s", e.Code, e.Reason)
+}
+
+type wsConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	w    *bufio.Writer
+}
+
+type wsFrame struct {
+	fin     bool
+	opcode  byte
+	payload []byte
+}
+
+// upgradeWebSocket answers the opening handshake and takes the connection
+// over from net/http. On failure it has already written the HTTP error.
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request, allowedOrigins []string) (*wsConn, error) {
+	if !headerHasToken(r.Header, "Connection", "upgrade") || !headerHasToken(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "websocket upgrade required", http.StatusUpgradeRequired)
+		return nil, errors.New("not a websocket upgrade")
+	}
+	if origin := r.Header.Get("Origin"); !originAllowed(origin, r.Host, allowedOrigins) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return nil, fmt.Errorf("origin %q not allowed", origin)
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+		return nil, errors.New("unsupported websocket version")
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if nonce, err := base64.StdEncoding.DecodeString(key); err != nil || len(nonce) != 16 {
+		http.Error(w, "invalid Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, errors.New("invalid websocket key")
+	}
+
+	conn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		http.Error(w, "websocket is not supported", http.StatusInternalServerError)
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + wsAccept(key) + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return &wsConn{conn: conn, r: rw.Reader, w: rw.Writer}, nil
+}
+
+// originAllowed guards against cross-site WebSocket hijacking: browsers
+// send Origin with every handshake, and a page may only connect from the
+// server's own host or from an allowed origin. Clients that are not
+// browsers send no Origin and are let through.
+func originAllowed(origin, host string, allowed []string) bool {
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	if strings.EqualFold(u.Host, host) {
+		return true
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(strings.TrimSuffix(a, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func wsAccept(key string) string {
+	sum := sha1.Sum([]byte(key + wsAcceptGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func headerHasToken(h http.Header, name, token string) bool {
+	for _, value := range h.Values(name) {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readFrame reads one frame. Client frames must be masked, and nothing may
+// be longer than limit.
+func (c *wsConn) readFrame(limit int64) (wsFrame, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.r, head[:]); err != nil {
+		return wsFrame{}, err
+	}
+
+	frame := wsFrame{fin: head[0]&0x80 != 0, opcode: head[0] & 0x0F}
+	if head[0]&0x70 != 0 {
+		return wsFrame{}, &wsCloseError{Code: wsCloseProtocolError, Reason: "unexpected reserved bits"}
+	}
+	if head[1]&0x80 == 0 {
+		return wsFrame{}, &wsCloseError{Code: wsCloseProtocolError, Reason: "client frames must be masked"}
+	}
+
+	length := int64(head[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			return wsFrame{}, err
+		}
+		length = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			return wsFrame{}, err
+		}
+		n := binary.BigEndian.Uint64(ext[:])
+		if n > 1<<62 {
+			return wsFrame{}, &wsCloseError{Code: wsCloseProtocolError, Reason: "invalid frame length"}
+		}
+		length = int64(n)
+	}
+
+	if frame.opcode >= wsClose && (!frame.fin || length > 125) {
+		return wsFrame{}, &wsCloseError{Code: wsCloseProtocolError, Reason: "invalid control frame"}
+	}
+	if length > limit {
+		return wsFrame{}, &wsCloseError{Code: wsCloseTooBig, Reason: "message too big"}
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.r, mask[:]); err != nil {
+		return wsFrame{}, err
+	}
+	frame.payload = make([]byte, length)
+	if _, err := io.ReadFull(c.r, frame.payload); err != nil {
+		return wsFrame{}, err
+	}
+	for i := range frame.payload {
+		frame.payload[i] ^= mask[i%4]
+	}
+
+	return frame, nil
+}
+
+// writeFrame writes one unfragmented, unmasked frame.
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	_ = c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+
+	head := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n <= 125:
+		head = append(head, byte(n))
+	case n <= 0xFFFF:
+		head = append(head, 126)
+		head = binary.BigEndian.AppendUint16(head, uint16(n))
+	default:
+		head = append(head, 127)
+		head = binary.BigEndian.AppendUint64(head, uint64(n))
+	}
+
+	if _, err := c.w.Write(head); err != nil {
+		return err
+	}
+	if _, err := c.w.Write(payload); err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
+
+func (c *wsConn) writeClose(code int, reason string) error {
+	if code == wsCloseNoStatus {
+		return c.writeFrame(wsClose, nil)
+	}
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	return c.writeFrame(wsClose, append(payload, reason...))
+}
+
+// closeCode reads the status code of a close frame the way it should be
+// echoed back.
+func closeCode(payload []byte) int {
+	if len(payload) < 2 {
+		return wsCloseNoStatus
+	}
+	code := int(binary.BigEndian.Uint16(payload))
+	if code < 1000 || code == 1004 || code == 1005 || code == 1006 || (code > 1014 && code < 3000) || code > 4999 {
+		return wsCloseProtocolError
+	}
+	return code
+}
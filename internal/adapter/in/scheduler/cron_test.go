@@ -0,0 +1,51 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+	"todo-api/internal/adapter/in/scheduler"
+)
+
+func TestParseCron(t *testing.T) {
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+	from := time.Date(2026, 3, 2, 10, 7, 30, 0, time.UTC) // Monday
+
+	cases := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"* * * * *", from, time.Date(2026, 3, 2, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", from, time.Date(2026, 3, 2, 10, 15, 0, 0, time.UTC)},
+		{"5 * * * *", from, time.Date(2026, 3, 2, 11, 5, 0, 0, time.UTC)},
+		{"0 9-18/3 * * 1-5", from, time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)},
+		{"30 2 * * 0", from, time.Date(2026, 3, 8, 2, 30, 0, 0, time.UTC)},
+		{"30 2 * * 7", from, time.Date(2026, 3, 8, 2, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", from, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", from, time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{"@daily", from, time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", from, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 90s", from, from.Add(90 * time.Second)},
+		{"0 3 * * *", from.In(moscow), time.Date(2026, 3, 3, 3, 0, 0, 0, moscow)},
+		{"0 0 30 2 *", from, time.Time{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.expr, func(t *testing.T) {
+			schedule, err := scheduler.ParseCron(tc.expr)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got := schedule.Next(tc.from); !got.Equal(tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	t.Run("Error - invalid expressions", func(t *testing.T) {
+		for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "@every -1s", "@sometimes"} {
+			if _, err := scheduler.ParseCron(expr); err == nil {
+				t.Errorf("expected an error for %q", expr)
+			}
+		}
+	})
+}
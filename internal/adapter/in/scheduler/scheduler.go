@@ -0,0 +1,256 @@
+// Package scheduler runs background jobs on cron-like schedules. It drives
+// the application from the inside the way the HTTP adapter does from the
+// outside.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+	"todo-api/internal/domain/port"
+)
+
+const defaultBackoff = time.Second
+
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context) error
+
+	// Retries is how many more attempts a failed run gets before the job
+	// waits for its next scheduled time.
+	Retries int
+	// Backoff is the delay before the first retry, one second by default. It
+	// doubles with every retry and is jittered so that jobs failing on a
+	// shared dependency do not retry in lockstep.
+	Backoff time.Duration
+	// Timeout bounds a single attempt; zero means no limit.
+	Timeout time.Duration
+}
+
+// JobStats are the metrics of one job since the scheduler started.
+type JobStats struct {
+	Runs         int64         `json:"runs"`
+	Failures     int64         `json:"failures"`
+	Retries      int64         `json:"retries"`
+	LastRun      time.Time     `json:"last_run,omitzero"`
+	LastDuration time.Duration `json:"last_duration_ns"`
+	LastError    string        `json:"last_error,omitempty"`
+	NextRun      time.Time     `json:"next_run,omitzero"`
+}
+
+// Scheduler runs every job in its own goroutine, so a slow job delays only
+// its own next run. Runs of one job never overlap: the next run time is
+// computed once the previous run has finished.
+type Scheduler struct {
+	log   *slog.Logger
+	clock port.Clock
+
+	mu      sync.Mutex
+	jobs    []Job
+	stats   map[string]*JobStats
+	started bool
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(log *slog.Logger, clock port.Clock) *Scheduler {
+	return &Scheduler{log: log, clock: clock, stats: make(map[string]*JobStats)}
+}
+
+// Add registers a job; jobs must be added before Start.
+func (s *Scheduler) Add(job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case s.started:
+		return errors.New("scheduler is already started")
+	case job.Name == "":
+		return errors.New("job name is required")
+	case job.Schedule == nil || job.Run == nil:
+		return fmt.Errorf("job %q needs a schedule and a run function", job.Name)
+	case s.stats[job.Name] != nil:
+		return fmt.Errorf("job %q is already added", job.Name)
+	}
+	if job.Backoff <= 0 {
+		job.Backoff = defaultBackoff
+	}
+
+	s.jobs = append(s.jobs, job)
+	s.stats[job.Name] = &JobStats{}
+	return nil
+}
+
+// Start launches the jobs. They run until ctx is canceled or Stop is called.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return
+	}
+	s.started = true
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}()
+	}
+	s.log.Info("scheduler started", slog.Int("jobs", len(s.jobs)))
+}
+
+// Stop cancels the running jobs and waits for them to return, giving up
+// when ctx is done.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.log.Info("scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("scheduler: jobs still running: %w", ctx.Err())
+	}
+}
+
+// Stats returns a copy of the metrics of every job, keyed by job name.
+func (s *Scheduler) Stats() map[string]JobStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := make(map[string]JobStats, len(s.stats))
+	for name, st := range s.stats {
+		stats[name] = *st
+	}
+	return stats
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	for {
+		next := job.Schedule.Next(s.clock.Now())
+		s.update(job.Name, func(st *JobStats) { st.NextRun = next })
+		if next.IsZero() {
+			s.log.Warn("job has no further runs", slog.String("job", job.Name))
+			return
+		}
+
+		if !sleep(ctx, next.Sub(s.clock.Now())) {
+			return
+		}
+		s.run(ctx, job)
+	}
+}
+
+// run makes up to 1+job.Retries attempts and records the outcome.
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	start := s.clock.Now()
+
+	var err error
+	attempts := 0
+	for {
+		attempts++
+		if err = attempt(ctx, job); err == nil || attempts > job.Retries || ctx.Err() != nil {
+			break
+		}
+
+		delay := jitter(job.Backoff << (attempts - 1))
+		s.log.WarnContext(ctx, "job failed, retrying",
+			slog.String("job", job.Name),
+			slog.Int("attempt", attempts),
+			slog.Duration("delay", delay),
+			slog.Any("err", err),
+		)
+		s.update(job.Name, func(st *JobStats) { st.Retries++ })
+		if !sleep(ctx, delay) {
+			break
+		}
+	}
+
+	duration := s.clock.Now().Sub(start)
+	s.update(job.Name, func(st *JobStats) {
+		st.Runs++
+		st.LastRun = start
+		st.LastDuration = duration
+		st.LastError = ""
+		if err != nil {
+			st.Failures++
+			st.LastError = err.Error()
+		}
+	})
+
+	if err != nil {
+		s.log.ErrorContext(ctx, "job failed",
+			slog.String("job", job.Name),
+			slog.Int("attempts", attempts),
+			slog.Duration("duration", duration),
+			slog.Any("err", err),
+		)
+		return
+	}
+	s.log.DebugContext(ctx, "job finished",
+		slog.String("job", job.Name),
+		slog.Int("attempts", attempts),
+		slog.Duration("duration", duration),
+	)
+}
+
+// attempt runs the job once, turning a panic into an error so that one
+// broken job cannot take the process down.
+func attempt(ctx context.Context, job Job) (err error) {
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return job.Run(ctx)
+}
+
+func (s *Scheduler) update(name string, change func(st *JobStats)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change(s.stats[name])
+}
+
+// jitter spreads d over [d/2, d].
+func jitter(d time.Duration) time.Duration {
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+// sleep waits for d and reports false when ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
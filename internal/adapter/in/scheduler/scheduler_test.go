@@ -0,0 +1,142 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+	"todo-api/internal/adapter/in/scheduler"
+	"todo-api/internal/adapter/out/clock"
+)
+
+var testLogger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+// waitFor polls cond until it holds or a second has passed.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if cond() {
+			return
+		}
+	}
+	t.Fatal("condition not met in time")
+}
+
+func TestScheduler(t *testing.T) {
+	t.Run("Success - runs and retries", func(t *testing.T) {
+		s := scheduler.New(testLogger, clock.System{})
+
+		var calls atomic.Int64
+		err := s.Add(scheduler.Job{
+			Name:     "flaky",
+			Schedule: scheduler.Every(5 * time.Millisecond),
+			Retries:  2,
+			Backoff:  time.Millisecond,
+			Run: func(ctx context.Context) error {
+				// Every run fails on its first attempt.
+				if calls.Add(1)%2 == 1 {
+					return errors.New("boom")
+				}
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		s.Start(context.Background())
+		waitFor(t, func() bool { return s.Stats()["flaky"].Runs >= 2 })
+		if err := s.Stop(context.Background()); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		stats := s.Stats()["flaky"]
+		if stats.Failures != 0 || stats.Retries < 2 || stats.LastError != "" || stats.LastRun.IsZero() {
+			t.Errorf("expected retried runs without failures, got %+v", stats)
+		}
+	})
+
+	t.Run("Success - failure and panic are recorded", func(t *testing.T) {
+		s := scheduler.New(testLogger, clock.System{})
+		_ = s.Add(scheduler.Job{
+			Name:     "broken",
+			Schedule: scheduler.Every(time.Millisecond),
+			Retries:  1,
+			Backoff:  time.Millisecond,
+			Run:      func(ctx context.Context) error { panic("nil map") },
+		})
+
+		s.Start(context.Background())
+		waitFor(t, func() bool { return s.Stats()["broken"].Failures >= 1 })
+		_ = s.Stop(context.Background())
+
+		if stats := s.Stats()["broken"]; stats.LastError != "job panicked: nil map" {
+			t.Errorf("expected the panic as last error, got %+v", stats)
+		}
+	})
+
+	t.Run("Success - stop cancels running jobs", func(t *testing.T) {
+		s := scheduler.New(testLogger, clock.System{})
+		started := make(chan struct{})
+		_ = s.Add(scheduler.Job{
+			Name:     "slow",
+			Schedule: scheduler.Every(time.Millisecond),
+			Run: func(ctx context.Context) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		})
+
+		s.Start(context.Background())
+		<-started
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := s.Stop(ctx); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("Error - stop gives up on stuck jobs", func(t *testing.T) {
+		s := scheduler.New(testLogger, clock.System{})
+		release := make(chan struct{})
+		defer close(release)
+		started := make(chan struct{})
+		_ = s.Add(scheduler.Job{
+			Name:     "stuck",
+			Schedule: scheduler.Every(time.Millisecond),
+			Run: func(ctx context.Context) error {
+				close(started)
+				<-release
+				return nil
+			},
+		})
+
+		s.Start(context.Background())
+		<-started
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := s.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("Error - invalid jobs", func(t *testing.T) {
+		s := scheduler.New(testLogger, clock.System{})
+		run := func(ctx context.Context) error { return nil }
+
+		if err := s.Add(scheduler.Job{Schedule: scheduler.Every(time.Second), Run: run}); err == nil {
+			t.Error("expected an error for a job without a name")
+		}
+		if err := s.Add(scheduler.Job{Name: "noop", Run: run}); err == nil {
+			t.Error("expected an error for a job without a schedule")
+		}
+		_ = s.Add(scheduler.Job{Name: "noop", Schedule: scheduler.Every(time.Second), Run: run})
+		if err := s.Add(scheduler.Job{Name: "noop", Schedule: scheduler.Every(time.Second), Run: run}); err == nil {
+			t.Error("expected an error for a duplicate job")
+		}
+	})
+}
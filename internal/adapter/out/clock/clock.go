@@ -0,0 +1,10 @@
+package clock
+
+import "time"
+
+// System is the wall clock in UTC.
+type System struct{}
+
+func (System) Now() time.Time {
+	return time.Now().UTC()
+}
@@ -0,0 +1,29 @@
+package notify
+
+import (
+	"context"
+	"log/slog"
+	"todo-api/internal/domain/entity"
+)
+
+// Log writes reminders to the application log; it needs no target.
+type Log struct {
+	Log *slog.Logger
+}
+
+func NewLog(log *slog.Logger) *Log {
+	return &Log{Log: log}
+}
+
+func (l *Log) CheckTarget(string) error {
+	return nil
+}
+
+func (l *Log) Notify(ctx context.Context, reminder *entity.Reminder, todo *entity.Todo) error {
+	l.Log.InfoContext(ctx, subject(todo),
+		slog.Int64("reminder_id", reminder.ID),
+		slog.Int64("todo_id", todo.ID),
+		slog.Time("due_at", todo.DueAt),
+	)
+	return nil
+}
@@ -0,0 +1,36 @@
+// Package notify delivers reminders over webhooks, email and the log.
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"todo-api/internal/domain/entity"
+)
+
+// Channel names the notifiers are registered under.
+const (
+	ChannelWebhook = "webhook"
+	ChannelEmail   = "email"
+	ChannelLog     = "log"
+)
+
+func subject(todo *entity.Todo) string {
+	return "Reminder: " + todo.Title
+}
+
+// text is the plain text body shared by the channels that need one.
+func text(todo *entity.Todo) string {
+	var b strings.Builder
+	b.WriteString(todo.Title)
+	b.WriteString("\n")
+	if todo.Description != "" {
+		b.WriteString("\n")
+		b.WriteString(todo.Description)
+		b.WriteString("\n")
+	}
+	if !todo.DueAt.IsZero() {
+		fmt.Fprintf(&b, "\nDue: %s\n", todo.DueAt.UTC().Format(time.RFC3339))
+	}
+	return b.String()
+}
@@ -0,0 +1,164 @@
+package notify_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+	"todo-api/internal/adapter/out/notify"
+	"todo-api/internal/domain/entity"
+)
+
+// fakeSMTP accepts one session on a local port and sends the envelope and
+// message it received on the returned channel. failRcpt makes it refuse the
+// recipient.
+func fakeSMTP(t *testing.T, failRcpt bool) (string, <-chan string) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		var session strings.Builder
+		r := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+		reply("220 fake ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				received <- session.String()
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 fake")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				session.WriteString(line)
+				reply("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				session.WriteString(line)
+				if failRcpt {
+					reply("550 no such user")
+				} else {
+					reply("250 OK")
+				}
+			case cmd == "DATA":
+				reply("354 go ahead")
+				for {
+					data, err := r.ReadString('\n')
+					if err != nil || data == ".\r\n" {
+						break
+					}
+					session.WriteString(data)
+				}
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				received <- session.String()
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	return ln.Addr().String(), received
+}
+
+func TestSMTP(t *testing.T) {
+	todo := &entity.Todo{
+		ID:          7,
+		Title:       "Оплатить счёт",
+		Description: "До конца дня",
+		DueAt:       time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC),
+	}
+	reminder := &entity.Reminder{ID: 1, TodoID: 7, Channel: notify.ChannelEmail, Target: "Ann <ann@example.com>"}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("Success", func(t *testing.T) {
+		addr, received := fakeSMTP(t, false)
+		notifier := notify.NewSMTP(addr, "todo@example.com", "", "")
+
+		if err := notifier.Notify(ctx, reminder, todo); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		session := <-received
+		for _, want := range []string{
+			"MAIL FROM:<todo@example.com>",
+			"RCPT TO:<ann@example.com>",
+			"Subject: =?utf-8?q?",
+			"Content-Type: text/plain; charset=utf-8",
+			"До конца дня\r\n",
+			"Due: 2026-03-01T18:00:00Z",
+		} {
+			if !strings.Contains(session, want) {
+				t.Errorf("expected %q in session, got\n%s", want, session)
+			}
+		}
+	})
+
+	t.Run("Success - encoded display names", func(t *testing.T) {
+		addr, received := fakeSMTP(t, false)
+		notifier := notify.NewSMTP(addr, "Задачи <todo@example.com>", "", "")
+		named := *reminder
+		named.Target = "Анна Петрова <ann@example.com>"
+
+		if err := notifier.Notify(ctx, &named, todo); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		session := <-received
+		want := map[string]string{"From": "Задачи", "To": "Анна Петрова"}
+		for _, line := range strings.Split(session, "\r\n") {
+			name, value, ok := strings.Cut(line, ": ")
+			if !ok || want[name] == "" {
+				continue
+			}
+			for _, r := range value {
+				if r > 127 {
+					t.Fatalf("expected an ASCII %s header, got %q", name, value)
+				}
+			}
+			address, err := mail.ParseAddress(value)
+			if err != nil || address.Name != want[name] {
+				t.Errorf("expected %s name %q, got %v (%v)", name, want[name], address, err)
+			}
+			delete(want, name)
+		}
+		if len(want) != 0 {
+			t.Errorf("expected the headers %v, got\n%s", want, session)
+		}
+	})
+
+	t.Run("Error - recipient refused", func(t *testing.T) {
+		addr, _ := fakeSMTP(t, true)
+		notifier := notify.NewSMTP(addr, "todo@example.com", "", "")
+
+		if err := notifier.Notify(ctx, reminder, todo); err == nil || !strings.Contains(err.Error(), "550") {
+			t.Errorf("expected the 550 reply, got %v", err)
+		}
+	})
+
+	t.Run("Error - invalid target", func(t *testing.T) {
+		if err := notify.NewSMTP("localhost:25", "todo@example.com", "", "").CheckTarget("not an address"); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+	"todo-api/internal/domain/entity"
+)
+
+// Webhook POSTs a JSON description of the reminder to the target URL and
+// counts any 2xx response as delivered.
+type Webhook struct {
+	Client *http.Client
+}
+
+func NewWebhook(client *http.Client) *Webhook {
+	return &Webhook{Client: client}
+}
+
+type webhookPayload struct {
+	ReminderID  int64      `json:"reminder_id"`
+	TodoID      int64      `json:"todo_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+}
+
+func (w *Webhook) CheckTarget(target string) error {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook target must be an http or https URL")
+	}
+	return nil
+}
+
+func (w *Webhook) Notify(ctx context.Context, reminder *entity.Reminder, todo *entity.Todo) error {
+	payload := webhookPayload{
+		ReminderID:  reminder.ID,
+		TodoID:      todo.ID,
+		Title:       todo.Title,
+		Description: todo.Description,
+	}
+	if !todo.DueAt.IsZero() {
+		payload.DueAt = &todo.DueAt
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reminder.Target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"todo-api/internal/adapter/out/notify"
+	"todo-api/internal/domain/entity"
+)
+
+func TestWebhook(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := notify.NewWebhook(server.Client())
+	todo := &entity.Todo{ID: 7, Title: "Pay rent"}
+
+	t.Run("Success", func(t *testing.T) {
+		reminder := &entity.Reminder{ID: 3, TodoID: 7, Target: server.URL + "/hook"}
+		if err := notifier.Notify(context.Background(), reminder, todo); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if got["reminder_id"] != 3.0 || got["todo_id"] != 7.0 || got["title"] != "Pay rent" {
+			t.Errorf("unexpected payload %v", got)
+		}
+	})
+
+	t.Run("Error - non-2xx response", func(t *testing.T) {
+		reminder := &entity.Reminder{ID: 3, TodoID: 7, Target: server.URL + "/down"}
+		if err := notifier.Notify(context.Background(), reminder, todo); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("Error - invalid target", func(t *testing.T) {
+		for _, target := range []string{"", "ftp://example.com", "/relative"} {
+			if err := notifier.CheckTarget(target); err == nil {
+				t.Errorf("expected an error for %q", target)
+			}
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+	"todo-api/internal/app/uc_errors"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+func mapError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return uc_errors.TodoNotFoundError
+	}
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY, sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+			return uc_errors.TodoAlreadyExistsError
+		}
+	}
+
+	return err
+}
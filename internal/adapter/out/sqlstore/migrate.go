@@ -0,0 +1,25 @@
+package sqlstore
+
+import (
+	"context"
+	"embed"
+	"todo-api/internal/adapter/out/sqlstore/migrate"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+func (s *Store) Migrator() (*migrate.Migrator, error) {
+	return migrate.New(s.db, migrationFS, "migrations")
+}
+
+// Migrate brings the schema up to the latest embedded version.
+func (s *Store) Migrate(ctx context.Context) error {
+	migrator, err := s.Migrator()
+	if err != nil {
+		return err
+	}
+
+	_, err = migrator.Up(ctx)
+	return err
+}
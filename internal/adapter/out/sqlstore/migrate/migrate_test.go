@@ -0,0 +1,207 @@
+package migrate_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+	"todo-api/internal/adapter/out/sqlstore/migrate"
+
+	_ "modernc.org/sqlite"
+)
+
+func newDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+func testMigrations() fstest.MapFS {
+	return fstest.MapFS{
+		"m/0001_create_items.up.sql":   {Data: []byte(`CREATE TABLE items (id INTEGER PRIMARY KEY);`)},
+		"m/0001_create_items.down.sql": {Data: []byte(`DROP TABLE items;`)},
+		"m/0002_add_name.up.sql":       {Data: []byte(`ALTER TABLE items ADD COLUMN name TEXT;`)},
+		"m/0002_add_name.down.sql":     {Data: []byte(`ALTER TABLE items DROP COLUMN name;`)},
+	}
+}
+
+func newMigrator(t *testing.T, db *sql.DB, source fstest.MapFS) *migrate.Migrator {
+	t.Helper()
+
+	m, err := migrate.New(db, source, "m")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	m.LockTimeout = 200 * time.Millisecond
+
+	return m
+}
+
+func TestMigrator_UpDownStatus(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+	m := newMigrator(t, db, testMigrations())
+
+	t.Run("Up applies everything", func(t *testing.T) {
+		n, err := m.Up(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if n != 2 {
+			t.Errorf("expected 2 applied, got %d", n)
+		}
+		if _, err := db.ExecContext(ctx, `INSERT INTO items (name) VALUES ('x')`); err != nil {
+			t.Errorf("expected schema to be migrated, got %v", err)
+		}
+	})
+
+	t.Run("Up is idempotent", func(t *testing.T) {
+		if n, err := m.Up(ctx); err != nil || n != 0 {
+			t.Errorf("expected 0 applied and no error, got %d, %v", n, err)
+		}
+	})
+
+	t.Run("Down reverts the latest", func(t *testing.T) {
+		n, err := m.Down(ctx, 1)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if n != 1 {
+			t.Errorf("expected 1 reverted, got %d", n)
+		}
+
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(statuses) != 2 || !statuses[0].Applied || statuses[1].Applied {
+			t.Errorf("expected only version 1 applied, got %+v", statuses)
+		}
+	})
+}
+
+func TestMigrator_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Checksum mismatch", func(t *testing.T) {
+		db := newDB(t)
+		if _, err := newMigrator(t, db, testMigrations()).Up(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		changed := testMigrations()
+		changed["m/0001_create_items.up.sql"] = &fstest.MapFile{Data: []byte(`CREATE TABLE items (id INTEGER);`)}
+
+		if _, err := newMigrator(t, db, changed).Up(ctx); !errors.Is(err, migrate.ErrChecksumMismatch) {
+			t.Errorf("expected ErrChecksumMismatch, got %v", err)
+		}
+	})
+
+	t.Run("Unknown version", func(t *testing.T) {
+		db := newDB(t)
+		if _, err := newMigrator(t, db, testMigrations()).Up(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		older := testMigrations()
+		delete(older, "m/0002_add_name.up.sql")
+		delete(older, "m/0002_add_name.down.sql")
+
+		if _, err := newMigrator(t, db, older).Up(ctx); !errors.Is(err, migrate.ErrUnknownVersion) {
+			t.Errorf("expected ErrUnknownVersion, got %v", err)
+		}
+	})
+
+	t.Run("Missing down script", func(t *testing.T) {
+		db := newDB(t)
+		source := testMigrations()
+		delete(source, "m/0002_add_name.down.sql")
+
+		m := newMigrator(t, db, source)
+		_, _ = m.Up(ctx)
+
+		if _, err := m.Down(ctx, 1); !errors.Is(err, migrate.ErrMissingDown) {
+			t.Errorf("expected ErrMissingDown, got %v", err)
+		}
+	})
+
+	t.Run("Bad file name", func(t *testing.T) {
+		source := fstest.MapFS{"m/create.sql": {Data: []byte(`SELECT 1;`)}}
+		if _, err := migrate.New(newDB(t), source, "m"); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestMigrator_Lock(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+	m := newMigrator(t, db, testMigrations())
+
+	if _, err := m.Status(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO schema_lock (id, owner, acquired_at) VALUES (1, 'other', ?)`, time.Now().Unix(),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	t.Run("Held lock blocks", func(t *testing.T) {
+		if _, err := m.Up(ctx); !errors.Is(err, migrate.ErrLocked) {
+			t.Errorf("expected ErrLocked, got %v", err)
+		}
+	})
+
+	t.Run("Stale lock is taken over", func(t *testing.T) {
+		m.LockTTL = time.Nanosecond
+		time.Sleep(time.Second)
+
+		if _, err := m.Up(ctx); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+}
+
+func TestMigrator_LegacyTable(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE schema_migrations (
+			version    INTEGER PRIMARY KEY,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE items (id INTEGER PRIMARY KEY);
+		INSERT INTO schema_migrations (version) VALUES (1);
+	`)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	m := newMigrator(t, db, testMigrations())
+	n, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected only version 2 to be applied, got %d", n)
+	}
+
+	var checksum string
+	_ = db.QueryRowContext(ctx, `SELECT checksum FROM schema_migrations WHERE version = 1`).Scan(&checksum)
+	if checksum == "" {
+		t.Error("expected checksum to be backfilled")
+	}
+}
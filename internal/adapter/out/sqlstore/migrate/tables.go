@@ -0,0 +1,172 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    INTEGER   PRIMARY KEY,
+    name       TEXT      NOT NULL DEFAULT '',
+    checksum   TEXT      NOT NULL DEFAULT '',
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+
+// schema_lock holds at most one row; whoever inserted it owns the lock.
+const createLockTable = `CREATE TABLE IF NOT EXISTS schema_lock (
+    id          INTEGER PRIMARY KEY CHECK (id = 1),
+    owner       TEXT    NOT NULL,
+    acquired_at INTEGER NOT NULL
+)`
+
+type appliedRecord struct {
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+func (m *Migrator) ensureTables(ctx context.Context) error {
+	if _, err := m.db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	if _, err := m.db.ExecContext(ctx, createLockTable); err != nil {
+		return fmt.Errorf("create schema_lock: %w", err)
+	}
+
+	// Databases migrated before checksums existed only have version and
+	// applied_at; the missing checksums are backfilled by verify.
+	for _, column := range []string{"name", "checksum"} {
+		probe := fmt.Sprintf(`SELECT %s FROM schema_migrations LIMIT 0`, column)
+		rows, err := m.db.QueryContext(ctx, probe)
+		if err == nil {
+			_ = rows.Close()
+			continue
+		}
+		alter := fmt.Sprintf(`ALTER TABLE schema_migrations ADD COLUMN %s TEXT NOT NULL DEFAULT ''`, column)
+		if _, err := m.db.ExecContext(ctx, alter); err != nil {
+			return fmt.Errorf("add schema_migrations.%s: %w", column, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int]appliedRecord, error) {
+	rows, err := m.db.QueryContext(ctx, `SELECT version, name, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	applied := make(map[int]appliedRecord)
+	for rows.Next() {
+		var (
+			version int
+			rec     appliedRecord
+		)
+		if err := rows.Scan(&version, &rec.name, &rec.checksum, &rec.appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = rec
+	}
+
+	return applied, rows.Err()
+}
+
+// verify refuses to go on if the database contains migrations this binary
+// does not know about or whose script has changed since it was applied.
+func (m *Migrator) verify(ctx context.Context) (map[int]appliedRecord, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int]Migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		known[mig.Version] = mig
+	}
+
+	for version, rec := range applied {
+		mig, ok := known[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: version %d", ErrUnknownVersion, version)
+		}
+
+		if rec.checksum == "" {
+			_, err := m.db.ExecContext(ctx,
+				`UPDATE schema_migrations SET name = ?, checksum = ? WHERE version = ?`,
+				mig.Name, mig.Checksum, version,
+			)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if rec.checksum != mig.Checksum {
+			return nil, fmt.Errorf("%w: %04d_%s", ErrChecksumMismatch, version, mig.Name)
+		}
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	if err := m.ensureTables(ctx); err != nil {
+		return err
+	}
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	return fn()
+}
+
+func (m *Migrator) lock(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, m.LockTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+
+		// A lock older than the TTL belongs to an instance that died mid-migration.
+		_, err := m.db.ExecContext(ctx,
+			`DELETE FROM schema_lock WHERE acquired_at < ?`, now.Add(-m.LockTTL).Unix(),
+		)
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+
+		res, err := m.db.ExecContext(ctx,
+			`INSERT INTO schema_lock (id, owner, acquired_at)
+             SELECT 1, ?, ? WHERE NOT EXISTS (SELECT 1 FROM schema_lock)`,
+			m.owner, now.Unix(),
+		)
+		if err == nil {
+			if n, err := res.RowsAffected(); err == nil && n == 1 {
+				return nil
+			}
+		} else if ctx.Err() == nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return ErrLocked
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Migrator) unlock() {
+	_, _ = m.db.ExecContext(context.Background(), `DELETE FROM schema_lock WHERE owner = ?`, m.owner)
+}
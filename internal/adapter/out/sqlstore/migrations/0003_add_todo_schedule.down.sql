@@ -0,0 +1,3 @@
+DROP INDEX todos_due_at;
+ALTER TABLE todos DROP COLUMN start_at;
+ALTER TABLE todos DROP COLUMN due_at;
@@ -0,0 +1,4 @@
+DROP INDEX todos_created_at;
+ALTER TABLE todos DROP COLUMN completed_at;
+ALTER TABLE todos DROP COLUMN updated_at;
+ALTER TABLE todos DROP COLUMN created_at;
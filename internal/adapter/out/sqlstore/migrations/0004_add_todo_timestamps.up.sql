@@ -0,0 +1,4 @@
+ALTER TABLE todos ADD COLUMN created_at TEXT;
+ALTER TABLE todos ADD COLUMN updated_at TEXT;
+ALTER TABLE todos ADD COLUMN completed_at TEXT;
+CREATE INDEX todos_created_at ON todos (created_at, id);
@@ -0,0 +1,2 @@
+DROP INDEX todos_open_priority;
+ALTER TABLE todos DROP COLUMN priority;
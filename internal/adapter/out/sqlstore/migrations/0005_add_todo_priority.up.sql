@@ -0,0 +1,2 @@
+ALTER TABLE todos ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX todos_open_priority ON todos (completed, priority DESC, due_at, created_at);
@@ -0,0 +1,6 @@
+CREATE TABLE todo_tags (
+    tag     TEXT    NOT NULL,
+    todo_id INTEGER NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    PRIMARY KEY (tag, todo_id)
+) WITHOUT ROWID;
+CREATE INDEX todo_tags_todo_id ON todo_tags (todo_id, tag);
@@ -0,0 +1,3 @@
+DROP INDEX todos_project_id;
+ALTER TABLE todos DROP COLUMN project_id;
+DROP TABLE projects;
@@ -0,0 +1,10 @@
+CREATE TABLE projects (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    name        TEXT    NOT NULL,
+    description TEXT    NOT NULL DEFAULT '',
+    version     INTEGER NOT NULL DEFAULT 1,
+    created_at  TEXT,
+    updated_at  TEXT
+);
+ALTER TABLE todos ADD COLUMN project_id INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX todos_project_id ON todos (project_id, id);
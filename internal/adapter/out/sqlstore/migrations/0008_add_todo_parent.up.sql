@@ -0,0 +1,2 @@
+ALTER TABLE todos ADD COLUMN parent_id INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX todos_parent_id ON todos (parent_id, id);
@@ -0,0 +1,5 @@
+CREATE TABLE todo_blockers (
+    todo_id    INTEGER NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    blocker_id INTEGER NOT NULL,
+    PRIMARY KEY (todo_id, blocker_id)
+) WITHOUT ROWID;
@@ -0,0 +1,2 @@
+ALTER TABLE todos DROP COLUMN recurrence_tz;
+ALTER TABLE todos DROP COLUMN recurrence_rule;
@@ -0,0 +1,2 @@
+ALTER TABLE todos ADD COLUMN recurrence_rule TEXT NOT NULL DEFAULT '';
+ALTER TABLE todos ADD COLUMN recurrence_tz TEXT NOT NULL DEFAULT '';
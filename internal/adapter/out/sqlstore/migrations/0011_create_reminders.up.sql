@@ -0,0 +1,14 @@
+CREATE TABLE reminders (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    todo_id    INTEGER NOT NULL,
+    at         TEXT,
+    before_ns  INTEGER NOT NULL DEFAULT 0,
+    channel    TEXT    NOT NULL,
+    target     TEXT    NOT NULL DEFAULT '',
+    status     TEXT    NOT NULL,
+    attempts   TEXT    NOT NULL DEFAULT '[]',
+    created_at TEXT,
+    sent_at    TEXT
+);
+CREATE INDEX reminders_todo_id ON reminders (todo_id, id);
+CREATE INDEX reminders_status ON reminders (status, id);
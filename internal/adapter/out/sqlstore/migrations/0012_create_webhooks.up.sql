@@ -0,0 +1,17 @@
+CREATE TABLE webhooks (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    url        TEXT NOT NULL,
+    secret     TEXT NOT NULL,
+    events     TEXT NOT NULL DEFAULT '[]',
+    created_at TEXT,
+    updated_at TEXT
+);
+CREATE TABLE dead_letters (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id INTEGER NOT NULL,
+    event_id   TEXT    NOT NULL,
+    event      TEXT    NOT NULL,
+    payload    BLOB    NOT NULL,
+    attempts   TEXT    NOT NULL DEFAULT '[]',
+    created_at TEXT
+);
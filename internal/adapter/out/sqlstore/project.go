@@ -0,0 +1,172 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+)
+
+const projectColumns = `id, name, description, version, created_at, updated_at`
+
+func scanProject(row scanner) (*entity.Project, error) {
+	var (
+		project entity.Project
+		times   [2]sql.NullString
+	)
+	if err := row.Scan(&project.ID, &project.Name, &project.Description, &project.Version, &times[0], &times[1]); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if project.CreatedAt, err = parseTime(times[0]); err != nil {
+		return nil, err
+	}
+	if project.UpdatedAt, err = parseTime(times[1]); err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// mapProjectError is mapError for project statements.
+func mapProjectError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return uc_errors.ProjectNotFoundError
+	}
+	return mapError(err)
+}
+
+func (s *Store) CreateProject(ctx context.Context, project *entity.Project) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	row := s.db.QueryRowContext(ctx,
+		`INSERT INTO projects (name, description, version, created_at, updated_at)
+         VALUES (?, ?, 1, ?, ?)
+         RETURNING id, version`,
+		project.Name, project.Description, formatTime(project.CreatedAt), formatTime(project.UpdatedAt),
+	)
+	if err := row.Scan(&project.ID, &project.Version); err != nil {
+		return mapProjectError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) GetProject(ctx context.Context, id int64) (*entity.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	row := s.db.QueryRowContext(ctx, `SELECT `+projectColumns+` FROM projects WHERE id = ?`, id)
+
+	project, err := scanProject(row)
+	if err != nil {
+		return nil, mapProjectError(err)
+	}
+
+	return project, nil
+}
+
+func (s *Store) ListProjects(ctx context.Context, limit, offset int) ([]*entity.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if limit == 0 {
+		limit = -1
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+projectColumns+` FROM projects ORDER BY id LIMIT ? OFFSET ?`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, mapProjectError(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	projects := make([]*entity.Project, 0)
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (s *Store) CountProjects(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM projects`).Scan(&count); err != nil {
+		return 0, mapProjectError(err)
+	}
+
+	return count, nil
+}
+
+func (s *Store) UpdateProject(ctx context.Context, project *entity.Project, expectedVersion int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	row := s.db.QueryRowContext(ctx,
+		`UPDATE projects SET name = ?, description = ?, created_at = ?, updated_at = ?, version = version + 1
+         WHERE id = ? AND (? = 0 OR version = ?)
+         RETURNING version`,
+		project.Name, project.Description, formatTime(project.CreatedAt), formatTime(project.UpdatedAt),
+		project.ID, expectedVersion, expectedVersion,
+	)
+	if err := row.Scan(&project.Version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return s.missingOrConflictProject(ctx, project.ID)
+		}
+		return mapProjectError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeleteProject(ctx context.Context, id int64, expectedVersion int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	res, err := s.db.ExecContext(ctx,
+		`DELETE FROM projects WHERE id = ? AND (? = 0 OR version = ?)`,
+		id, expectedVersion, expectedVersion,
+	)
+	if err != nil {
+		return mapProjectError(err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return s.missingOrConflictProject(ctx, id)
+	}
+	return nil
+}
+
+func (s *Store) missingOrConflictProject(ctx context.Context, id int64) error {
+	var exists int
+	err := s.db.QueryRowContext(ctx, `SELECT 1 FROM projects WHERE id = ?`, id).Scan(&exists)
+	if err != nil {
+		return mapProjectError(err)
+	}
+	return uc_errors.ProjectVersionConflictError
+}
@@ -0,0 +1,202 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/query"
+
+	"modernc.org/sqlite"
+)
+
+// SQLite's lower() only folds ASCII, so OpContains is evaluated by the same
+// Go function the in-memory store uses.
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("todo_contains", 2,
+		func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			haystack, _ := args[0].(string)
+			needle, _ := args[1].(string)
+			return query.Contains(haystack, needle), nil
+		},
+	)
+}
+
+var columns = map[query.Field]string{
+	query.FieldID:          "id",
+	query.FieldTitle:       "title",
+	query.FieldDescription: "description",
+	query.FieldCompleted:   "completed",
+	query.FieldPriority:    "priority",
+	query.FieldProjectID:   "project_id",
+	query.FieldParentID:    "parent_id",
+	query.FieldDueAt:       "due_at",
+	query.FieldStartAt:     "start_at",
+	query.FieldCreatedAt:   "created_at",
+	query.FieldUpdatedAt:   "updated_at",
+	query.FieldCompletedAt: "completed_at",
+}
+
+func (s *Store) QueryTodos(ctx context.Context, q query.Query) ([]*entity.Todo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	where, args, err := whereClause(q.Filter)
+	if err != nil {
+		return nil, err
+	}
+	orderBy, err := orderByClause(q.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := q.Limit
+	if limit == 0 {
+		limit = -1
+	}
+	args = append(args, limit, q.Offset)
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+todoColumns+` FROM todos WHERE `+where+` ORDER BY `+orderBy+` LIMIT ? OFFSET ?`,
+		args...,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	todos := make([]*entity.Todo, 0)
+	for rows.Next() {
+		todo, err := scanTodo(rows)
+		if err != nil {
+			return nil, err
+		}
+		todos = append(todos, todo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return todos, nil
+}
+
+func (s *Store) CountTodos(ctx context.Context, filter query.Filter) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	where, args, err := whereClause(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM todos WHERE `+where, args...).Scan(&count); err != nil {
+		return 0, mapError(err)
+	}
+
+	return count, nil
+}
+
+func whereClause(f query.Filter) (string, []any, error) {
+	switch f := f.(type) {
+	case nil:
+		return "1", nil, nil
+	case query.Predicate:
+		column, ok := columns[f.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown filter field %q", f.Field)
+		}
+		if f.Op == query.OpContains {
+			return "todo_contains(" + column + ", ?)", []any{f.Value}, nil
+		}
+		if at, ok := f.Value.(time.Time); ok {
+			return timeClause(column, f.Op, at)
+		}
+		switch f.Op {
+		case query.OpEq, query.OpNe, query.OpLt, query.OpLe, query.OpGt, query.OpGe:
+			return column + " " + string(f.Op) + " ?", []any{f.Value}, nil
+		}
+		return "", nil, fmt.Errorf("unknown filter operator %q", f.Op)
+	case query.HasTag:
+		return "EXISTS (SELECT 1 FROM todo_tags WHERE todo_tags.tag = ? AND todo_tags.todo_id = todos.id)", []any{f.Tag}, nil
+	case query.And:
+		return joinClauses(f, " AND ", "1")
+	case query.Or:
+		return joinClauses(f, " OR ", "0")
+	case query.Not:
+		clause, args, err := whereClause(f.Filter)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT (" + clause + ")", args, nil
+	}
+
+	return "", nil, fmt.Errorf("unsupported filter %T", f)
+}
+
+// timeClause compares a nullable time column the way query.Predicate does:
+// NULL is the zero time and orders before every set time. Each branch is
+// two-valued so that NOT keeps working.
+func timeClause(column string, op query.Op, at time.Time) (string, []any, error) {
+	if at.IsZero() {
+		switch op {
+		case query.OpEq, query.OpLe:
+			return column + " IS NULL", nil, nil
+		case query.OpNe, query.OpGt:
+			return column + " IS NOT NULL", nil, nil
+		case query.OpGe:
+			return "1", nil, nil
+		case query.OpLt:
+			return "0", nil, nil
+		}
+		return "", nil, fmt.Errorf("unknown filter operator %q", op)
+	}
+
+	arg := []any{formatTime(at)}
+	switch op {
+	case query.OpEq, query.OpGt, query.OpGe:
+		return "(" + column + " IS NOT NULL AND " + column + " " + string(op) + " ?)", arg, nil
+	case query.OpNe, query.OpLt, query.OpLe:
+		return "(" + column + " IS NULL OR " + column + " " + string(op) + " ?)", arg, nil
+	}
+	return "", nil, fmt.Errorf("unknown filter operator %q", op)
+}
+
+func joinClauses(filters []query.Filter, sep, empty string) (string, []any, error) {
+	if len(filters) == 0 {
+		return empty, nil, nil
+	}
+
+	clauses := make([]string, len(filters))
+	var args []any
+	for i, f := range filters {
+		clause, clauseArgs, err := whereClause(f)
+		if err != nil {
+			return "", nil, err
+		}
+		clauses[i] = "(" + clause + ")"
+		args = append(args, clauseArgs...)
+	}
+
+	return strings.Join(clauses, sep), args, nil
+}
+
+func orderByClause(keys []query.SortKey) (string, error) {
+	terms := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		column, ok := columns[key.Field]
+		if !ok {
+			return "", fmt.Errorf("unknown sort field %q", key.Field)
+		}
+		if key.Desc {
+			column += " DESC"
+		}
+		terms = append(terms, column)
+	}
+
+	return strings.Join(append(terms, "id"), ", "), nil
+}
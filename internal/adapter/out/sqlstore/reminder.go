@@ -0,0 +1,181 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+)
+
+const reminderColumns = `id, todo_id, at, before_ns, channel, target, status, attempts, created_at, sent_at`
+
+func scanReminder(row scanner) (*entity.Reminder, error) {
+	var (
+		reminder entity.Reminder
+		before   int64
+		attempts string
+		times    [3]sql.NullString
+	)
+	err := row.Scan(&reminder.ID, &reminder.TodoID, &times[0], &before, &reminder.Channel, &reminder.Target,
+		&reminder.Status, &attempts, &times[1], &times[2])
+	if err != nil {
+		return nil, err
+	}
+	reminder.Before = time.Duration(before)
+
+	if err := json.Unmarshal([]byte(attempts), &reminder.Attempts); err != nil {
+		return nil, err
+	}
+	if len(reminder.Attempts) == 0 {
+		reminder.Attempts = nil
+	}
+
+	for i, dst := range []*time.Time{&reminder.At, &reminder.CreatedAt, &reminder.SentAt} {
+		if *dst, err = parseTime(times[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return &reminder, nil
+}
+
+func formatAttempts(attempts []entity.DeliveryAttempt) (string, error) {
+	if attempts == nil {
+		attempts = []entity.DeliveryAttempt{}
+	}
+	data, err := json.Marshal(attempts)
+	return string(data), err
+}
+
+// mapReminderError is mapError for reminder statements.
+func mapReminderError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return uc_errors.ReminderNotFoundError
+	}
+	return mapError(err)
+}
+
+func (s *Store) CreateReminder(ctx context.Context, reminder *entity.Reminder) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	attempts, err := formatAttempts(reminder.Attempts)
+	if err != nil {
+		return err
+	}
+
+	row := s.db.QueryRowContext(ctx,
+		`INSERT INTO reminders (todo_id, at, before_ns, channel, target, status, attempts, created_at, sent_at)
+         VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+         RETURNING id`,
+		reminder.TodoID, formatTime(reminder.At), int64(reminder.Before), reminder.Channel, reminder.Target,
+		reminder.Status, attempts, formatTime(reminder.CreatedAt), formatTime(reminder.SentAt),
+	)
+	if err := row.Scan(&reminder.ID); err != nil {
+		return mapReminderError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) GetReminder(ctx context.Context, id int64) (*entity.Reminder, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	row := s.db.QueryRowContext(ctx, `SELECT `+reminderColumns+` FROM reminders WHERE id = ?`, id)
+
+	reminder, err := scanReminder(row)
+	if err != nil {
+		return nil, mapReminderError(err)
+	}
+
+	return reminder, nil
+}
+
+func (s *Store) ListReminders(ctx context.Context, todoID int64) ([]*entity.Reminder, error) {
+	return s.queryReminders(ctx, `SELECT `+reminderColumns+` FROM reminders WHERE todo_id = ? ORDER BY id`, todoID)
+}
+
+func (s *Store) PendingReminders(ctx context.Context) ([]*entity.Reminder, error) {
+	return s.queryReminders(ctx, `SELECT `+reminderColumns+` FROM reminders WHERE status = ? ORDER BY id`, entity.ReminderPending)
+}
+
+func (s *Store) queryReminders(ctx context.Context, query string, args ...any) ([]*entity.Reminder, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, mapReminderError(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	reminders := make([]*entity.Reminder, 0)
+	for rows.Next() {
+		reminder, err := scanReminder(rows)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+func (s *Store) UpdateReminder(ctx context.Context, reminder *entity.Reminder) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	attempts, err := formatAttempts(reminder.Attempts)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE reminders SET todo_id = ?, at = ?, before_ns = ?, channel = ?, target = ?, status = ?, attempts = ?,
+             created_at = ?, sent_at = ?
+         WHERE id = ?`,
+		reminder.TodoID, formatTime(reminder.At), int64(reminder.Before), reminder.Channel, reminder.Target,
+		reminder.Status, attempts, formatTime(reminder.CreatedAt), formatTime(reminder.SentAt),
+		reminder.ID,
+	)
+	if err != nil {
+		return mapReminderError(err)
+	}
+	return checkAffected(res, uc_errors.ReminderNotFoundError)
+}
+
+func (s *Store) DeleteReminder(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	res, err := s.db.ExecContext(ctx, `DELETE FROM reminders WHERE id = ?`, id)
+	if err != nil {
+		return mapReminderError(err)
+	}
+	return checkAffected(res, uc_errors.ReminderNotFoundError)
+}
+
+// checkAffected fails with notFound when a statement keyed by id touched no
+// row.
+func checkAffected(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
+}
@@ -0,0 +1,337 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+)
+
+const DriverName = "sqlite"
+
+const todoColumns = `id, title, description, completed, priority, project_id, parent_id, recurrence_rule, recurrence_tz, version, due_at, start_at, created_at, updated_at, completed_at,
+    (SELECT json_group_array(tag) FROM todo_tags WHERE todo_id = todos.id),
+    (SELECT json_group_array(blocker_id) FROM todo_blockers WHERE todo_id = todos.id)`
+
+// timeLayout is RFC 3339 in UTC with a fixed-width fraction, so stored times
+// sort lexicographically in time order.
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+func Open(dsn string) (*Store, error) {
+	db, err := sql.Open(DriverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return New(db), nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTodo(row scanner) (*entity.Todo, error) {
+	var (
+		todo     entity.Todo
+		times    [5]sql.NullString
+		tags     string
+		blockers string
+	)
+	err := row.Scan(&todo.ID, &todo.Title, &todo.Description, &todo.Completed, &todo.Priority, &todo.ProjectID, &todo.ParentID,
+		&todo.Recurrence.Rule, &todo.Recurrence.TimeZone, &todo.Version,
+		&times[0], &times[1], &times[2], &times[3], &times[4], &tags, &blockers)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(tags), &todo.Tags); err != nil {
+		return nil, err
+	}
+	if len(todo.Tags) == 0 {
+		todo.Tags = nil
+	}
+	slices.Sort(todo.Tags)
+
+	if err := json.Unmarshal([]byte(blockers), &todo.BlockedBy); err != nil {
+		return nil, err
+	}
+	if len(todo.BlockedBy) == 0 {
+		todo.BlockedBy = nil
+	}
+	slices.Sort(todo.BlockedBy)
+
+	for i, dst := range []*time.Time{&todo.DueAt, &todo.StartAt, &todo.CreatedAt, &todo.UpdatedAt, &todo.CompletedAt} {
+		if *dst, err = parseTime(times[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return &todo, nil
+}
+
+// formatTime stores unset (zero) times as NULL.
+func formatTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(timeLayout)
+}
+
+func parseTime(s sql.NullString) (time.Time, error) {
+	if !s.Valid {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s.String)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
+
+func (s *Store) CreateTodo(ctx context.Context, todo *entity.Todo) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var id any
+	if todo.ID != 0 {
+		id = todo.ID
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	row := tx.QueryRowContext(ctx,
+		`INSERT INTO todos (id, title, description, completed, priority, project_id, parent_id, recurrence_rule, recurrence_tz,
+             version, due_at, start_at, created_at, updated_at, completed_at)
+         VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 1, ?, ?, ?, ?, ?)
+         RETURNING id, version`,
+		id, todo.Title, todo.Description, todo.Completed, todo.Priority, todo.ProjectID, todo.ParentID,
+		todo.Recurrence.Rule, todo.Recurrence.TimeZone, formatTime(todo.DueAt), formatTime(todo.StartAt),
+		formatTime(todo.CreatedAt), formatTime(todo.UpdatedAt), formatTime(todo.CompletedAt),
+	)
+	if err := row.Scan(&todo.ID, &todo.Version); err != nil {
+		return mapError(err)
+	}
+	if err := insertTags(ctx, tx, todo.ID, todo.Tags); err != nil {
+		return err
+	}
+	if err := insertBlockers(ctx, tx, todo.ID, todo.BlockedBy); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *Store) GetTodo(ctx context.Context, id int64) (*entity.Todo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	row := s.db.QueryRowContext(ctx, `SELECT `+todoColumns+` FROM todos WHERE id = ?`, id)
+
+	todo, err := scanTodo(row)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return todo, nil
+}
+
+func (s *Store) GetTodoList(ctx context.Context, limit, offset int) ([]*entity.Todo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// SQLite treats a negative LIMIT as "no limit".
+	if limit == 0 {
+		limit = -1
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+todoColumns+` FROM todos ORDER BY id LIMIT ? OFFSET ?`,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	todos := make([]*entity.Todo, 0)
+	for rows.Next() {
+		todo, err := scanTodo(rows)
+		if err != nil {
+			return nil, err
+		}
+		todos = append(todos, todo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return todos, nil
+}
+
+func (s *Store) UpdateTodo(ctx context.Context, todo *entity.Todo, expectedVersion int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	row := tx.QueryRowContext(ctx,
+		`UPDATE todos SET title = ?, description = ?, completed = ?, priority = ?, project_id = ?, parent_id = ?,
+             recurrence_rule = ?, recurrence_tz = ?, due_at = ?, start_at = ?,
+             created_at = ?, updated_at = ?, completed_at = ?, version = version + 1
+         WHERE id = ? AND (? = 0 OR version = ?)
+         RETURNING version`,
+		todo.Title, todo.Description, todo.Completed, todo.Priority, todo.ProjectID, todo.ParentID,
+		todo.Recurrence.Rule, todo.Recurrence.TimeZone, formatTime(todo.DueAt), formatTime(todo.StartAt),
+		formatTime(todo.CreatedAt), formatTime(todo.UpdatedAt), formatTime(todo.CompletedAt),
+		todo.ID, expectedVersion, expectedVersion,
+	)
+	if err := row.Scan(&todo.Version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			_ = tx.Rollback()
+			return s.missingOrConflict(ctx, todo.ID)
+		}
+		return mapError(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM todo_tags WHERE todo_id = ?`, todo.ID); err != nil {
+		return mapError(err)
+	}
+	if err := insertTags(ctx, tx, todo.ID, todo.Tags); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM todo_blockers WHERE todo_id = ?`, todo.ID); err != nil {
+		return mapError(err)
+	}
+	if err := insertBlockers(ctx, tx, todo.ID, todo.BlockedBy); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertTags(ctx context.Context, tx *sql.Tx, id int64, tags []string) error {
+	for _, tag := range tags {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO todo_tags (tag, todo_id) VALUES (?, ?)`, tag, id); err != nil {
+			return mapError(err)
+		}
+	}
+	return nil
+}
+
+// insertBlockers stores the todo's edges only; a blocker that is deleted later
+// keeps its edges, as it does in the in-memory storage.
+func insertBlockers(ctx context.Context, tx *sql.Tx, id int64, blockers []int64) error {
+	for _, blocker := range blockers {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO todo_blockers (todo_id, blocker_id) VALUES (?, ?)`, id, blocker); err != nil {
+			return mapError(err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) DeleteTodo(ctx context.Context, id int64, expectedVersion int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx,
+		`DELETE FROM todos WHERE id = ? AND (? = 0 OR version = ?)`,
+		id, expectedVersion, expectedVersion,
+	)
+	if err != nil {
+		return mapError(err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		_ = tx.Rollback()
+		return s.missingOrConflict(ctx, id)
+	}
+
+	// Tags and edges are removed explicitly as the DSN may leave foreign keys
+	// off.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM todo_tags WHERE todo_id = ?`, id); err != nil {
+		return mapError(err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM todo_blockers WHERE todo_id = ?`, id); err != nil {
+		return mapError(err)
+	}
+	return tx.Commit()
+}
+
+func (s *Store) ListTags(ctx context.Context) ([]entity.TagCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT tag, COUNT(*) FROM todo_tags GROUP BY tag ORDER BY tag`)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	tags := make([]entity.TagCount, 0)
+	for rows.Next() {
+		var tag entity.TagCount
+		if err := rows.Scan(&tag.Tag, &tag.Count); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// missingOrConflict explains why a versioned write touched no rows.
+func (s *Store) missingOrConflict(ctx context.Context, id int64) error {
+	var exists int
+	err := s.db.QueryRowContext(ctx, `SELECT 1 FROM todos WHERE id = ?`, id).Scan(&exists)
+	if err != nil {
+		return mapError(err)
+	}
+	return uc_errors.TodoVersionConflictError
+}
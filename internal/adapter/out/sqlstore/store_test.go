@@ -0,0 +1,549 @@
+package sqlstore_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+	"todo-api/internal/adapter/out/sqlstore"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/query"
+)
+
+func newStore(t *testing.T) *sqlstore.Store {
+	t.Helper()
+
+	s, err := sqlstore.Open(filepath.Join(t.TempDir(), "todos.db"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	if err := s.Migrate(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	return s
+}
+
+func TestStore_Migrate(t *testing.T) {
+	s := newStore(t)
+
+	if err := s.Migrate(context.Background()); err != nil {
+		t.Errorf("expected repeated migrate to be a no-op, got %v", err)
+	}
+}
+
+func TestStore_CreateTodo(t *testing.T) {
+	s := newStore(t)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		todo := entity.Todo{
+			Title:       "Get a coffee",
+			Description: "Get an ice-latte in Starbucks",
+		}
+		if err := s.CreateTodo(ctx, &todo); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if todo.ID == 0 {
+			t.Error("expected auto-gen id, got 0")
+		}
+	})
+
+	t.Run("Context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := s.CreateTodo(ctx, &entity.Todo{Title: "Get a coffee"}); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("Task already exists", func(t *testing.T) {
+		todo := entity.Todo{
+			ID:        10,
+			Title:     "Cook noodles",
+			Completed: true,
+		}
+
+		_ = s.CreateTodo(ctx, &todo)
+
+		if err := s.CreateTodo(ctx, &todo); !errors.Is(err, uc_errors.TodoAlreadyExistsError) {
+			t.Errorf("expected ErrTodoAlreadyExists, got %v", err)
+		}
+	})
+}
+
+func TestStore_GetTodo(t *testing.T) {
+	s := newStore(t)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		todo := entity.Todo{
+			Title:       "Get a coffee",
+			Description: "Get an ice-latte in Starbucks",
+			Completed:   true,
+			Tags:        []string{"coffee", "morning"},
+		}
+
+		_ = s.CreateTodo(ctx, &todo)
+
+		got, err := s.GetTodo(ctx, todo.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(*got, todo) {
+			t.Errorf("expected %v, got %v", todo, got)
+		}
+	})
+
+	t.Run("Task not found", func(t *testing.T) {
+		got, err := s.GetTodo(ctx, 1500)
+		if !errors.Is(err, uc_errors.TodoNotFoundError) {
+			t.Errorf("expected ErrTodoNotFound, got %v", err)
+		}
+		if got != nil {
+			t.Errorf("expected nothing, got %v", got)
+		}
+	})
+}
+
+func TestStore_GetList(t *testing.T) {
+	s := newStore(t)
+	ctx := context.Background()
+
+	titles := []string{"Get something", "Go somewhere", "Do somehow"}
+	for _, title := range titles {
+		_ = s.CreateTodo(ctx, &entity.Todo{Title: title})
+	}
+
+	t.Run("Limit and offset", func(t *testing.T) {
+		list, err := s.GetTodoList(ctx, 2, 1)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(list) != 2 {
+			t.Fatalf("expected 2 items, got %d", len(list))
+		}
+		if list[0].Title != titles[1] || list[1].Title != titles[2] {
+			t.Errorf("expected %v, got %v, %v", titles[1:], list[0], list[1])
+		}
+	})
+
+	t.Run("Zero limit returns everything", func(t *testing.T) {
+		list, err := s.GetTodoList(ctx, 0, 0)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(list) != len(titles) {
+			t.Errorf("expected %d items, got %d", len(titles), len(list))
+		}
+	})
+
+	t.Run("Offset past the end", func(t *testing.T) {
+		list, err := s.GetTodoList(ctx, 10, 10)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(list) != 0 {
+			t.Errorf("expected 0 items, got %d", len(list))
+		}
+	})
+}
+
+func TestStore_UpdateTodo(t *testing.T) {
+	s := newStore(t)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		todo := entity.Todo{Title: "Get a coffee"}
+		_ = s.CreateTodo(ctx, &todo)
+
+		todo.Title = "Get a cake"
+		todo.Completed = true
+		if err := s.UpdateTodo(ctx, &todo, 0); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if got, _ := s.GetTodo(ctx, todo.ID); !reflect.DeepEqual(*got, todo) {
+			t.Errorf("expected %v, got %v", todo, got)
+		}
+	})
+
+	t.Run("Task not found", func(t *testing.T) {
+		fakeTodo := entity.Todo{ID: 100, Title: "Get a pizza"}
+		if err := s.UpdateTodo(ctx, &fakeTodo, 0); !errors.Is(err, uc_errors.TodoNotFoundError) {
+			t.Errorf("expected ErrTodoNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Version", func(t *testing.T) {
+		todo := entity.Todo{Title: "Get a coffee"}
+		_ = s.CreateTodo(ctx, &todo)
+		if todo.Version != 1 {
+			t.Fatalf("expected version 1 after create, got %d", todo.Version)
+		}
+
+		if err := s.UpdateTodo(ctx, &todo, 1); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if todo.Version != 2 {
+			t.Errorf("expected version 2 after update, got %d", todo.Version)
+		}
+
+		stale := entity.Todo{ID: todo.ID, Title: "Get a tea"}
+		if err := s.UpdateTodo(ctx, &stale, 1); !errors.Is(err, uc_errors.TodoVersionConflictError) {
+			t.Errorf("expected ErrTodoVersionConflict, got %v", err)
+		}
+	})
+}
+
+func TestStore_DeleteTodo(t *testing.T) {
+	s := newStore(t)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		todo := entity.Todo{Title: "Get a coffee"}
+		_ = s.CreateTodo(ctx, &todo)
+
+		if err := s.DeleteTodo(ctx, todo.ID, 0); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("IDs are not reused", func(t *testing.T) {
+		todo := entity.Todo{Title: "Get a coffee"}
+		_ = s.CreateTodo(ctx, &todo)
+		_ = s.DeleteTodo(ctx, todo.ID, 0)
+
+		next := entity.Todo{Title: "Get a cake"}
+		_ = s.CreateTodo(ctx, &next)
+		if next.ID <= todo.ID {
+			t.Errorf("expected id greater than %d, got %d", todo.ID, next.ID)
+		}
+	})
+
+	t.Run("Task not found", func(t *testing.T) {
+		if err := s.DeleteTodo(ctx, 1000, 0); !errors.Is(err, uc_errors.TodoNotFoundError) {
+			t.Errorf("expected ErrTodoNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Version conflict", func(t *testing.T) {
+		todo := entity.Todo{Title: "Get a coffee"}
+		_ = s.CreateTodo(ctx, &todo)
+
+		if err := s.DeleteTodo(ctx, todo.ID, todo.Version+1); !errors.Is(err, uc_errors.TodoVersionConflictError) {
+			t.Errorf("expected ErrTodoVersionConflict, got %v", err)
+		}
+		if err := s.DeleteTodo(ctx, todo.ID, todo.Version); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+}
+
+// TestStore_QueryTodos checks that filters translated to SQL select, order and
+// count exactly what the in-memory evaluation does.
+func TestStore_QueryTodos(t *testing.T) {
+	s := newStore(t)
+	mem := storage.NewDataStorage()
+	ctx := context.Background()
+
+	due := time.Date(2026, 3, 1, 12, 0, 0, 500, time.UTC)
+	fixtures := []entity.Todo{
+		{Title: "Купить молоко", Description: "2 литра", DueAt: due, Recurrence: entity.Recurrence{Rule: "FREQ=WEEKLY;BYDAY=MO", TimeZone: "Europe/Moscow"}},
+		{Title: "Walk the dog", Completed: true, Priority: entity.PriorityHigh, DueAt: due.Add(-time.Hour), StartAt: due.Add(-2 * time.Hour)},
+		{Title: "buy bread", Description: "rye", Priority: entity.PriorityLow, Tags: []string{"food", "shop"}, ParentID: 1},
+		{Title: "Call mom", Description: "about the МОЛОКО", Completed: true, DueAt: due.Add(time.Nanosecond)},
+		{Title: "Buy bread", Tags: []string{"shop"}, BlockedBy: []int64{2, 3}, DueAt: due, CreatedAt: due.Add(-time.Hour), UpdatedAt: due, CompletedAt: due},
+	}
+	for _, todo := range fixtures {
+		_ = s.CreateTodo(ctx, &todo)
+		_ = mem.CreateTodo(ctx, &todo)
+	}
+
+	queries := map[string]query.Query{
+		"Unicode contains": {Filter: query.Predicate{Field: query.FieldDescription, Op: query.OpContains, Value: "молоко"}},
+		"Bool":             {Filter: query.Predicate{Field: query.FieldCompleted, Op: query.OpEq, Value: true}},
+		"Range": {Filter: query.And{
+			query.Predicate{Field: query.FieldID, Op: query.OpGt, Value: int64(1)},
+			query.Predicate{Field: query.FieldID, Op: query.OpLe, Value: int64(4)},
+		}},
+		"Or and not": {Filter: query.Or{
+			query.Not{Filter: query.Predicate{Field: query.FieldCompleted, Op: query.OpNe, Value: false}},
+			query.Predicate{Field: query.FieldTitle, Op: query.OpGe, Value: "Walk"},
+		}},
+		"Sort by title":      {Sort: []query.SortKey{{Field: query.FieldTitle}}},
+		"Sort desc and page": {Sort: []query.SortKey{{Field: query.FieldCompleted, Desc: true}, {Field: query.FieldTitle}}, Limit: 2, Offset: 1},
+		"Empty combinators":  {Filter: query.Or{query.And{}, query.Or{}}},
+		"Due before": {Filter: query.And{
+			query.IsSet(query.FieldDueAt),
+			query.Predicate{Field: query.FieldDueAt, Op: query.OpLt, Value: due.Add(time.Nanosecond)},
+		}},
+		"Due unset":           {Filter: query.Predicate{Field: query.FieldDueAt, Op: query.OpEq, Value: time.Time{}}},
+		"Not due at":          {Filter: query.Not{Filter: query.Predicate{Field: query.FieldDueAt, Op: query.OpEq, Value: due}}},
+		"Due range":           {Filter: query.Or{query.Predicate{Field: query.FieldDueAt, Op: query.OpLe, Value: due}, query.Predicate{Field: query.FieldStartAt, Op: query.OpGe, Value: due.Add(-3 * time.Hour)}}},
+		"Sort by due":         {Sort: []query.SortKey{{Field: query.FieldDueAt}}},
+		"Sort by created":     {Sort: []query.SortKey{{Field: query.FieldCreatedAt, Desc: true}, {Field: query.FieldCompletedAt}}},
+		"Sort by due desc":    {Sort: []query.SortKey{{Field: query.FieldDueAt, Desc: true}}, Limit: 3},
+		"Tag":                 {Filter: query.HasTag{Tag: "shop"}},
+		"Any tag":             {Filter: query.Or{query.HasTag{Tag: "food"}, query.HasTag{Tag: "home"}}},
+		"All tags":            {Filter: query.And{query.HasTag{Tag: "shop"}, query.Not{Filter: query.HasTag{Tag: "food"}}}},
+		"Priority":            {Filter: query.Predicate{Field: query.FieldPriority, Op: query.OpGe, Value: int64(entity.PriorityLow)}, Sort: []query.SortKey{{Field: query.FieldPriority, Desc: true}}},
+		"Subtasks":            {Filter: query.Or{query.Predicate{Field: query.FieldParentID, Op: query.OpEq, Value: int64(1)}, query.Predicate{Field: query.FieldParentID, Op: query.OpEq, Value: int64(3)}}},
+		"Filter, sort, limit": {Filter: query.Predicate{Field: query.FieldTitle, Op: query.OpContains, Value: "BREAD"}, Sort: []query.SortKey{{Field: query.FieldID, Desc: true}}, Limit: 1},
+	}
+
+	for name, q := range queries {
+		t.Run(name, func(t *testing.T) {
+			got, err := s.QueryTodos(ctx, q)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			expected, _ := mem.QueryTodos(ctx, q)
+
+			if len(got) != len(expected) {
+				t.Fatalf("expected %d items, got %d", len(expected), len(got))
+			}
+			for i := range got {
+				if !reflect.DeepEqual(*got[i], *expected[i]) {
+					t.Errorf("item %d: expected %v, got %v", i, expected[i], got[i])
+				}
+			}
+
+			count, err := s.CountTodos(ctx, q.Filter)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if expectedCount, _ := mem.CountTodos(ctx, q.Filter); count != expectedCount {
+				t.Errorf("expected count %d, got %d", expectedCount, count)
+			}
+		})
+	}
+}
+
+func TestStore_Tags(t *testing.T) {
+	s := newStore(t)
+	ctx := context.Background()
+
+	home := entity.Todo{Title: "Clean", Tags: []string{"home", "weekend"}}
+	shop := entity.Todo{Title: "Buy milk", Tags: []string{"home", "shop"}}
+	_ = s.CreateTodo(ctx, &home)
+	_ = s.CreateTodo(ctx, &shop)
+
+	shop.Tags = []string{"shop"}
+	if err := s.UpdateTodo(ctx, &shop, 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := s.DeleteTodo(ctx, home.ID, 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	got, _ := s.GetTodo(ctx, shop.ID)
+	if !reflect.DeepEqual(got.Tags, []string{"shop"}) {
+		t.Errorf("expected tags [shop], got %v", got.Tags)
+	}
+
+	tags, err := s.ListTags(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := []entity.TagCount{{Tag: "shop", Count: 1}}; !reflect.DeepEqual(tags, expected) {
+		t.Errorf("expected %v, got %v", expected, tags)
+	}
+
+	t.Run("Failed update keeps tags", func(t *testing.T) {
+		stale := *got
+		stale.Tags = []string{"stale"}
+		if err := s.UpdateTodo(ctx, &stale, got.Version+1); !errors.Is(err, uc_errors.TodoVersionConflictError) {
+			t.Fatalf("expected TodoVersionConflictError, got %v", err)
+		}
+		if after, _ := s.GetTodo(ctx, shop.ID); !reflect.DeepEqual(after.Tags, []string{"shop"}) {
+			t.Errorf("expected tags [shop], got %v", after.Tags)
+		}
+	})
+}
+
+func TestStore_Projects(t *testing.T) {
+	s := newStore(t)
+	ctx := context.Background()
+
+	home := entity.Project{Name: "Home", CreatedAt: time.Unix(100, 0).UTC()}
+	work := entity.Project{Name: "Work"}
+	if err := s.CreateProject(ctx, &home); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_ = s.CreateProject(ctx, &work)
+
+	todo := entity.Todo{Title: "Sweep", ProjectID: home.ID}
+	_ = s.CreateTodo(ctx, &todo)
+
+	t.Run("Success", func(t *testing.T) {
+		got, err := s.GetProject(ctx, home.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(*got, home) {
+			t.Errorf("expected %+v, got %+v", home, *got)
+		}
+
+		list, _ := s.ListProjects(ctx, 0, 1)
+		if len(list) != 1 || list[0].ID != work.ID {
+			t.Errorf("expected [Work], got %v", list)
+		}
+		if n, _ := s.CountProjects(ctx); n != 2 {
+			t.Errorf("expected 2 projects, got %d", n)
+		}
+
+		n, _ := s.CountTodos(ctx, query.Predicate{Field: query.FieldProjectID, Op: query.OpEq, Value: home.ID})
+		if n != 1 {
+			t.Errorf("expected 1 todo in the project, got %d", n)
+		}
+	})
+
+	t.Run("Error - version conflict", func(t *testing.T) {
+		home.Description = "chores"
+		if err := s.UpdateProject(ctx, &home, home.Version+1); !errors.Is(err, uc_errors.ProjectVersionConflictError) {
+			t.Errorf("expected ProjectVersionConflictError, got %v", err)
+		}
+		if err := s.DeleteProject(ctx, 42, 0); !errors.Is(err, uc_errors.ProjectNotFoundError) {
+			t.Errorf("expected ProjectNotFoundError, got %v", err)
+		}
+	})
+}
+
+func TestStore_Reminders(t *testing.T) {
+	s := newStore(t)
+	ctx := context.Background()
+
+	reminder := entity.Reminder{
+		TodoID:    3,
+		At:        time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+		Channel:   "webhook",
+		Target:    "https://example.com/hook",
+		Status:    entity.ReminderPending,
+		CreatedAt: time.Unix(100, 0).UTC(),
+	}
+	if err := s.CreateReminder(ctx, &reminder); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	relative := entity.Reminder{TodoID: 3, Before: 90 * time.Minute, Channel: "log", Status: entity.ReminderPending}
+	_ = s.CreateReminder(ctx, &relative)
+
+	t.Run("Success", func(t *testing.T) {
+		got, err := s.GetReminder(ctx, reminder.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(*got, reminder) {
+			t.Errorf("expected %+v, got %+v", reminder, *got)
+		}
+
+		list, _ := s.ListReminders(ctx, 3)
+		if len(list) != 2 || list[1].Before != 90*time.Minute {
+			t.Errorf("expected both reminders, got %v", list)
+		}
+	})
+
+	t.Run("Success - update", func(t *testing.T) {
+		reminder.Status = entity.ReminderSent
+		reminder.SentAt = time.Unix(300, 0).UTC()
+		reminder.Attempts = []entity.DeliveryAttempt{{At: time.Unix(200, 0).UTC(), Error: "503"}, {At: time.Unix(300, 0).UTC()}}
+		if err := s.UpdateReminder(ctx, &reminder); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		got, _ := s.GetReminder(ctx, reminder.ID)
+		if !reflect.DeepEqual(*got, reminder) {
+			t.Errorf("expected %+v, got %+v", reminder, *got)
+		}
+		pending, _ := s.PendingReminders(ctx)
+		if len(pending) != 1 || pending[0].ID != relative.ID {
+			t.Errorf("expected only reminder %d pending, got %v", relative.ID, pending)
+		}
+	})
+
+	t.Run("Error - deleted", func(t *testing.T) {
+		if err := s.DeleteReminder(ctx, relative.ID); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if err := s.DeleteReminder(ctx, relative.ID); !errors.Is(err, uc_errors.ReminderNotFoundError) {
+			t.Errorf("expected ReminderNotFoundError, got %v", err)
+		}
+		if _, err := s.GetReminder(ctx, relative.ID); !errors.Is(err, uc_errors.ReminderNotFoundError) {
+			t.Errorf("expected ReminderNotFoundError, got %v", err)
+		}
+	})
+}
+
+func TestStore_Webhooks(t *testing.T) {
+	s := newStore(t)
+	ctx := context.Background()
+
+	webhook := entity.Webhook{
+		URL:       "https://example.com/hook",
+		Secret:    "s3cret",
+		Events:    []entity.TodoEventType{entity.TodoEventCompleted, entity.TodoEventDeleted},
+		CreatedAt: time.Unix(100, 0).UTC(),
+		UpdatedAt: time.Unix(100, 0).UTC(),
+	}
+	if err := s.CreateWebhook(ctx, &webhook); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	letter := entity.DeadLetter{
+		WebhookID: webhook.ID,
+		EventID:   "e1",
+		Event:     entity.TodoEventDeleted,
+		Payload:   []byte(`{"id":"e1"}`),
+		Attempts:  []entity.DeliveryAttempt{{At: time.Unix(200, 0).UTC(), Error: "503"}},
+		CreatedAt: time.Unix(300, 0).UTC(),
+	}
+	if err := s.CreateDeadLetter(ctx, &letter); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		got, err := s.GetWebhook(ctx, webhook.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(*got, webhook) {
+			t.Errorf("expected %+v, got %+v", webhook, *got)
+		}
+
+		webhook.Events = nil
+		webhook.UpdatedAt = time.Unix(400, 0).UTC()
+		if err := s.UpdateWebhook(ctx, &webhook); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		list, _ := s.ListWebhooks(ctx)
+		if len(list) != 1 || !reflect.DeepEqual(*list[0], webhook) {
+			t.Errorf("expected %+v, got %v", webhook, list)
+		}
+
+		letters, _ := s.ListDeadLetters(ctx)
+		if len(letters) != 1 || !reflect.DeepEqual(*letters[0], letter) {
+			t.Errorf("expected %+v, got %v", letter, letters)
+		}
+	})
+
+	t.Run("Error - deleted", func(t *testing.T) {
+		if err := s.DeleteDeadLetter(ctx, letter.ID); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if _, err := s.GetDeadLetter(ctx, letter.ID); !errors.Is(err, uc_errors.DeadLetterNotFoundError) {
+			t.Errorf("expected DeadLetterNotFoundError, got %v", err)
+		}
+		if err := s.DeleteWebhook(ctx, webhook.ID); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if err := s.UpdateWebhook(ctx, &webhook); !errors.Is(err, uc_errors.WebhookNotFoundError) {
+			t.Errorf("expected WebhookNotFoundError, got %v", err)
+		}
+	})
+}
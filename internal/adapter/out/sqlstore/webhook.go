@@ -0,0 +1,263 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+)
+
+const (
+	webhookColumns    = `id, url, secret, events, created_at, updated_at`
+	deadLetterColumns = `id, webhook_id, event_id, event, payload, attempts, created_at`
+)
+
+func scanWebhook(row scanner) (*entity.Webhook, error) {
+	var (
+		webhook entity.Webhook
+		events  string
+		times   [2]sql.NullString
+	)
+	if err := row.Scan(&webhook.ID, &webhook.URL, &webhook.Secret, &events, &times[0], &times[1]); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(events), &webhook.Events); err != nil {
+		return nil, err
+	}
+	if len(webhook.Events) == 0 {
+		webhook.Events = nil
+	}
+
+	var err error
+	for i, dst := range []*time.Time{&webhook.CreatedAt, &webhook.UpdatedAt} {
+		if *dst, err = parseTime(times[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return &webhook, nil
+}
+
+func scanDeadLetter(row scanner) (*entity.DeadLetter, error) {
+	var (
+		letter    entity.DeadLetter
+		attempts  string
+		createdAt sql.NullString
+	)
+	err := row.Scan(&letter.ID, &letter.WebhookID, &letter.EventID, &letter.Event, &letter.Payload, &attempts, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(attempts), &letter.Attempts); err != nil {
+		return nil, err
+	}
+	if len(letter.Attempts) == 0 {
+		letter.Attempts = nil
+	}
+	if letter.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+
+	return &letter, nil
+}
+
+func formatEvents(events []entity.TodoEventType) (string, error) {
+	if events == nil {
+		events = []entity.TodoEventType{}
+	}
+	data, err := json.Marshal(events)
+	return string(data), err
+}
+
+// mapWebhookError and mapDeadLetterError are mapError for their statements.
+func mapWebhookError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return uc_errors.WebhookNotFoundError
+	}
+	return mapError(err)
+}
+
+func mapDeadLetterError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return uc_errors.DeadLetterNotFoundError
+	}
+	return mapError(err)
+}
+
+func (s *Store) CreateWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	events, err := formatEvents(webhook.Events)
+	if err != nil {
+		return err
+	}
+
+	row := s.db.QueryRowContext(ctx,
+		`INSERT INTO webhooks (url, secret, events, created_at, updated_at)
+         VALUES (?, ?, ?, ?, ?)
+         RETURNING id`,
+		webhook.URL, webhook.Secret, events, formatTime(webhook.CreatedAt), formatTime(webhook.UpdatedAt),
+	)
+	if err := row.Scan(&webhook.ID); err != nil {
+		return mapWebhookError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) GetWebhook(ctx context.Context, id int64) (*entity.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	row := s.db.QueryRowContext(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = ?`, id)
+
+	webhook, err := scanWebhook(row)
+	if err != nil {
+		return nil, mapWebhookError(err)
+	}
+
+	return webhook, nil
+}
+
+func (s *Store) ListWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+webhookColumns+` FROM webhooks ORDER BY id`)
+	if err != nil {
+		return nil, mapWebhookError(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	webhooks := make([]*entity.Webhook, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (s *Store) UpdateWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	events, err := formatEvents(webhook.Events)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE webhooks SET url = ?, secret = ?, events = ?, created_at = ?, updated_at = ? WHERE id = ?`,
+		webhook.URL, webhook.Secret, events, formatTime(webhook.CreatedAt), formatTime(webhook.UpdatedAt),
+		webhook.ID,
+	)
+	if err != nil {
+		return mapWebhookError(err)
+	}
+	return checkAffected(res, uc_errors.WebhookNotFoundError)
+}
+
+func (s *Store) DeleteWebhook(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	res, err := s.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		return mapWebhookError(err)
+	}
+	return checkAffected(res, uc_errors.WebhookNotFoundError)
+}
+
+func (s *Store) CreateDeadLetter(ctx context.Context, letter *entity.DeadLetter) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	attempts, err := formatAttempts(letter.Attempts)
+	if err != nil {
+		return err
+	}
+
+	row := s.db.QueryRowContext(ctx,
+		`INSERT INTO dead_letters (webhook_id, event_id, event, payload, attempts, created_at)
+         VALUES (?, ?, ?, ?, ?, ?)
+         RETURNING id`,
+		letter.WebhookID, letter.EventID, letter.Event, letter.Payload, attempts, formatTime(letter.CreatedAt),
+	)
+	if err := row.Scan(&letter.ID); err != nil {
+		return mapDeadLetterError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) GetDeadLetter(ctx context.Context, id int64) (*entity.DeadLetter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	row := s.db.QueryRowContext(ctx, `SELECT `+deadLetterColumns+` FROM dead_letters WHERE id = ?`, id)
+
+	letter, err := scanDeadLetter(row)
+	if err != nil {
+		return nil, mapDeadLetterError(err)
+	}
+
+	return letter, nil
+}
+
+func (s *Store) ListDeadLetters(ctx context.Context) ([]*entity.DeadLetter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+deadLetterColumns+` FROM dead_letters ORDER BY id`)
+	if err != nil {
+		return nil, mapDeadLetterError(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	letters := make([]*entity.DeadLetter, 0)
+	for rows.Next() {
+		letter, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, letter)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return letters, nil
+}
+
+func (s *Store) DeleteDeadLetter(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	res, err := s.db.ExecContext(ctx, `DELETE FROM dead_letters WHERE id = ?`, id)
+	if err != nil {
+		return mapDeadLetterError(err)
+	}
+	return checkAffected(res, uc_errors.DeadLetterNotFoundError)
+}
@@ -2,20 +2,48 @@ package storage
 
 import (
 	"context"
-	"sort"
+	"slices"
+	"strings"
 	"sync"
-	"sync/atomic"
 	"todo-api/internal/app/uc_errors"
 	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/query"
 )
 
 type DataStorage struct {
-	data   sync.Map
-	prevID int64
+	mu        sync.RWMutex
+	data      map[int64]entity.Todo
+	byID      *skipList[int64]
+	byDue     *skipList[dueKey]
+	byTag     map[string]*skipList[int64]
+	completed int
+	prevID    int64
+
+	projects      map[int64]entity.Project
+	prevProjectID int64
+
+	reminders      map[int64]entity.Reminder
+	prevReminderID int64
+
+	webhooks         map[int64]entity.Webhook
+	prevWebhookID    int64
+	deadLetters      map[int64]entity.DeadLetter
+	prevDeadLetterID int64
 }
 
 func NewDataStorage() *DataStorage {
-	return &DataStorage{}
+	return &DataStorage{
+		data:  make(map[int64]entity.Todo),
+		byID:  newSkipList(func(a, b int64) bool { return a < b }),
+		byDue: newSkipList(dueLess),
+		byTag: make(map[string]*skipList[int64]),
+
+		projects:  make(map[int64]entity.Project),
+		reminders: make(map[int64]entity.Reminder),
+
+		webhooks:    make(map[int64]entity.Webhook),
+		deadLetters: make(map[int64]entity.DeadLetter),
+	}
 }
 
 func (s *DataStorage) CreateTodo(ctx context.Context, todo *entity.Todo) error {
@@ -23,15 +51,20 @@ func (s *DataStorage) CreateTodo(ctx context.Context, todo *entity.Todo) error {
 		return err
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if todo.ID == 0 {
-		todo.ID = atomic.AddInt64(&s.prevID, 1)
+		s.prevID++
+		todo.ID = s.prevID
 	} else {
-		if _, exists := s.data.Load(todo.ID); exists {
+		if _, exists := s.data[todo.ID]; exists {
 			return uc_errors.TodoAlreadyExistsError
 		}
 	}
+	todo.Version = 1
 
-	s.data.Store(todo.ID, *todo)
+	s.put(*todo)
 	return nil
 }
 
@@ -40,13 +73,14 @@ func (s *DataStorage) GetTodo(ctx context.Context, id int64) (*entity.Todo, erro
 		return nil, err
 	}
 
-	raw, ok := s.data.Load(id)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	todo, ok := s.data[id]
 	if !ok {
 		return nil, uc_errors.TodoNotFoundError
 	}
 
-	todo := raw.(entity.Todo)
-
 	return &todo, nil
 }
 
@@ -55,69 +89,306 @@ func (s *DataStorage) GetTodoList(ctx context.Context, limit, offset int) ([]*en
 		return nil, err
 	}
 
-	var (
-		todos    []entity.Todo
-		rangeErr error
-	)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	s.data.Range(func(key, value any) bool {
-		select {
-		case <-ctx.Done():
-			rangeErr = ctx.Err()
-			return false
-		default:
-			todos = append(todos, value.(entity.Todo))
-			return true
-		}
-	})
+	total := s.byID.len()
+	if offset > total {
+		return []*entity.Todo{}, nil
+	}
 
-	if rangeErr != nil {
-		return nil, rangeErr
+	count := total - offset
+	if limit > 0 && limit < count {
+		count = limit
 	}
 
-	sort.Slice(todos, func(i, j int) bool {
-		return todos[i].ID < todos[j].ID
+	result := make([]*entity.Todo, 0, count)
+	s.byID.ascend(offset, func(id int64) bool {
+		todo := s.data[id]
+		result = append(result, &todo)
+		return len(result) < count
 	})
 
-	start := offset
-	if start > len(todos) {
+	return result, nil
+}
+
+func (s *DataStorage) QueryTodos(ctx context.Context, q query.Query) ([]*entity.Todo, error) {
+	if q.Filter == nil && len(q.Sort) == 0 {
+		return s.GetTodoList(ctx, q.Limit, q.Offset)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matched := make([]*entity.Todo, 0)
+
+	if from, to, ok := dueRange(q.Filter); ok {
+		s.scanDue(from, to, func(todo entity.Todo) bool {
+			if q.Filter.Match(&todo) {
+				matched = append(matched, &todo)
+			}
+			return true
+		})
+		slices.SortStableFunc(matched, query.Order(q.Sort))
+	} else {
+		// Without a sort the id index already yields the final order, so the
+		// scan can stop as soon as the page is full.
+		want := -1
+		if len(q.Sort) == 0 && q.Limit > 0 {
+			want = q.Offset + q.Limit
+		}
+
+		collect := func(id int64) bool {
+			todo := s.data[id]
+			if q.Filter == nil || q.Filter.Match(&todo) {
+				matched = append(matched, &todo)
+			}
+			return len(matched) != want
+		}
+
+		if ids, ok := s.tagCandidates(q.Filter); ok {
+			for _, id := range ids {
+				if !collect(id) {
+					break
+				}
+			}
+		} else {
+			s.byID.ascend(0, collect)
+		}
+
+		if len(q.Sort) > 0 {
+			slices.SortStableFunc(matched, query.Order(q.Sort))
+		}
+	}
+
+	if q.Offset > len(matched) {
 		return []*entity.Todo{}, nil
 	}
+	matched = matched[q.Offset:]
+	if q.Limit > 0 && q.Limit < len(matched) {
+		matched = matched[:q.Limit]
+	}
+
+	return matched, nil
+}
 
-	end := offset + limit
-	if end > len(todos) || limit == 0 {
-		end = len(todos)
+func (s *DataStorage) CountTodos(ctx context.Context, filter query.Filter) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
 	}
 
-	result := make([]*entity.Todo, 0, end-start)
-	for i := start; i < end; i++ {
-		result = append(result, &todos[i])
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if filter == nil {
+		return len(s.data), nil
 	}
 
-	return result, nil
+	// ?completed= is by far the most common filter, so it is answered from
+	// a counter instead of a scan.
+	if p, ok := filter.(query.Predicate); ok && p.Field == query.FieldCompleted && (p.Op == query.OpEq || p.Op == query.OpNe) {
+		if value, _ := p.Value.(bool); value == (p.Op == query.OpEq) {
+			return s.completed, nil
+		}
+		return len(s.data) - s.completed, nil
+	}
+
+	count := 0
+	if from, to, ok := dueRange(filter); ok {
+		s.scanDue(from, to, func(todo entity.Todo) bool {
+			if filter.Match(&todo) {
+				count++
+			}
+			return true
+		})
+		return count, nil
+	}
+
+	if h, ok := filter.(query.HasTag); ok {
+		if ids, exists := s.byTag[h.Tag]; exists {
+			return ids.len(), nil
+		}
+		return 0, nil
+	}
+	if ids, ok := s.tagCandidates(filter); ok {
+		for _, id := range ids {
+			todo := s.data[id]
+			if filter.Match(&todo) {
+				count++
+			}
+		}
+		return count, nil
+	}
+
+	for _, todo := range s.data {
+		if filter.Match(&todo) {
+			count++
+		}
+	}
+	return count, nil
 }
 
-func (s *DataStorage) UpdateTodo(ctx context.Context, todo *entity.Todo) error {
+func (s *DataStorage) ListTags(ctx context.Context) ([]entity.TagCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tags := make([]entity.TagCount, 0, len(s.byTag))
+	for tag, ids := range s.byTag {
+		tags = append(tags, entity.TagCount{Tag: tag, Count: ids.len()})
+	}
+	slices.SortFunc(tags, func(a, b entity.TagCount) int { return strings.Compare(a.Tag, b.Tag) })
+
+	return tags, nil
+}
+
+func (s *DataStorage) UpdateTodo(ctx context.Context, todo *entity.Todo, expectedVersion int64) error {
 	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	if _, ok := s.data.Load(todo.ID); !ok {
-		return uc_errors.TodoNotFoundError
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.checkVersion(todo.ID, expectedVersion)
+	if err != nil {
+		return err
 	}
+	todo.Version = current.Version + 1
 
-	s.data.Store(todo.ID, *todo)
+	s.put(*todo)
 	return nil
 }
 
-func (s *DataStorage) DeleteTodo(ctx context.Context, id int64) error {
+func (s *DataStorage) DeleteTodo(ctx context.Context, id int64, expectedVersion int64) error {
 	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	if _, ok := s.data.LoadAndDelete(id); !ok {
-		return uc_errors.TodoNotFoundError
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.checkVersion(id, expectedVersion); err != nil {
+		return err
 	}
 
+	s.remove(id)
 	return nil
 }
+
+func (s *DataStorage) checkVersion(id int64, expectedVersion int64) (entity.Todo, error) {
+	current, ok := s.data[id]
+	if !ok {
+		return entity.Todo{}, uc_errors.TodoNotFoundError
+	}
+	if expectedVersion != 0 && current.Version != expectedVersion {
+		return entity.Todo{}, uc_errors.TodoVersionConflictError
+	}
+	return current, nil
+}
+
+// put and remove keep the map and the indexes in step; callers hold s.mu.
+func (s *DataStorage) put(todo entity.Todo) {
+	if old, exists := s.data[todo.ID]; !exists {
+		s.byID.insert(todo.ID)
+	} else {
+		if old.Completed {
+			s.completed--
+		}
+		if !old.DueAt.IsZero() {
+			s.byDue.delete(dueKey{at: old.DueAt, id: old.ID})
+		}
+		s.untag(old)
+	}
+	if todo.Completed {
+		s.completed++
+	}
+	if !todo.DueAt.IsZero() {
+		s.byDue.insert(dueKey{at: todo.DueAt, id: todo.ID})
+	}
+	// The stored copy must not share its tags and edges with the caller's todo.
+	todo.Tags = slices.Clone(todo.Tags)
+	todo.BlockedBy = slices.Clone(todo.BlockedBy)
+	s.tag(todo)
+	s.data[todo.ID] = todo
+}
+
+func (s *DataStorage) remove(id int64) {
+	old, exists := s.data[id]
+	if !exists {
+		return
+	}
+	if old.Completed {
+		s.completed--
+	}
+	if !old.DueAt.IsZero() {
+		s.byDue.delete(dueKey{at: old.DueAt, id: id})
+	}
+	s.untag(old)
+	s.byID.delete(id)
+	delete(s.data, id)
+}
+
+// The helpers below let FileStorage drive the store while holding its own lock.
+
+func (s *DataStorage) exists(id int64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.data[id]
+	return ok
+}
+
+func (s *DataStorage) current(id int64, expectedVersion int64) (entity.Todo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.checkVersion(id, expectedVersion)
+}
+
+func (s *DataStorage) restore(todo entity.Todo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.put(todo)
+}
+
+func (s *DataStorage) forget(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(id)
+}
+
+func (s *DataStorage) all() []entity.Todo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	todos := make([]entity.Todo, 0, s.byID.len())
+	s.byID.ascend(0, func(id int64) bool {
+		todos = append(todos, s.data[id])
+		return true
+	})
+
+	return todos
+}
+
+func (s *DataStorage) lastID() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.prevID
+}
+
+func (s *DataStorage) setLastID(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prevID = id
+}
@@ -3,215 +3,678 @@ package storage_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
 	"testing"
+	"time"
 	"todo-api/internal/adapter/out/storage"
 	"todo-api/internal/app/uc_errors"
 	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+	"todo-api/internal/domain/query"
 )
 
+func forEachStorage(t *testing.T, test func(t *testing.T, newStorage func(t *testing.T) port.DataStorage)) {
+	factories := []struct {
+		name string
+		new  func(t *testing.T) port.DataStorage
+	}{
+		{
+			name: "Memory",
+			new: func(t *testing.T) port.DataStorage {
+				return storage.NewDataStorage()
+			},
+		},
+		{
+			name: "File",
+			new: func(t *testing.T) port.DataStorage {
+				return newFileStorage(t, t.TempDir())
+			},
+		},
+	}
+
+	for _, f := range factories {
+		t.Run(f.name, func(t *testing.T) {
+			test(t, f.new)
+		})
+	}
+}
+
 func TestStorage_CreateTodo(t *testing.T) {
-	s := storage.NewDataStorage()
-	ctx := context.Background()
+	forEachStorage(t, func(t *testing.T, newStorage func(t *testing.T) port.DataStorage) {
+		s := newStorage(t)
+		ctx := context.Background()
 
-	t.Run("Success", func(t *testing.T) {
-		todo := entity.Todo{
-			ID:          0,
-			Title:       "Get a coffee",
-			Description: "Get an ice-latte in Starbucks",
-			Completed:   false,
-		}
-		if err := s.CreateTodo(ctx, &todo); err != nil {
-			t.Fatalf("expected no error, got %v", err)
-			return
-		}
-		if todo.ID == 0 {
-			t.Error("expected auto-gen id, got 0")
-		}
+		t.Run("Success", func(t *testing.T) {
+			todo := entity.Todo{
+				ID:          0,
+				Title:       "Get a coffee",
+				Description: "Get an ice-latte in Starbucks",
+				Completed:   false,
+			}
+			if err := s.CreateTodo(ctx, &todo); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+				return
+			}
+			if todo.ID == 0 {
+				t.Error("expected auto-gen id, got 0")
+			}
+		})
+
+		t.Run("Context cancelled", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			todo := entity.Todo{
+				ID:          0,
+				Title:       "Get a coffee",
+				Description: "Get an ice-latte in Starbucks",
+				Completed:   false,
+			}
+
+			cancel()
+			if err := s.CreateTodo(ctx, &todo); !errors.Is(err, context.Canceled) {
+				t.Errorf("expected context.Canceled, got %v", err)
+			}
+		})
+
+		t.Run("Task already exists", func(t *testing.T) {
+			todo := entity.Todo{
+				ID:          10,
+				Title:       "Cook noodles",
+				Description: "",
+				Completed:   true,
+			}
+
+			_ = s.CreateTodo(ctx, &todo)
+
+			if err := s.CreateTodo(ctx, &todo); !errors.Is(err, uc_errors.TodoAlreadyExistsError) {
+				t.Errorf("expected ErrTodoAlreadyExists, got %v", err)
+			}
+		})
 	})
+}
 
-	t.Run("Context cancelled", func(t *testing.T) {
-		ctx, cancel := context.WithCancel(context.Background())
+func TestStorage_GetTodo(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, newStorage func(t *testing.T) port.DataStorage) {
+		s := newStorage(t)
+		ctx := context.Background()
 
-		todo := entity.Todo{
-			ID:          0,
-			Title:       "Get a coffee",
-			Description: "Get an ice-latte in Starbucks",
-			Completed:   false,
-		}
+		t.Run("Success", func(t *testing.T) {
+			todo := entity.Todo{
+				ID:          0,
+				Title:       "Get a coffee",
+				Description: "Get an ice-latte in Starbucks",
+				Completed:   false,
+			}
+
+			_ = s.CreateTodo(ctx, &todo)
+
+			got, err := s.GetTodo(ctx, todo.ID)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+				return
+			}
+
+			if todo.Title != got.Title || todo.Description != got.Description {
+				t.Errorf("expected %v, got %v", todo, got)
+			}
+		})
+
+		t.Run("Task not found", func(t *testing.T) {
+			var fakeID = int64(1500)
+
+			got, err := s.GetTodo(ctx, fakeID)
+			if !errors.Is(err, uc_errors.TodoNotFoundError) {
+				t.Errorf("expected ErrTodoNotFound, got %v", err)
+				return
+			}
+			if got != nil {
+				t.Errorf("expected nothing, got %v", got)
+			}
+		})
+	})
+}
 
-		cancel()
-		if err := s.CreateTodo(ctx, &todo); !errors.Is(err, context.Canceled) {
-			t.Errorf("expected context.Canceled, got %v", err)
-		}
+func TestStorage_GetList(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, newStorage func(t *testing.T) port.DataStorage) {
+		s := newStorage(t)
+		t.Run("Success", func(t *testing.T) {
+			ctx := context.Background()
+
+			todo1 := entity.Todo{Title: "Get something"}
+			todo2 := entity.Todo{Title: "Go somewhere"}
+			todo3 := entity.Todo{Title: "Do somehow"}
+			_ = s.CreateTodo(ctx, &todo1)
+			_ = s.CreateTodo(ctx, &todo2)
+			_ = s.CreateTodo(ctx, &todo3)
+
+			var limit, offset = 2, 0
+			list, err := s.GetTodoList(ctx, limit, offset)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if len(list) != 2 {
+				t.Errorf("expected 2 items, got %d", len(list))
+			}
+
+			if list[0].Title != todo1.Title || list[1].Title != todo2.Title {
+				t.Errorf("expected %v, %v, but got %v, %v", todo1, todo2, list[0], list[1])
+			}
+		})
+
+		t.Run("Empty list", func(t *testing.T) {
+			emptyStorage := newStorage(t)
+			list, err := emptyStorage.GetTodoList(context.Background(), 10, 0)
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if len(list) != 0 {
+				t.Errorf("expected 0 items, got %d", len(list))
+			}
+		})
+
+		t.Run("Context cancellation during Range", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			for i := 0; i < 100; i++ {
+				_ = s.CreateTodo(ctx, &entity.Todo{Title: "Get something"})
+			}
+
+			cancel()
+
+			_, err := s.GetTodoList(ctx, 100, 0)
+
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("expected context.Canceled error, got %v", err)
+			}
+		})
 	})
+}
 
-	t.Run("Task already exists", func(t *testing.T) {
-		todo := entity.Todo{
-			ID:          10,
-			Title:       "Cook noodles",
-			Description: "",
-			Completed:   true,
-		}
+func TestStorage_UpdateTodo(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, newStorage func(t *testing.T) port.DataStorage) {
+		s := newStorage(t)
+		ctx := context.Background()
 
-		_ = s.CreateTodo(ctx, &todo)
+		t.Run("Success", func(t *testing.T) {
+			todo := entity.Todo{
+				ID:          0,
+				Title:       "Get a coffee",
+				Description: "Get an ice-latte in Starbucks",
+				Completed:   false,
+			}
+
+			_ = s.CreateTodo(ctx, &todo)
+
+			todo.Title = "Get a cake"
+
+			if err := s.UpdateTodo(ctx, &todo, 0); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+
+		t.Run("Task not found", func(t *testing.T) {
+			var fakeTodo = entity.Todo{
+				ID:        100,
+				Title:     "Get a pizza",
+				Completed: true,
+			}
+
+			if err := s.UpdateTodo(ctx, &fakeTodo, 0); !errors.Is(err, uc_errors.TodoNotFoundError) {
+				t.Errorf("expected ErrTodoNotFound, got %v", err)
+			}
+		})
+
+		t.Run("Version", func(t *testing.T) {
+			todo := entity.Todo{Title: "Get a coffee"}
+			_ = s.CreateTodo(ctx, &todo)
+			if todo.Version != 1 {
+				t.Fatalf("expected version 1 after create, got %d", todo.Version)
+			}
+
+			if err := s.UpdateTodo(ctx, &todo, 1); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if todo.Version != 2 {
+				t.Errorf("expected version 2 after update, got %d", todo.Version)
+			}
+
+			stale := entity.Todo{ID: todo.ID, Title: "Get a tea"}
+			if err := s.UpdateTodo(ctx, &stale, 1); !errors.Is(err, uc_errors.TodoVersionConflictError) {
+				t.Errorf("expected ErrTodoVersionConflict, got %v", err)
+			}
+			if got, _ := s.GetTodo(ctx, todo.ID); got.Title != todo.Title || got.Version != 2 {
+				t.Errorf("expected stale update to be rejected, got %v", got)
+			}
+		})
+	})
+}
 
-		if err := s.CreateTodo(ctx, &todo); !errors.Is(err, uc_errors.TodoAlreadyExistsError) {
-			t.Errorf("expected ErrTodoAlreadyExists, got %v", err)
-		}
+func TestStorage_DeleteTodo(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, newStorage func(t *testing.T) port.DataStorage) {
+		s := newStorage(t)
+		ctx := context.Background()
+
+		t.Run("Success", func(t *testing.T) {
+			todo := entity.Todo{
+				ID:          0,
+				Title:       "Get a coffee",
+				Description: "Get an ice-latte in Starbucks",
+				Completed:   false,
+			}
+
+			_ = s.CreateTodo(ctx, &todo)
+
+			if err := s.DeleteTodo(ctx, todo.ID, 0); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+
+		t.Run("Task not found", func(t *testing.T) {
+			var fakeID = int64(1000)
+
+			if err := s.DeleteTodo(ctx, fakeID, 0); !errors.Is(err, uc_errors.TodoNotFoundError) {
+				t.Errorf("expected ErrTodoNotFound, got %v", err)
+			}
+		})
+
+		t.Run("Version conflict", func(t *testing.T) {
+			todo := entity.Todo{Title: "Get a coffee"}
+			_ = s.CreateTodo(ctx, &todo)
+
+			if err := s.DeleteTodo(ctx, todo.ID, todo.Version+1); !errors.Is(err, uc_errors.TodoVersionConflictError) {
+				t.Errorf("expected ErrTodoVersionConflict, got %v", err)
+			}
+			if err := s.DeleteTodo(ctx, todo.ID, todo.Version); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
 	})
 }
 
-func TestStorage_GetTodo(t *testing.T) {
+func TestStorage_ListIndex(t *testing.T) {
 	s := storage.NewDataStorage()
 	ctx := context.Background()
-
-	t.Run("Success", func(t *testing.T) {
-		todo := entity.Todo{
-			ID:          0,
-			Title:       "Get a coffee",
-			Description: "Get an ice-latte in Starbucks",
-			Completed:   false,
+	rnd := rand.New(rand.NewPCG(1, 2))
+
+	live := make(map[int64]bool)
+	for i := 0; i < 2000; i++ {
+		if len(live) > 0 && rnd.IntN(3) == 0 {
+			for id := range live {
+				_ = s.DeleteTodo(ctx, id, 0)
+				delete(live, id)
+				break
+			}
+			continue
 		}
-
+		todo := entity.Todo{Title: fmt.Sprintf("todo %d", i)}
 		_ = s.CreateTodo(ctx, &todo)
+		live[todo.ID] = true
+	}
+
+	want := make([]int64, 0, len(live))
+	for id := range live {
+		want = append(want, id)
+	}
+	sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+
+	for _, page := range []struct{ limit, offset int }{
+		{0, 0}, {10, 0}, {10, 5}, {25, len(want) - 10}, {10, len(want)}, {10, len(want) + 5},
+	} {
+		t.Run(fmt.Sprintf("limit=%d offset=%d", page.limit, page.offset), func(t *testing.T) {
+			list, err := s.GetTodoList(ctx, page.limit, page.offset)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			start := min(page.offset, len(want))
+			end := len(want)
+			if page.limit > 0 {
+				end = min(start+page.limit, len(want))
+			}
+			expected := want[start:end]
+
+			if len(list) != len(expected) {
+				t.Fatalf("expected %d items, got %d", len(expected), len(list))
+			}
+			for i := range list {
+				if list[i].ID != expected[i] {
+					t.Fatalf("expected id %d at %d, got %d", expected[i], i, list[i].ID)
+				}
+			}
+		})
+	}
+}
 
-		got, err := s.GetTodo(ctx, todo.ID)
-		if err != nil {
-			t.Fatalf("expected no error, got %v", err)
-			return
-		}
-
-		if todo.Title != got.Title || todo.Description != got.Description {
-			t.Errorf("expected %v, got %v", todo, got)
-		}
-	})
-
-	t.Run("Task not found", func(t *testing.T) {
-		var fakeID = int64(1500)
-
-		got, err := s.GetTodo(ctx, fakeID)
-		if !errors.Is(err, uc_errors.TodoNotFoundError) {
-			t.Errorf("expected ErrTodoNotFound, got %v", err)
-			return
-		}
-		if got != nil {
-			t.Errorf("expected nothing, got %v", got)
+func BenchmarkStorage_GetTodoList(b *testing.B) {
+	ctx := context.Background()
+	const limit = 20
+
+	for _, n := range []int{1_000, 10_000, 100_000} {
+		s := storage.NewDataStorage()
+		todos := make([]entity.Todo, 0, n)
+		for i := 0; i < n; i++ {
+			todo := entity.Todo{Title: "Get something"}
+			_ = s.CreateTodo(ctx, &todo)
+			todos = append(todos, todo)
 		}
-	})
+		offset := n / 2
+
+		b.Run(fmt.Sprintf("n=%d/indexed", n), func(b *testing.B) {
+			for b.Loop() {
+				if _, err := s.GetTodoList(ctx, limit, offset); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		// The pre-index implementation: copy everything, sort, slice one page.
+		b.Run(fmt.Sprintf("n=%d/full-sort", n), func(b *testing.B) {
+			for b.Loop() {
+				all := make([]entity.Todo, len(todos))
+				copy(all, todos)
+				sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+
+				page := make([]*entity.Todo, 0, limit)
+				for i := offset; i < offset+limit; i++ {
+					page = append(page, &all[i])
+				}
+			}
+		})
+	}
 }
 
-func TestStorage_GetList(t *testing.T) {
-	s := storage.NewDataStorage()
-	t.Run("Success", func(t *testing.T) {
+func TestStorage_QueryTodos(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, newStorage func(t *testing.T) port.DataStorage) {
+		s := newStorage(t)
 		ctx := context.Background()
 
-		todo1 := entity.Todo{Title: "Get something"}
-		todo2 := entity.Todo{Title: "Go somewhere"}
-		todo3 := entity.Todo{Title: "Do somehow"}
-		_ = s.CreateTodo(ctx, &todo1)
-		_ = s.CreateTodo(ctx, &todo2)
-		_ = s.CreateTodo(ctx, &todo3)
-
-		var limit, offset = 2, 0
-		list, err := s.GetTodoList(ctx, limit, offset)
-		if err != nil {
-			t.Fatalf("expected no error, got %v", err)
+		fixtures := []entity.Todo{
+			{Title: "Buy milk", Description: "2 liters"},
+			{Title: "Walk the dog", Completed: true},
+			{Title: "Buy bread", Description: "rye"},
+			{Title: "Call mom", Description: "about the MILK"},
 		}
-
-		if len(list) != 2 {
-			t.Errorf("expected 2 items, got %d", len(list))
+		for i := range fixtures {
+			_ = s.CreateTodo(ctx, &fixtures[i])
 		}
 
-		if list[0].Title != todo1.Title || list[1].Title != todo2.Title {
-			t.Errorf("expected %v, %v, but got %v, %v", todo1, todo2, list[0], list[1])
+		ids := func(todos []*entity.Todo) []int64 {
+			result := make([]int64, len(todos))
+			for i, todo := range todos {
+				result[i] = todo.ID
+			}
+			return result
 		}
-	})
 
-	t.Run("Empty list", func(t *testing.T) {
-		emptyStorage := storage.NewDataStorage()
-		list, err := emptyStorage.GetTodoList(context.Background(), 10, 0)
-		if err != nil {
-			t.Errorf("expected no error, got %v", err)
-		}
-		if len(list) != 0 {
-			t.Errorf("expected 0 items, got %d", len(list))
+		notCompleted := query.Predicate{Field: query.FieldCompleted, Op: query.OpEq, Value: false}
+		milk := query.Or{
+			query.Predicate{Field: query.FieldTitle, Op: query.OpContains, Value: "milk"},
+			query.Predicate{Field: query.FieldDescription, Op: query.OpContains, Value: "milk"},
 		}
-	})
 
-	t.Run("Context cancellation during Range", func(t *testing.T) {
-		ctx, cancel := context.WithCancel(context.Background())
-		for i := 0; i < 100; i++ {
-			_ = s.CreateTodo(ctx, &entity.Todo{Title: "Get something"})
+		cases := []struct {
+			name     string
+			query    query.Query
+			expected []int64
+		}{
+			{"No filter", query.Query{}, []int64{1, 2, 3, 4}},
+			{"Filter", query.Query{Filter: notCompleted}, []int64{1, 3, 4}},
+			{"Combined filter", query.Query{Filter: query.And{notCompleted, milk}}, []int64{1, 4}},
+			{"Not", query.Query{Filter: query.Not{Filter: milk}}, []int64{2, 3}},
+			{"Sort", query.Query{Sort: []query.SortKey{{Field: query.FieldTitle}}}, []int64{3, 1, 4, 2}},
+			{"Sort desc with tie-break", query.Query{Sort: []query.SortKey{{Field: query.FieldCompleted, Desc: true}}}, []int64{2, 1, 3, 4}},
+			{"Filter and page", query.Query{Filter: notCompleted, Limit: 1, Offset: 1}, []int64{3}},
+			{"Sort and page", query.Query{Sort: []query.SortKey{{Field: query.FieldID, Desc: true}}, Limit: 2, Offset: 1}, []int64{3, 2}},
+			{"Offset past the end", query.Query{Filter: notCompleted, Offset: 10}, []int64{}},
 		}
 
-		cancel()
-
-		_, err := s.GetTodoList(ctx, 100, 0)
-
-		if !errors.Is(err, context.Canceled) {
-			t.Errorf("expected context.Canceled error, got %v", err)
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				list, err := s.QueryTodos(ctx, tc.query)
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				if got := ids(list); fmt.Sprint(got) != fmt.Sprint(tc.expected) {
+					t.Errorf("expected %v, got %v", tc.expected, got)
+				}
+			})
 		}
 	})
 }
 
-func TestStorage_UpdateTodo(t *testing.T) {
-	s := storage.NewDataStorage()
-	ctx := context.Background()
+func TestStorage_CountTodos(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, newStorage func(t *testing.T) port.DataStorage) {
+		s := newStorage(t)
+		ctx := context.Background()
 
-	t.Run("Success", func(t *testing.T) {
-		todo := entity.Todo{
-			ID:          0,
-			Title:       "Get a coffee",
-			Description: "Get an ice-latte in Starbucks",
-			Completed:   false,
+		todos := []entity.Todo{
+			{Title: "Buy milk"},
+			{Title: "Walk the dog", Completed: true},
+			{Title: "Buy bread"},
 		}
-
-		_ = s.CreateTodo(ctx, &todo)
-
-		todo.Title = "Get a cake"
-
-		if err := s.UpdateTodo(ctx, &todo); err != nil {
-			t.Errorf("expected no error, got %v", err)
+		for i := range todos {
+			_ = s.CreateTodo(ctx, &todos[i])
 		}
-	})
 
-	t.Run("Task not found", func(t *testing.T) {
-		var fakeTodo = entity.Todo{
-			ID:        100,
-			Title:     "Get a pizza",
-			Completed: true,
+		// Flip one todo and delete another so that the completed counter has
+		// to follow updates and removals.
+		todos[0].Completed = true
+		_ = s.UpdateTodo(ctx, &todos[0], 0)
+		_ = s.DeleteTodo(ctx, todos[1].ID, 0)
+
+		cases := []struct {
+			name     string
+			filter   query.Filter
+			expected int
+		}{
+			{"All", nil, 2},
+			{"Completed", query.Predicate{Field: query.FieldCompleted, Op: query.OpEq, Value: true}, 1},
+			{"Not completed", query.Predicate{Field: query.FieldCompleted, Op: query.OpNe, Value: true}, 1},
+			{"Scan", query.Predicate{Field: query.FieldTitle, Op: query.OpContains, Value: "buy"}, 2},
+			{"No match", query.Predicate{Field: query.FieldID, Op: query.OpGt, Value: int64(100)}, 0},
 		}
 
-		if err := s.UpdateTodo(ctx, &fakeTodo); !errors.Is(err, uc_errors.TodoNotFoundError) {
-			t.Errorf("expected ErrTodoNotFound, got %v", err)
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				count, err := s.CountTodos(ctx, tc.filter)
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				if count != tc.expected {
+					t.Errorf("expected %d, got %d", tc.expected, count)
+				}
+			})
 		}
 	})
 }
 
-func TestStorage_DeleteTodo(t *testing.T) {
+// TestStorage_DueIndex checks that due date queries answered from the due
+// index return what a full scan would, while due dates move around.
+func TestStorage_DueIndex(t *testing.T) {
 	s := storage.NewDataStorage()
 	ctx := context.Background()
+	rnd := rand.New(rand.NewPCG(3, 4))
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
 
-	t.Run("Success", func(t *testing.T) {
-		todo := entity.Todo{
-			ID:          0,
-			Title:       "Get a coffee",
-			Description: "Get an ice-latte in Starbucks",
-			Completed:   false,
+	randomDue := func() time.Time {
+		if rnd.IntN(4) == 0 {
+			return time.Time{}
 		}
-
-		_ = s.CreateTodo(ctx, &todo)
-
-		if err := s.DeleteTodo(ctx, todo.ID); err != nil {
-			t.Errorf("expected no error, got %v", err)
+		return base.Add(time.Duration(rnd.IntN(100)) * time.Hour)
+	}
+
+	live := make(map[int64]entity.Todo)
+	for i := 0; i < 1000; i++ {
+		switch op := rnd.IntN(4); {
+		case op == 0 && len(live) > 0:
+			for id := range live {
+				_ = s.DeleteTodo(ctx, id, 0)
+				delete(live, id)
+				break
+			}
+		case op == 1 && len(live) > 0:
+			for _, todo := range live {
+				todo.DueAt = randomDue()
+				_ = s.UpdateTodo(ctx, &todo, 0)
+				live[todo.ID] = todo
+				break
+			}
+		default:
+			todo := entity.Todo{Title: fmt.Sprintf("todo %d", i), DueAt: randomDue(), Completed: rnd.IntN(2) == 0}
+			_ = s.CreateTodo(ctx, &todo)
+			live[todo.ID] = todo
 		}
-	})
+	}
+
+	at := func(hours int) time.Time { return base.Add(time.Duration(hours) * time.Hour) }
+	filters := map[string]query.Filter{
+		"Is set": query.IsSet(query.FieldDueAt),
+		"Before": query.And{
+			query.IsSet(query.FieldDueAt),
+			query.Predicate{Field: query.FieldDueAt, Op: query.OpLt, Value: at(30)},
+		},
+		"Window": query.And{
+			query.Predicate{Field: query.FieldDueAt, Op: query.OpGe, Value: at(20)},
+			query.Predicate{Field: query.FieldDueAt, Op: query.OpLe, Value: at(40)},
+			query.Predicate{Field: query.FieldCompleted, Op: query.OpEq, Value: false},
+		},
+		"Exact":  query.Predicate{Field: query.FieldDueAt, Op: query.OpEq, Value: at(50)},
+		"Unset":  query.Predicate{Field: query.FieldDueAt, Op: query.OpEq, Value: time.Time{}},
+		"Lt all": query.Predicate{Field: query.FieldDueAt, Op: query.OpLt, Value: at(10)},
+	}
+
+	for name, filter := range filters {
+		t.Run(name, func(t *testing.T) {
+			var expected []int64
+			for id, todo := range live {
+				if filter.Match(&todo) {
+					expected = append(expected, id)
+				}
+			}
+			sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+
+			list, err := s.QueryTodos(ctx, query.Query{Filter: filter})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			got := make([]int64, len(list))
+			for i, todo := range list {
+				got[i] = todo.ID
+			}
+			if fmt.Sprint(got) != fmt.Sprint(expected) {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+
+			if count, _ := s.CountTodos(ctx, filter); count != len(expected) {
+				t.Errorf("expected count %d, got %d", len(expected), count)
+			}
+		})
+	}
+}
 
-	t.Run("Task not found", func(t *testing.T) {
-		var fakeID = int64(1000)
+func TestStorage_TagIndex(t *testing.T) {
+	s := storage.NewDataStorage()
+	ctx := context.Background()
+	rnd := rand.New(rand.NewPCG(5, 6))
+	names := []string{"home", "shop", "work", "urgent"}
+
+	randomTags := func() []string {
+		var tags []string
+		for _, name := range names {
+			if rnd.IntN(3) == 0 {
+				tags = append(tags, name)
+			}
+		}
+		return tags
+	}
+
+	live := make(map[int64]entity.Todo)
+	for i := 0; i < 1000; i++ {
+		switch op := rnd.IntN(4); {
+		case op == 0 && len(live) > 0:
+			for id := range live {
+				_ = s.DeleteTodo(ctx, id, 0)
+				delete(live, id)
+				break
+			}
+		case op == 1 && len(live) > 0:
+			for _, todo := range live {
+				todo.Tags = randomTags()
+				_ = s.UpdateTodo(ctx, &todo, 0)
+				live[todo.ID] = todo
+				break
+			}
+		default:
+			todo := entity.Todo{Title: fmt.Sprintf("todo %d", i), Tags: randomTags(), Completed: rnd.IntN(2) == 0}
+			_ = s.CreateTodo(ctx, &todo)
+			live[todo.ID] = todo
+		}
+	}
+
+	filters := map[string]query.Filter{
+		"Tag":     query.HasTag{Tag: "home"},
+		"Missing": query.HasTag{Tag: "garden"},
+		"All":     query.And{query.HasTag{Tag: "home"}, query.HasTag{Tag: "work"}},
+		"Any":     query.Or{query.HasTag{Tag: "shop"}, query.HasTag{Tag: "urgent"}},
+		"Any and open": query.And{
+			query.Predicate{Field: query.FieldCompleted, Op: query.OpEq, Value: false},
+			query.Or{query.HasTag{Tag: "home"}, query.HasTag{Tag: "garden"}},
+		},
+		"Without": query.And{query.HasTag{Tag: "work"}, query.Not{Filter: query.HasTag{Tag: "shop"}}},
+	}
+
+	for name, filter := range filters {
+		t.Run(name, func(t *testing.T) {
+			var expected []int64
+			for id, todo := range live {
+				if filter.Match(&todo) {
+					expected = append(expected, id)
+				}
+			}
+			sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+
+			list, err := s.QueryTodos(ctx, query.Query{Filter: filter})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			got := make([]int64, len(list))
+			for i, todo := range list {
+				got[i] = todo.ID
+			}
+			if fmt.Sprint(got) != fmt.Sprint(expected) {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+
+			if count, _ := s.CountTodos(ctx, filter); count != len(expected) {
+				t.Errorf("expected count %d, got %d", len(expected), count)
+			}
+		})
+	}
+
+	t.Run("ListTags", func(t *testing.T) {
+		counts := make(map[string]int)
+		for _, todo := range live {
+			for _, tag := range todo.Tags {
+				counts[tag]++
+			}
+		}
 
-		if err := s.DeleteTodo(ctx, fakeID); !errors.Is(err, uc_errors.TodoNotFoundError) {
-			t.Errorf("expected ErrTodoNotFound, got %v", err)
+		tags, err := s.ListTags(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(tags) != len(counts) {
+			t.Fatalf("expected %d tags, got %v", len(counts), tags)
+		}
+		for i, tag := range tags {
+			if i > 0 && tags[i-1].Tag >= tag.Tag {
+				t.Errorf("expected tags in order, got %v", tags)
+			}
+			if counts[tag.Tag] != tag.Count {
+				t.Errorf("tag %q: expected count %d, got %d", tag.Tag, counts[tag.Tag], tag.Count)
+			}
 		}
 	})
 }
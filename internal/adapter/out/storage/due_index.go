@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"time"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/query"
+)
+
+// dueKey orders todos that have a due date by (DueAt, ID).
+type dueKey struct {
+	at time.Time
+	id int64
+}
+
+func dueLess(a, b dueKey) bool {
+	if c := a.at.Compare(b.at); c != 0 {
+		return c < 0
+	}
+	return a.id < b.id
+}
+
+// dueRange derives bounds on due_at from the top-level predicates of filter.
+// ok is true only when the filter cannot match a todo without a due date, so
+// that the due index holds every candidate. Zero bounds are open.
+func dueRange(filter query.Filter) (from, to time.Time, ok bool) {
+	var predicates []query.Predicate
+	switch f := filter.(type) {
+	case query.Predicate:
+		predicates = append(predicates, f)
+	case query.And:
+		for _, member := range f {
+			if p, isPredicate := member.(query.Predicate); isPredicate {
+				predicates = append(predicates, p)
+			}
+		}
+	}
+
+	for _, p := range predicates {
+		if p.Field != query.FieldDueAt {
+			continue
+		}
+		at, _ := p.Value.(time.Time)
+
+		switch p.Op {
+		case query.OpGt:
+			ok = true
+		case query.OpGe, query.OpEq:
+			ok = ok || !at.IsZero()
+		}
+		switch p.Op {
+		case query.OpGt, query.OpGe, query.OpEq:
+			if at.After(from) {
+				from = at
+			}
+		}
+		switch p.Op {
+		case query.OpLt, query.OpLe, query.OpEq:
+			if !at.IsZero() && (to.IsZero() || at.Before(to)) {
+				to = at
+			}
+		}
+	}
+
+	return from, to, ok
+}
+
+// scanDue calls fn for every todo due within [from, to] in due order until fn
+// returns false; callers hold s.mu.
+func (s *DataStorage) scanDue(from, to time.Time, fn func(todo entity.Todo) bool) {
+	s.byDue.ascend(s.byDue.rank(dueKey{at: from}), func(key dueKey) bool {
+		if !to.IsZero() && key.at.After(to) {
+			return false
+		}
+		return fn(s.data[key.id])
+	})
+}
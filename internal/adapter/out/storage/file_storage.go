@@ -0,0 +1,653 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/query"
+)
+
+const walFileName = "todos.wal"
+
+type FileStorageConfig struct {
+	Dir          string
+	SyncPolicy   SyncPolicy
+	SyncInterval time.Duration
+
+	SnapshotInterval time.Duration
+	SnapshotRetain   int
+
+	Logger *slog.Logger
+}
+
+// FileStorage serves reads from an in-memory DataStorage and makes every
+// mutation durable by appending it to a write-ahead log before applying it.
+// Snapshots of the whole set let the log be truncated so boot only replays
+// the tail written since the last one.
+type FileStorage struct {
+	mu     sync.Mutex
+	mem    *DataStorage
+	log    *wal
+	dir    string
+	seq    uint64
+	retain int
+	logger *slog.Logger
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewFileStorage(cfg FileStorageConfig) (*FileStorage, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create storage dir: %w", err)
+	}
+
+	s := &FileStorage{
+		mem:    NewDataStorage(),
+		dir:    cfg.Dir,
+		retain: cfg.SnapshotRetain,
+		logger: cfg.Logger,
+	}
+
+	if err := s.loadSnapshot(); err != nil {
+		return nil, fmt.Errorf("load snapshot: %w", err)
+	}
+
+	log, err := openWAL(filepath.Join(cfg.Dir, walFileName), cfg.SyncPolicy, cfg.SyncInterval)
+	if err != nil {
+		return nil, fmt.Errorf("open wal: %w", err)
+	}
+	s.log = log
+
+	if err := log.replay(s.apply); err != nil {
+		_ = log.close()
+		return nil, fmt.Errorf("replay wal: %w", err)
+	}
+
+	if cfg.SnapshotInterval > 0 {
+		s.stop = make(chan struct{})
+		s.done = make(chan struct{})
+		go s.snapshotLoop(cfg.SnapshotInterval)
+	}
+
+	return s, nil
+}
+
+// loadSnapshot restores the newest readable snapshot, falling back to older
+// ones if the latest is damaged.
+// loadSnapshot restores the newest snapshot. The WAL only holds the writes
+// made after it, so an older snapshot cannot stand in for an unreadable one
+// without losing the writes in between; opening fails instead.
+func (s *FileStorage) loadSnapshot() error {
+	seqs, err := listSnapshots(s.dir)
+	if err != nil {
+		return err
+	}
+	if len(seqs) == 0 {
+		return nil
+	}
+	s.seq = seqs[0]
+
+	snap, err := readSnapshot(s.dir, s.seq)
+	if err != nil {
+		return fmt.Errorf("read snapshot %d: %w", s.seq, err)
+	}
+	for _, todo := range snap.Todos {
+		s.mem.restore(todo)
+	}
+	s.mem.setLastID(snap.PrevID)
+	for _, project := range snap.Projects {
+		s.mem.restoreProject(project)
+	}
+	s.mem.setLastProjectID(snap.PrevProjectID)
+	for _, reminder := range snap.Reminders {
+		s.mem.restoreReminder(reminder)
+	}
+	s.mem.setLastReminderID(snap.PrevReminderID)
+	for _, webhook := range snap.Webhooks {
+		s.mem.restoreWebhook(webhook)
+	}
+	for _, letter := range snap.DeadLetters {
+		s.mem.restoreDeadLetter(letter)
+	}
+	s.mem.setLastWebhookIDs(snap.PrevWebhookID, snap.PrevDeadLetterID)
+	return nil
+}
+
+func (s *FileStorage) apply(rec walRecord) error {
+	switch rec.Op {
+	case walOpCreate, walOpUpdate:
+		if rec.Todo == nil {
+			return fmt.Errorf("%s record without todo", rec.Op)
+		}
+		s.mem.restore(*rec.Todo)
+	case walOpDelete:
+		s.mem.forget(rec.ID)
+	case walOpCreateProject, walOpUpdateProject:
+		if rec.Project == nil {
+			return fmt.Errorf("%s record without project", rec.Op)
+		}
+		s.mem.restoreProject(*rec.Project)
+	case walOpDeleteProject:
+		s.mem.forgetProject(rec.ID)
+	case walOpCreateReminder, walOpUpdateReminder:
+		if rec.Reminder == nil {
+			return fmt.Errorf("%s record without reminder", rec.Op)
+		}
+		s.mem.restoreReminder(*rec.Reminder)
+	case walOpDeleteReminder:
+		s.mem.forgetReminder(rec.ID)
+	case walOpCreateWebhook, walOpUpdateWebhook:
+		if rec.Webhook == nil {
+			return fmt.Errorf("%s record without webhook", rec.Op)
+		}
+		s.mem.restoreWebhook(*rec.Webhook)
+	case walOpDeleteWebhook:
+		s.mem.forgetWebhook(rec.ID)
+	case walOpCreateDeadLetter:
+		if rec.DeadLetter == nil {
+			return fmt.Errorf("%s record without dead letter", rec.Op)
+		}
+		s.mem.restoreDeadLetter(*rec.DeadLetter)
+	case walOpDeleteDeadLetter:
+		s.mem.forgetDeadLetter(rec.ID)
+	default:
+		return fmt.Errorf("unknown wal op %q", rec.Op)
+	}
+
+	s.mem.setLastID(rec.PrevID)
+	s.mem.setLastProjectID(rec.PrevProjectID)
+	s.mem.setLastReminderID(rec.PrevReminderID)
+	s.mem.setLastWebhookIDs(rec.PrevWebhookID, rec.PrevDeadLetterID)
+	return nil
+}
+
+// record starts a log record carrying the current id counters; creations
+// bump the counter they draw from.
+func (s *FileStorage) record(op walOp) walRecord {
+	rec := walRecord{
+		Op:     op,
+		PrevID: s.mem.lastID(),
+
+		PrevProjectID:  s.mem.lastProjectID(),
+		PrevReminderID: s.mem.lastReminderID(),
+	}
+	rec.PrevWebhookID, rec.PrevDeadLetterID = s.mem.lastWebhookIDs()
+	return rec
+}
+
+func (s *FileStorage) CreateTodo(ctx context.Context, todo *entity.Todo) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec := s.record(walOpCreate)
+
+	created := *todo
+	if created.ID == 0 {
+		rec.PrevID++
+		created.ID = rec.PrevID
+	} else if s.mem.exists(created.ID) {
+		return uc_errors.TodoAlreadyExistsError
+	}
+	created.Version = 1
+	rec.Todo = &created
+
+	if err := s.log.append(rec); err != nil {
+		return err
+	}
+	if err := s.apply(rec); err != nil {
+		return err
+	}
+
+	todo.ID = created.ID
+	todo.Version = created.Version
+	return nil
+}
+
+func (s *FileStorage) GetTodo(ctx context.Context, id int64) (*entity.Todo, error) {
+	return s.mem.GetTodo(ctx, id)
+}
+
+func (s *FileStorage) GetTodoList(ctx context.Context, limit, offset int) ([]*entity.Todo, error) {
+	return s.mem.GetTodoList(ctx, limit, offset)
+}
+
+func (s *FileStorage) QueryTodos(ctx context.Context, q query.Query) ([]*entity.Todo, error) {
+	return s.mem.QueryTodos(ctx, q)
+}
+
+func (s *FileStorage) CountTodos(ctx context.Context, filter query.Filter) (int, error) {
+	return s.mem.CountTodos(ctx, filter)
+}
+
+func (s *FileStorage) ListTags(ctx context.Context) ([]entity.TagCount, error) {
+	return s.mem.ListTags(ctx)
+}
+
+func (s *FileStorage) UpdateTodo(ctx context.Context, todo *entity.Todo, expectedVersion int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.mem.current(todo.ID, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	updated := *todo
+	updated.Version = current.Version + 1
+	rec := s.record(walOpUpdate)
+	rec.Todo = &updated
+
+	if err := s.log.append(rec); err != nil {
+		return err
+	}
+	if err := s.apply(rec); err != nil {
+		return err
+	}
+
+	todo.Version = updated.Version
+	return nil
+}
+
+func (s *FileStorage) DeleteTodo(ctx context.Context, id int64, expectedVersion int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.mem.current(id, expectedVersion); err != nil {
+		return err
+	}
+
+	rec := s.record(walOpDelete)
+	rec.ID = id
+
+	if err := s.log.append(rec); err != nil {
+		return err
+	}
+	return s.apply(rec)
+}
+
+func (s *FileStorage) CreateProject(ctx context.Context, project *entity.Project) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec := s.record(walOpCreateProject)
+	rec.PrevProjectID++
+
+	created := *project
+	created.ID = rec.PrevProjectID
+	created.Version = 1
+	rec.Project = &created
+
+	if err := s.log.append(rec); err != nil {
+		return err
+	}
+	if err := s.apply(rec); err != nil {
+		return err
+	}
+
+	project.ID = created.ID
+	project.Version = created.Version
+	return nil
+}
+
+func (s *FileStorage) GetProject(ctx context.Context, id int64) (*entity.Project, error) {
+	return s.mem.GetProject(ctx, id)
+}
+
+func (s *FileStorage) ListProjects(ctx context.Context, limit, offset int) ([]*entity.Project, error) {
+	return s.mem.ListProjects(ctx, limit, offset)
+}
+
+func (s *FileStorage) CountProjects(ctx context.Context) (int, error) {
+	return s.mem.CountProjects(ctx)
+}
+
+func (s *FileStorage) UpdateProject(ctx context.Context, project *entity.Project, expectedVersion int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.mem.currentProject(project.ID, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	updated := *project
+	updated.Version = current.Version + 1
+	rec := s.record(walOpUpdateProject)
+	rec.Project = &updated
+
+	if err := s.log.append(rec); err != nil {
+		return err
+	}
+	if err := s.apply(rec); err != nil {
+		return err
+	}
+
+	project.Version = updated.Version
+	return nil
+}
+
+func (s *FileStorage) DeleteProject(ctx context.Context, id int64, expectedVersion int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.mem.currentProject(id, expectedVersion); err != nil {
+		return err
+	}
+
+	rec := s.record(walOpDeleteProject)
+	rec.ID = id
+
+	if err := s.log.append(rec); err != nil {
+		return err
+	}
+	return s.apply(rec)
+}
+
+func (s *FileStorage) CreateReminder(ctx context.Context, reminder *entity.Reminder) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec := s.record(walOpCreateReminder)
+	rec.PrevReminderID++
+
+	created := reminder.Clone()
+	created.ID = rec.PrevReminderID
+	rec.Reminder = &created
+
+	if err := s.log.append(rec); err != nil {
+		return err
+	}
+	if err := s.apply(rec); err != nil {
+		return err
+	}
+
+	reminder.ID = created.ID
+	return nil
+}
+
+func (s *FileStorage) GetReminder(ctx context.Context, id int64) (*entity.Reminder, error) {
+	return s.mem.GetReminder(ctx, id)
+}
+
+func (s *FileStorage) ListReminders(ctx context.Context, todoID int64) ([]*entity.Reminder, error) {
+	return s.mem.ListReminders(ctx, todoID)
+}
+
+func (s *FileStorage) PendingReminders(ctx context.Context) ([]*entity.Reminder, error) {
+	return s.mem.PendingReminders(ctx)
+}
+
+func (s *FileStorage) UpdateReminder(ctx context.Context, reminder *entity.Reminder) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.mem.reminderExists(reminder.ID) {
+		return uc_errors.ReminderNotFoundError
+	}
+
+	updated := reminder.Clone()
+	rec := s.record(walOpUpdateReminder)
+	rec.Reminder = &updated
+
+	if err := s.log.append(rec); err != nil {
+		return err
+	}
+	return s.apply(rec)
+}
+
+func (s *FileStorage) DeleteReminder(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.mem.reminderExists(id) {
+		return uc_errors.ReminderNotFoundError
+	}
+
+	rec := s.record(walOpDeleteReminder)
+	rec.ID = id
+
+	if err := s.log.append(rec); err != nil {
+		return err
+	}
+	return s.apply(rec)
+}
+
+func (s *FileStorage) CreateWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec := s.record(walOpCreateWebhook)
+	rec.PrevWebhookID++
+
+	created := webhook.Clone()
+	created.ID = rec.PrevWebhookID
+	rec.Webhook = &created
+
+	if err := s.log.append(rec); err != nil {
+		return err
+	}
+	if err := s.apply(rec); err != nil {
+		return err
+	}
+
+	webhook.ID = created.ID
+	return nil
+}
+
+func (s *FileStorage) GetWebhook(ctx context.Context, id int64) (*entity.Webhook, error) {
+	return s.mem.GetWebhook(ctx, id)
+}
+
+func (s *FileStorage) ListWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	return s.mem.ListWebhooks(ctx)
+}
+
+func (s *FileStorage) UpdateWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.mem.webhookExists(webhook.ID) {
+		return uc_errors.WebhookNotFoundError
+	}
+
+	updated := webhook.Clone()
+	rec := s.record(walOpUpdateWebhook)
+	rec.Webhook = &updated
+
+	if err := s.log.append(rec); err != nil {
+		return err
+	}
+	return s.apply(rec)
+}
+
+func (s *FileStorage) DeleteWebhook(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.mem.webhookExists(id) {
+		return uc_errors.WebhookNotFoundError
+	}
+
+	rec := s.record(walOpDeleteWebhook)
+	rec.ID = id
+
+	if err := s.log.append(rec); err != nil {
+		return err
+	}
+	return s.apply(rec)
+}
+
+func (s *FileStorage) CreateDeadLetter(ctx context.Context, letter *entity.DeadLetter) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec := s.record(walOpCreateDeadLetter)
+	rec.PrevDeadLetterID++
+
+	created := letter.Clone()
+	created.ID = rec.PrevDeadLetterID
+	rec.DeadLetter = &created
+
+	if err := s.log.append(rec); err != nil {
+		return err
+	}
+	if err := s.apply(rec); err != nil {
+		return err
+	}
+
+	letter.ID = created.ID
+	return nil
+}
+
+func (s *FileStorage) GetDeadLetter(ctx context.Context, id int64) (*entity.DeadLetter, error) {
+	return s.mem.GetDeadLetter(ctx, id)
+}
+
+func (s *FileStorage) ListDeadLetters(ctx context.Context) ([]*entity.DeadLetter, error) {
+	return s.mem.ListDeadLetters(ctx)
+}
+
+func (s *FileStorage) DeleteDeadLetter(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.mem.deadLetterExists(id) {
+		return uc_errors.DeadLetterNotFoundError
+	}
+
+	rec := s.record(walOpDeleteDeadLetter)
+	rec.ID = id
+
+	if err := s.log.append(rec); err != nil {
+		return err
+	}
+	return s.apply(rec)
+}
+
+// Snapshot writes the current todo set to disk and truncates the log behind
+// it. Replaying records already covered by a snapshot is harmless, so a crash
+// between the two steps only costs a longer boot.
+func (s *FileStorage) Snapshot() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap := &snapshot{
+		Seq:    s.seq + 1,
+		PrevID: s.mem.lastID(),
+		Todos:  s.mem.all(),
+
+		PrevProjectID: s.mem.lastProjectID(),
+		Projects:      s.mem.allProjects(),
+
+		PrevReminderID: s.mem.lastReminderID(),
+		Reminders:      s.mem.allReminders(),
+
+		Webhooks:    s.mem.allWebhooks(),
+		DeadLetters: s.mem.allDeadLetters(),
+	}
+	snap.PrevWebhookID, snap.PrevDeadLetterID = s.mem.lastWebhookIDs()
+
+	if err := writeSnapshot(s.dir, snap); err != nil {
+		return fmt.Errorf("write snapshot: %w", err)
+	}
+	s.seq = snap.Seq
+
+	if err := s.log.reset(); err != nil {
+		return fmt.Errorf("truncate wal: %w", err)
+	}
+
+	return pruneSnapshots(s.dir, s.retain)
+}
+
+func (s *FileStorage) snapshotLoop(interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			if err := s.Snapshot(); err != nil && s.logger != nil {
+				s.logger.Error("failed to snapshot storage", slog.Any("err", err))
+			}
+		}
+	}
+}
+
+func (s *FileStorage) Close() error {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.log.close()
+}
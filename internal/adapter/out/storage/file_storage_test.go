@@ -0,0 +1,376 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+)
+
+func newFileStorage(t *testing.T, dir string) *storage.FileStorage {
+	t.Helper()
+
+	s, err := storage.NewFileStorage(storage.FileStorageConfig{
+		Dir:        dir,
+		SyncPolicy: storage.SyncAlways,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	return s
+}
+
+func TestFileStorage_Replay(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s := newFileStorage(t, dir)
+
+	kept := entity.Todo{Title: "Buy milk"}
+	updated := entity.Todo{Title: "Walk the dog"}
+	deleted := entity.Todo{Title: "Call mom"}
+	_ = s.CreateTodo(ctx, &kept)
+	_ = s.CreateTodo(ctx, &updated)
+	_ = s.CreateTodo(ctx, &deleted)
+
+	updated.Completed = true
+	updated.BlockedBy = []int64{kept.ID}
+	_ = s.UpdateTodo(ctx, &updated, 0)
+	_ = s.DeleteTodo(ctx, deleted.ID, 0)
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	reopened := newFileStorage(t, dir)
+
+	t.Run("Restores todos", func(t *testing.T) {
+		list, err := reopened.GetTodoList(ctx, 0, 0)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(list) != 2 {
+			t.Fatalf("expected 2 items, got %d", len(list))
+		}
+		if list[0].Title != kept.Title || !list[1].Completed || len(list[1].BlockedBy) != 1 {
+			t.Errorf("expected %v, %v, got %v, %v", kept, updated, list[0], list[1])
+		}
+	})
+
+	t.Run("Deleted todo stays deleted", func(t *testing.T) {
+		if _, err := reopened.GetTodo(ctx, deleted.ID); !errors.Is(err, uc_errors.TodoNotFoundError) {
+			t.Errorf("expected ErrTodoNotFound, got %v", err)
+		}
+	})
+
+	t.Run("IDs are not reused", func(t *testing.T) {
+		todo := entity.Todo{Title: "Read a book"}
+		if err := reopened.CreateTodo(ctx, &todo); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if todo.ID != deleted.ID+1 {
+			t.Errorf("expected id %d, got %d", deleted.ID+1, todo.ID)
+		}
+	})
+}
+
+func TestFileStorage_TornRecord(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s := newFileStorage(t, dir)
+	first := entity.Todo{Title: "Buy milk"}
+	second := entity.Todo{Title: "Walk the dog"}
+	_ = s.CreateTodo(ctx, &first)
+	_ = s.CreateTodo(ctx, &second)
+	_ = s.Close()
+
+	path := filepath.Join(dir, "todos.wal")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := os.Truncate(path, info.Size()-3); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	reopened := newFileStorage(t, dir)
+
+	if _, err := reopened.GetTodo(ctx, first.ID); err != nil {
+		t.Errorf("expected first todo to survive, got %v", err)
+	}
+	if _, err := reopened.GetTodo(ctx, second.ID); !errors.Is(err, uc_errors.TodoNotFoundError) {
+		t.Errorf("expected torn todo to be dropped, got %v", err)
+	}
+
+	third := entity.Todo{Title: "Call mom"}
+	if err := reopened.CreateTodo(ctx, &third); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_ = reopened.Close()
+
+	again := newFileStorage(t, dir)
+	if got, err := again.GetTodo(ctx, third.ID); err != nil || got.Title != third.Title {
+		t.Errorf("expected %v after truncated tail, got %v, %v", third, got, err)
+	}
+}
+
+func TestFileStorage_SyncPolicies(t *testing.T) {
+	ctx := context.Background()
+
+	for _, policy := range []storage.SyncPolicy{storage.SyncAlways, storage.SyncInterval, storage.SyncNever} {
+		t.Run(string(policy), func(t *testing.T) {
+			dir := t.TempDir()
+			s, err := storage.NewFileStorage(storage.FileStorageConfig{
+				Dir:          dir,
+				SyncPolicy:   policy,
+				SyncInterval: 10 * time.Millisecond,
+			})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			todo := entity.Todo{Title: "Buy milk"}
+			_ = s.CreateTodo(ctx, &todo)
+			if err := s.Close(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			reopened := newFileStorage(t, dir)
+			if _, err := reopened.GetTodo(ctx, todo.ID); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+	}
+
+	t.Run("Unknown policy", func(t *testing.T) {
+		if _, err := storage.ParseSyncPolicy("sometimes"); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestFileStorage_Snapshot(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Snapshot and tail are restored", func(t *testing.T) {
+		dir := t.TempDir()
+		s := newFileStorage(t, dir)
+
+		first := entity.Todo{Title: "Buy milk"}
+		second := entity.Todo{Title: "Walk the dog"}
+		_ = s.CreateTodo(ctx, &first)
+		_ = s.CreateTodo(ctx, &second)
+		_ = s.DeleteTodo(ctx, second.ID, 0)
+
+		if err := s.Snapshot(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if info, err := os.Stat(filepath.Join(dir, "todos.wal")); err != nil || info.Size() != 0 {
+			t.Fatalf("expected empty wal after snapshot, got %v, %v", info, err)
+		}
+
+		third := entity.Todo{Title: "Call mom"}
+		_ = s.CreateTodo(ctx, &third)
+		_ = s.Close()
+
+		reopened := newFileStorage(t, dir)
+
+		list, err := reopened.GetTodoList(ctx, 0, 0)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(list) != 2 || list[0].ID != first.ID || list[1].ID != third.ID {
+			t.Errorf("expected todos %d and %d, got %v", first.ID, third.ID, list)
+		}
+	})
+
+	t.Run("IDs are not reused after snapshot", func(t *testing.T) {
+		dir := t.TempDir()
+		s := newFileStorage(t, dir)
+
+		todo := entity.Todo{Title: "Buy milk"}
+		_ = s.CreateTodo(ctx, &todo)
+		_ = s.DeleteTodo(ctx, todo.ID, 0)
+		_ = s.Snapshot()
+		_ = s.Close()
+
+		reopened := newFileStorage(t, dir)
+
+		next := entity.Todo{Title: "Walk the dog"}
+		_ = reopened.CreateTodo(ctx, &next)
+		if next.ID != todo.ID+1 {
+			t.Errorf("expected id %d, got %d", todo.ID+1, next.ID)
+		}
+	})
+
+	t.Run("Retention", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := storage.NewFileStorage(storage.FileStorageConfig{
+			Dir:            dir,
+			SyncPolicy:     storage.SyncAlways,
+			SnapshotRetain: 2,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		defer func() { _ = s.Close() }()
+
+		for i := 0; i < 4; i++ {
+			_ = s.CreateTodo(ctx, &entity.Todo{Title: "Buy milk"})
+			if err := s.Snapshot(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+
+		matches, _ := filepath.Glob(filepath.Join(dir, "snapshot-*.json"))
+		if len(matches) != 2 {
+			t.Errorf("expected 2 snapshots, got %d", len(matches))
+		}
+	})
+
+	t.Run("Refuses an unreadable newest snapshot", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := storage.NewFileStorage(storage.FileStorageConfig{
+			Dir:            dir,
+			SyncPolicy:     storage.SyncAlways,
+			SnapshotRetain: 2,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		_ = s.CreateTodo(ctx, &entity.Todo{Title: "Buy milk"})
+		_ = s.Snapshot()
+		_ = s.CreateTodo(ctx, &entity.Todo{Title: "Buy bread"})
+		_ = s.Snapshot()
+		_ = s.Close()
+
+		matches, _ := filepath.Glob(filepath.Join(dir, "snapshot-*.json"))
+		if len(matches) != 2 {
+			t.Fatalf("expected 2 snapshots, got %d", len(matches))
+		}
+		if err := os.WriteFile(matches[1], []byte("{"), 0o644); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		// The older snapshot lacks the second todo, which the WAL no longer
+		// holds either, so restoring it would lose a write.
+		if _, err := storage.NewFileStorage(storage.FileStorageConfig{Dir: dir, SyncPolicy: storage.SyncAlways}); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("Periodic", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := storage.NewFileStorage(storage.FileStorageConfig{
+			Dir:              dir,
+			SyncPolicy:       storage.SyncAlways,
+			SnapshotInterval: 10 * time.Millisecond,
+			SnapshotRetain:   1,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		defer func() { _ = s.Close() }()
+
+		_ = s.CreateTodo(ctx, &entity.Todo{Title: "Buy milk"})
+
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			if matches, _ := filepath.Glob(filepath.Join(dir, "snapshot-*.json")); len(matches) > 0 {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Error("expected a snapshot to be written")
+	})
+}
+
+func TestFileStorage_CorruptRecord(t *testing.T) {
+	ctx := context.Background()
+
+	// corrupt writes todos with the given titles and flips a byte in the
+	// payload of the record of the title given as bad.
+	corrupt := func(t *testing.T, bad string, titles ...string) string {
+		t.Helper()
+		dir := t.TempDir()
+		s := newFileStorage(t, dir)
+		for _, title := range titles {
+			_ = s.CreateTodo(ctx, &entity.Todo{Title: title})
+		}
+		_ = s.Close()
+
+		path := filepath.Join(dir, "todos.wal")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		i := bytes.Index(data, []byte(bad))
+		if i < 0 {
+			t.Fatalf("expected %q in the wal", bad)
+		}
+		data[i] ^= 0xFF
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		return dir
+	}
+
+	t.Run("Success - bad last record is torn", func(t *testing.T) {
+		dir := corrupt(t, "Walk the dog", "Buy milk", "Walk the dog")
+
+		reopened := newFileStorage(t, dir)
+		list, err := reopened.GetTodoList(ctx, 0, 0)
+		if err != nil || len(list) != 1 || list[0].Title != "Buy milk" {
+			t.Errorf("expected only the first todo, got %v, %v", list, err)
+		}
+	})
+
+	t.Run("Error - bad record in the middle", func(t *testing.T) {
+		dir := corrupt(t, "Walk the dog", "Buy milk", "Walk the dog", "Call mom")
+		before, _ := os.Stat(filepath.Join(dir, "todos.wal"))
+
+		_, err := storage.NewFileStorage(storage.FileStorageConfig{Dir: dir, SyncPolicy: storage.SyncAlways})
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+		if after, _ := os.Stat(filepath.Join(dir, "todos.wal")); after.Size() != before.Size() {
+			t.Errorf("expected the wal to be left alone, got %d bytes from %d", after.Size(), before.Size())
+		}
+	})
+
+	t.Run("Error - damaged length", func(t *testing.T) {
+		dir := t.TempDir()
+		s := newFileStorage(t, dir)
+		_ = s.CreateTodo(ctx, &entity.Todo{Title: "Buy milk"})
+		_ = s.CreateTodo(ctx, &entity.Todo{Title: "Walk the dog"})
+		_ = s.Close()
+
+		// The first record claims 4 GiB; replay must not try to read it.
+		path := filepath.Join(dir, "todos.wal")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		binary.LittleEndian.PutUint32(data[0:4], math.MaxUint32)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if _, err := storage.NewFileStorage(storage.FileStorageConfig{Dir: dir, SyncPolicy: storage.SyncAlways}); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+)
+
+func (s *DataStorage) CreateProject(ctx context.Context, project *entity.Project) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prevProjectID++
+	project.ID = s.prevProjectID
+	project.Version = 1
+
+	s.projects[project.ID] = *project
+	return nil
+}
+
+func (s *DataStorage) GetProject(ctx context.Context, id int64) (*entity.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	project, ok := s.projects[id]
+	if !ok {
+		return nil, uc_errors.ProjectNotFoundError
+	}
+
+	return &project, nil
+}
+
+// ListProjects sorts on every call; projects are few compared to todos.
+func (s *DataStorage) ListProjects(ctx context.Context, limit, offset int) ([]*entity.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	projects := make([]*entity.Project, 0, len(s.projects))
+	for _, project := range s.projects {
+		projects = append(projects, &project)
+	}
+	slices.SortFunc(projects, func(a, b *entity.Project) int { return cmp.Compare(a.ID, b.ID) })
+
+	if offset > len(projects) {
+		return []*entity.Project{}, nil
+	}
+	projects = projects[offset:]
+	if limit > 0 && limit < len(projects) {
+		projects = projects[:limit]
+	}
+
+	return projects, nil
+}
+
+func (s *DataStorage) CountProjects(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.projects), nil
+}
+
+func (s *DataStorage) UpdateProject(ctx context.Context, project *entity.Project, expectedVersion int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.checkProjectVersion(project.ID, expectedVersion)
+	if err != nil {
+		return err
+	}
+	project.Version = current.Version + 1
+
+	s.projects[project.ID] = *project
+	return nil
+}
+
+func (s *DataStorage) DeleteProject(ctx context.Context, id int64, expectedVersion int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.checkProjectVersion(id, expectedVersion); err != nil {
+		return err
+	}
+
+	delete(s.projects, id)
+	return nil
+}
+
+func (s *DataStorage) checkProjectVersion(id int64, expectedVersion int64) (entity.Project, error) {
+	current, ok := s.projects[id]
+	if !ok {
+		return entity.Project{}, uc_errors.ProjectNotFoundError
+	}
+	if expectedVersion != 0 && current.Version != expectedVersion {
+		return entity.Project{}, uc_errors.ProjectVersionConflictError
+	}
+	return current, nil
+}
+
+// The helpers below let FileStorage drive the projects while holding its own
+// lock, like their todo counterparts in data_storage.go.
+
+func (s *DataStorage) currentProject(id int64, expectedVersion int64) (entity.Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.checkProjectVersion(id, expectedVersion)
+}
+
+func (s *DataStorage) restoreProject(project entity.Project) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.projects[project.ID] = project
+}
+
+func (s *DataStorage) forgetProject(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.projects, id)
+}
+
+func (s *DataStorage) allProjects() []entity.Project {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	projects := make([]entity.Project, 0, len(s.projects))
+	for _, project := range s.projects {
+		projects = append(projects, project)
+	}
+	slices.SortFunc(projects, func(a, b entity.Project) int { return cmp.Compare(a.ID, b.ID) })
+
+	return projects
+}
+
+func (s *DataStorage) lastProjectID() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.prevProjectID
+}
+
+func (s *DataStorage) setLastProjectID(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prevProjectID = id
+}
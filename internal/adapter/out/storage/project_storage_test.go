@@ -0,0 +1,118 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+)
+
+func TestStorage_Projects(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, newStorage func(t *testing.T) port.DataStorage) {
+		s := newStorage(t).(port.ProjectStorage)
+		ctx := context.Background()
+
+		home := entity.Project{Name: "Home"}
+		work := entity.Project{Name: "Work"}
+		_ = s.CreateProject(ctx, &home)
+		_ = s.CreateProject(ctx, &work)
+
+		t.Run("Success", func(t *testing.T) {
+			if home.ID != 1 || work.ID != 2 || home.Version != 1 {
+				t.Fatalf("expected ids 1, 2 at version 1, got %+v, %+v", home, work)
+			}
+
+			got, err := s.GetProject(ctx, work.ID)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got.Name != "Work" {
+				t.Errorf("expected Work, got %v", got.Name)
+			}
+
+			list, _ := s.ListProjects(ctx, 1, 1)
+			if len(list) != 1 || list[0].ID != work.ID {
+				t.Errorf("expected [Work], got %v", list)
+			}
+			if n, _ := s.CountProjects(ctx); n != 2 {
+				t.Errorf("expected 2 projects, got %d", n)
+			}
+		})
+
+		t.Run("Success - update", func(t *testing.T) {
+			home.Description = "chores"
+			if err := s.UpdateProject(ctx, &home, 1); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if home.Version != 2 {
+				t.Errorf("expected version 2, got %d", home.Version)
+			}
+		})
+
+		t.Run("Error - version conflict", func(t *testing.T) {
+			if err := s.UpdateProject(ctx, &home, 1); !errors.Is(err, uc_errors.ProjectVersionConflictError) {
+				t.Errorf("expected ProjectVersionConflictError, got %v", err)
+			}
+			if err := s.DeleteProject(ctx, home.ID, 1); !errors.Is(err, uc_errors.ProjectVersionConflictError) {
+				t.Errorf("expected ProjectVersionConflictError, got %v", err)
+			}
+		})
+
+		t.Run("Error - deleted", func(t *testing.T) {
+			if err := s.DeleteProject(ctx, work.ID, 0); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if _, err := s.GetProject(ctx, work.ID); !errors.Is(err, uc_errors.ProjectNotFoundError) {
+				t.Errorf("expected ProjectNotFoundError, got %v", err)
+			}
+			if err := s.DeleteProject(ctx, work.ID, 0); !errors.Is(err, uc_errors.ProjectNotFoundError) {
+				t.Errorf("expected ProjectNotFoundError, got %v", err)
+			}
+		})
+	})
+}
+
+func TestFileStorage_ReplayProjects(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s := newFileStorage(t, dir)
+
+	kept := entity.Project{Name: "Home"}
+	deleted := entity.Project{Name: "Work"}
+	_ = s.CreateProject(ctx, &kept)
+	_ = s.CreateProject(ctx, &deleted)
+	kept.Description = "chores"
+	_ = s.UpdateProject(ctx, &kept, 0)
+	_ = s.DeleteProject(ctx, deleted.ID, 0)
+
+	if err := s.Snapshot(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_ = s.CreateTodo(ctx, &entity.Todo{Title: "Sweep", ProjectID: kept.ID})
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	reopened := newFileStorage(t, dir)
+
+	got, err := reopened.GetProject(ctx, kept.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.Description != "chores" || got.Version != 2 {
+		t.Errorf("expected the updated project, got %+v", got)
+	}
+	if todo, _ := reopened.GetTodo(ctx, 1); todo == nil || todo.ProjectID != kept.ID {
+		t.Errorf("expected todo in project %d, got %+v", kept.ID, todo)
+	}
+
+	project := entity.Project{Name: "Garden"}
+	_ = reopened.CreateProject(ctx, &project)
+	if project.ID != deleted.ID+1 {
+		t.Errorf("expected id %d, got %d", deleted.ID+1, project.ID)
+	}
+}
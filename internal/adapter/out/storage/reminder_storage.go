@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+)
+
+func (s *DataStorage) CreateReminder(ctx context.Context, reminder *entity.Reminder) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prevReminderID++
+	reminder.ID = s.prevReminderID
+
+	s.reminders[reminder.ID] = reminder.Clone()
+	return nil
+}
+
+func (s *DataStorage) GetReminder(ctx context.Context, id int64) (*entity.Reminder, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	reminder, ok := s.reminders[id]
+	if !ok {
+		return nil, uc_errors.ReminderNotFoundError
+	}
+
+	reminder = reminder.Clone()
+	return &reminder, nil
+}
+
+// ListReminders and PendingReminders scan every reminder; there are few per
+// todo and pending ones are fired soon.
+func (s *DataStorage) ListReminders(ctx context.Context, todoID int64) ([]*entity.Reminder, error) {
+	return s.filterReminders(ctx, func(r entity.Reminder) bool { return r.TodoID == todoID })
+}
+
+func (s *DataStorage) PendingReminders(ctx context.Context) ([]*entity.Reminder, error) {
+	return s.filterReminders(ctx, func(r entity.Reminder) bool { return r.Status == entity.ReminderPending })
+}
+
+func (s *DataStorage) filterReminders(ctx context.Context, keep func(entity.Reminder) bool) ([]*entity.Reminder, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	reminders := make([]*entity.Reminder, 0)
+	for _, reminder := range s.reminders {
+		if keep(reminder) {
+			reminder = reminder.Clone()
+			reminders = append(reminders, &reminder)
+		}
+	}
+	slices.SortFunc(reminders, func(a, b *entity.Reminder) int { return cmp.Compare(a.ID, b.ID) })
+
+	return reminders, nil
+}
+
+func (s *DataStorage) UpdateReminder(ctx context.Context, reminder *entity.Reminder) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.reminders[reminder.ID]; !ok {
+		return uc_errors.ReminderNotFoundError
+	}
+
+	s.reminders[reminder.ID] = reminder.Clone()
+	return nil
+}
+
+func (s *DataStorage) DeleteReminder(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.reminders[id]; !ok {
+		return uc_errors.ReminderNotFoundError
+	}
+
+	delete(s.reminders, id)
+	return nil
+}
+
+// The helpers below let FileStorage drive the reminders while holding its
+// own lock, like their todo counterparts in data_storage.go.
+
+func (s *DataStorage) reminderExists(id int64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.reminders[id]
+	return ok
+}
+
+func (s *DataStorage) restoreReminder(reminder entity.Reminder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reminders[reminder.ID] = reminder.Clone()
+}
+
+func (s *DataStorage) forgetReminder(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.reminders, id)
+}
+
+func (s *DataStorage) allReminders() []entity.Reminder {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	reminders := make([]entity.Reminder, 0, len(s.reminders))
+	for _, reminder := range s.reminders {
+		reminders = append(reminders, reminder.Clone())
+	}
+	slices.SortFunc(reminders, func(a, b entity.Reminder) int { return cmp.Compare(a.ID, b.ID) })
+
+	return reminders
+}
+
+func (s *DataStorage) lastReminderID() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.prevReminderID
+}
+
+func (s *DataStorage) setLastReminderID(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prevReminderID = id
+}
@@ -0,0 +1,107 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+)
+
+func TestStorage_Reminders(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, newStorage func(t *testing.T) port.DataStorage) {
+		s := newStorage(t).(port.ReminderStorage)
+		ctx := context.Background()
+
+		at := entity.Reminder{TodoID: 1, At: time.Unix(100, 0).UTC(), Channel: "log", Status: entity.ReminderPending}
+		before := entity.Reminder{TodoID: 2, Before: time.Hour, Channel: "log", Status: entity.ReminderPending}
+		_ = s.CreateReminder(ctx, &at)
+		_ = s.CreateReminder(ctx, &before)
+
+		t.Run("Success", func(t *testing.T) {
+			if at.ID != 1 || before.ID != 2 {
+				t.Fatalf("expected ids 1 and 2, got %d and %d", at.ID, before.ID)
+			}
+
+			list, _ := s.ListReminders(ctx, 2)
+			if len(list) != 1 || list[0].Before != time.Hour {
+				t.Errorf("expected the relative reminder, got %v", list)
+			}
+		})
+
+		t.Run("Success - update", func(t *testing.T) {
+			at.Status = entity.ReminderSent
+			at.Attempts = []entity.DeliveryAttempt{{At: time.Unix(200, 0).UTC()}}
+			if err := s.UpdateReminder(ctx, &at); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			// The stored attempts must not share the caller's slice.
+			at.Attempts[0].Error = "changed"
+
+			got, _ := s.GetReminder(ctx, at.ID)
+			if got.Status != entity.ReminderSent || len(got.Attempts) != 1 || got.Attempts[0].Error != "" {
+				t.Errorf("expected one clean attempt, got %+v", got)
+			}
+
+			pending, _ := s.PendingReminders(ctx)
+			if len(pending) != 1 || pending[0].ID != before.ID {
+				t.Errorf("expected only reminder %d pending, got %v", before.ID, pending)
+			}
+		})
+
+		t.Run("Error - deleted", func(t *testing.T) {
+			if err := s.DeleteReminder(ctx, before.ID); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if _, err := s.GetReminder(ctx, before.ID); !errors.Is(err, uc_errors.ReminderNotFoundError) {
+				t.Errorf("expected ReminderNotFoundError, got %v", err)
+			}
+			if err := s.UpdateReminder(ctx, &before); !errors.Is(err, uc_errors.ReminderNotFoundError) {
+				t.Errorf("expected ReminderNotFoundError, got %v", err)
+			}
+		})
+	})
+}
+
+func TestFileStorage_ReplayReminders(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s := newFileStorage(t, dir)
+
+	kept := entity.Reminder{TodoID: 1, Before: time.Minute, Channel: "log", Status: entity.ReminderPending}
+	deleted := entity.Reminder{TodoID: 1, Before: time.Hour, Channel: "log", Status: entity.ReminderPending}
+	_ = s.CreateReminder(ctx, &kept)
+	if err := s.Snapshot(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_ = s.CreateReminder(ctx, &deleted)
+	kept.Attempts = []entity.DeliveryAttempt{{At: time.Unix(100, 0).UTC(), Error: "timeout"}}
+	_ = s.UpdateReminder(ctx, &kept)
+	_ = s.DeleteReminder(ctx, deleted.ID)
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	reopened := newFileStorage(t, dir)
+
+	got, err := reopened.GetReminder(ctx, kept.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(got.Attempts) != 1 || got.Attempts[0].Error != "timeout" {
+		t.Errorf("expected the failed attempt, got %+v", got)
+	}
+	if _, err := reopened.GetReminder(ctx, deleted.ID); !errors.Is(err, uc_errors.ReminderNotFoundError) {
+		t.Errorf("expected ReminderNotFoundError, got %v", err)
+	}
+
+	reminder := entity.Reminder{TodoID: 1, Channel: "log", Status: entity.ReminderPending}
+	_ = reopened.CreateReminder(ctx, &reminder)
+	if reminder.ID != deleted.ID+1 {
+		t.Errorf("expected id %d, got %d", deleted.ID+1, reminder.ID)
+	}
+}
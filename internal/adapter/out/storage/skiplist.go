@@ -0,0 +1,169 @@
+package storage
+
+import "math/rand/v2"
+
+const (
+	skipListMaxLevel = 32
+	skipListP        = 4 // a node is promoted to the next level with probability 1/skipListP
+)
+
+// skipList is an ordered set with rank support: every link also records how
+// many level-0 nodes it jumps over, so both key lookups and positional
+// lookups (the n-th smallest key) cost O(log n).
+type skipList[K any] struct {
+	less  func(a, b K) bool
+	head  *skipNode[K]
+	level int
+	size  int
+}
+
+type skipNode[K any] struct {
+	key  K
+	next []skipLink[K]
+}
+
+type skipLink[K any] struct {
+	node *skipNode[K]
+	span int
+}
+
+func newSkipList[K any](less func(a, b K) bool) *skipList[K] {
+	return &skipList[K]{
+		less:  less,
+		head:  &skipNode[K]{next: make([]skipLink[K], skipListMaxLevel)},
+		level: 1,
+	}
+}
+
+func randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.IntN(skipListP) == 0 {
+		level++
+	}
+	return level
+}
+
+func (l *skipList[K]) len() int {
+	return l.size
+}
+
+// insert adds key, which must not already be present.
+func (l *skipList[K]) insert(key K) {
+	var (
+		update [skipListMaxLevel]*skipNode[K]
+		rank   [skipListMaxLevel]int
+	)
+
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		if i < l.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.next[i].node != nil && l.less(x.next[i].node.key, key) {
+			rank[i] += x.next[i].span
+			x = x.next[i].node
+		}
+		update[i] = x
+	}
+
+	level := randomLevel()
+	if level > l.level {
+		for i := l.level; i < level; i++ {
+			rank[i] = 0
+			update[i] = l.head
+			update[i].next[i].span = l.size
+		}
+		l.level = level
+	}
+
+	node := &skipNode[K]{key: key, next: make([]skipLink[K], level)}
+	for i := 0; i < level; i++ {
+		node.next[i].node = update[i].next[i].node
+		update[i].next[i].node = node
+
+		node.next[i].span = update[i].next[i].span - (rank[0] - rank[i])
+		update[i].next[i].span = rank[0] - rank[i] + 1
+	}
+	for i := level; i < l.level; i++ {
+		update[i].next[i].span++
+	}
+
+	l.size++
+}
+
+// delete removes key and reports whether it was present.
+func (l *skipList[K]) delete(key K) bool {
+	var update [skipListMaxLevel]*skipNode[K]
+
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i].node != nil && l.less(x.next[i].node.key, key) {
+			x = x.next[i].node
+		}
+		update[i] = x
+	}
+
+	target := x.next[0].node
+	if target == nil || l.less(key, target.key) {
+		return false
+	}
+
+	for i := 0; i < l.level; i++ {
+		if update[i].next[i].node == target {
+			update[i].next[i].span += target.next[i].span - 1
+			update[i].next[i].node = target.next[i].node
+		} else {
+			update[i].next[i].span--
+		}
+	}
+	for l.level > 1 && l.head.next[l.level-1].node == nil {
+		l.level--
+	}
+
+	l.size--
+	return true
+}
+
+// nodeAt returns the node holding the rank-th smallest key (0-based).
+func (l *skipList[K]) nodeAt(rank int) *skipNode[K] {
+	if rank < 0 || rank >= l.size {
+		return nil
+	}
+
+	traversed := 0
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i].node != nil && traversed+x.next[i].span <= rank+1 {
+			traversed += x.next[i].span
+			x = x.next[i].node
+		}
+		if traversed == rank+1 {
+			return x
+		}
+	}
+
+	return nil
+}
+
+// rank returns the number of keys less than key, which is the rank of the
+// first key not less than it.
+func (l *skipList[K]) rank(key K) int {
+	rank := 0
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i].node != nil && l.less(x.next[i].node.key, key) {
+			rank += x.next[i].span
+			x = x.next[i].node
+		}
+	}
+	return rank
+}
+
+// ascend calls fn for keys in order starting at rank until fn returns false.
+func (l *skipList[K]) ascend(rank int, fn func(key K) bool) {
+	for x := l.nodeAt(rank); x != nil; x = x.next[0].node {
+		if !fn(x.key) {
+			return
+		}
+	}
+}
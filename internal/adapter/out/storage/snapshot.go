@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"todo-api/internal/domain/entity"
+)
+
+const (
+	snapshotPrefix = "snapshot-"
+	snapshotSuffix = ".json"
+)
+
+type snapshot struct {
+	Seq    uint64        `json:"seq"`
+	PrevID int64         `json:"prev_id"`
+	Todos  []entity.Todo `json:"todos"`
+
+	PrevProjectID int64            `json:"prev_project_id,omitempty"`
+	Projects      []entity.Project `json:"projects,omitempty"`
+
+	PrevReminderID int64             `json:"prev_reminder_id,omitempty"`
+	Reminders      []entity.Reminder `json:"reminders,omitempty"`
+
+	PrevWebhookID    int64               `json:"prev_webhook_id,omitempty"`
+	Webhooks         []entity.Webhook    `json:"webhooks,omitempty"`
+	PrevDeadLetterID int64               `json:"prev_dead_letter_id,omitempty"`
+	DeadLetters      []entity.DeadLetter `json:"dead_letters,omitempty"`
+}
+
+func snapshotPath(dir string, seq uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%s%020d%s", snapshotPrefix, seq, snapshotSuffix))
+}
+
+// listSnapshots returns the sequence numbers of the snapshots in dir, newest first.
+func listSnapshots(dir string) ([]uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var seqs []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, snapshotPrefix) || !strings.HasSuffix(name, snapshotSuffix) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, snapshotPrefix), snapshotSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		seqs = append(seqs, seq)
+	}
+
+	sort.Slice(seqs, func(i, j int) bool {
+		return seqs[i] > seqs[j]
+	})
+
+	return seqs, nil
+}
+
+func readSnapshot(dir string, seq uint64) (*snapshot, error) {
+	raw, err := os.ReadFile(snapshotPath(dir, seq))
+	if err != nil {
+		return nil, err
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(raw, &snap); err != nil {
+		return nil, err
+	}
+
+	return &snap, nil
+}
+
+// writeSnapshot goes through a temp file and a rename so a crash never leaves
+// a half-written snapshot under its final name.
+func writeSnapshot(dir string, snap *snapshot) error {
+	raw, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, snapshotPrefix+"*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), snapshotPath(dir, snap.Seq)); err != nil {
+		return err
+	}
+
+	return syncDir(dir)
+}
+
+func pruneSnapshots(dir string, retain int) error {
+	seqs, err := listSnapshots(dir)
+	if err != nil {
+		return err
+	}
+	if retain < 1 {
+		retain = 1
+	}
+
+	for _, seq := range seqs[min(retain, len(seqs)):] {
+		if err := os.Remove(snapshotPath(dir, seq)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = d.Close() }()
+
+	return d.Sync()
+}
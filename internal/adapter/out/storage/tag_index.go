@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"slices"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/query"
+)
+
+// tag and untag maintain the inverted index from tag to todo ids; callers
+// hold s.mu.
+func (s *DataStorage) tag(todo entity.Todo) {
+	for _, tag := range todo.Tags {
+		ids, ok := s.byTag[tag]
+		if !ok {
+			ids = newSkipList(func(a, b int64) bool { return a < b })
+			s.byTag[tag] = ids
+		}
+		ids.insert(todo.ID)
+	}
+}
+
+func (s *DataStorage) untag(todo entity.Todo) {
+	for _, tag := range todo.Tags {
+		ids, ok := s.byTag[tag]
+		if !ok {
+			continue
+		}
+		ids.delete(todo.ID)
+		if ids.len() == 0 {
+			delete(s.byTag, tag)
+		}
+	}
+}
+
+// tagCandidates uses the tag index to narrow down the todos filter can match.
+// ok is true when every match is among ids, which are in ascending order.
+func (s *DataStorage) tagCandidates(filter query.Filter) (ids []int64, ok bool) {
+	switch f := filter.(type) {
+	case query.HasTag:
+		return s.tagged(f.Tag), true
+	case query.And:
+		// Any tagged member bounds the result; the smallest is cheapest.
+		for _, member := range f {
+			if memberIDs, memberOK := s.tagCandidates(member); memberOK && (!ok || len(memberIDs) < len(ids)) {
+				ids, ok = memberIDs, true
+			}
+		}
+		return ids, ok
+	case query.Or:
+		for _, member := range f {
+			memberIDs, memberOK := s.tagCandidates(member)
+			if !memberOK {
+				return nil, false
+			}
+			ids = append(ids, memberIDs...)
+		}
+		slices.Sort(ids)
+		return slices.Compact(ids), true
+	}
+	return nil, false
+}
+
+func (s *DataStorage) tagged(tag string) []int64 {
+	list, ok := s.byTag[tag]
+	if !ok {
+		return nil
+	}
+
+	ids := make([]int64, 0, list.len())
+	list.ascend(0, func(id int64) bool {
+		ids = append(ids, id)
+		return true
+	})
+	return ids
+}
@@ -0,0 +1,307 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"todo-api/internal/domain/entity"
+)
+
+type SyncPolicy string
+
+const (
+	SyncAlways   SyncPolicy = "always"
+	SyncInterval SyncPolicy = "interval"
+	SyncNever    SyncPolicy = "never"
+)
+
+func ParseSyncPolicy(s string) (SyncPolicy, error) {
+	switch p := SyncPolicy(s); p {
+	case SyncAlways, SyncInterval, SyncNever:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown wal sync policy %q", s)
+	}
+}
+
+type walOp string
+
+const (
+	walOpCreate walOp = "create"
+	walOpUpdate walOp = "update"
+	walOpDelete walOp = "delete"
+
+	walOpCreateProject walOp = "create_project"
+	walOpUpdateProject walOp = "update_project"
+	walOpDeleteProject walOp = "delete_project"
+
+	walOpCreateReminder walOp = "create_reminder"
+	walOpUpdateReminder walOp = "update_reminder"
+	walOpDeleteReminder walOp = "delete_reminder"
+
+	walOpCreateWebhook    walOp = "create_webhook"
+	walOpUpdateWebhook    walOp = "update_webhook"
+	walOpDeleteWebhook    walOp = "delete_webhook"
+	walOpCreateDeadLetter walOp = "create_dead_letter"
+	walOpDeleteDeadLetter walOp = "delete_dead_letter"
+)
+
+// Every record carries all id counters, so replay restores them whatever
+// kind of record comes last.
+type walRecord struct {
+	Op               walOp              `json:"op"`
+	Todo             *entity.Todo       `json:"todo,omitempty"`
+	Project          *entity.Project    `json:"project,omitempty"`
+	Reminder         *entity.Reminder   `json:"reminder,omitempty"`
+	Webhook          *entity.Webhook    `json:"webhook,omitempty"`
+	DeadLetter       *entity.DeadLetter `json:"dead_letter,omitempty"`
+	ID               int64              `json:"id,omitempty"`
+	PrevID           int64              `json:"prev_id"`
+	PrevProjectID    int64              `json:"prev_project_id,omitempty"`
+	PrevReminderID   int64              `json:"prev_reminder_id,omitempty"`
+	PrevWebhookID    int64              `json:"prev_webhook_id,omitempty"`
+	PrevDeadLetterID int64              `json:"prev_dead_letter_id,omitempty"`
+}
+
+// Each record is framed as [payload length][crc32 of payload][payload].
+const walHeaderSize = 8
+
+// walMaxRecordSize bounds a payload, so that a damaged length cannot make
+// replay allocate gigabytes. Records are far smaller.
+const walMaxRecordSize = 16 << 20
+
+var (
+	walCRCTable   = crc32.MakeTable(crc32.Castagnoli)
+	errTornRecord = errors.New("torn wal record")
+	// errCorruptRecord is a complete record that fails its checksum or does
+	// not decode. It is only torn when nothing follows it.
+	errCorruptRecord = errors.New("corrupt wal record")
+)
+
+type wal struct {
+	mu     sync.Mutex
+	file   *os.File
+	policy SyncPolicy
+	dirty  bool
+	// failed is set when a failed append could not be rolled back; the log
+	// then refuses further appends rather than write after a torn record.
+	failed error
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func openWAL(path string, policy SyncPolicy, interval time.Duration) (*wal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &wal{file: file, policy: policy}
+	if policy == SyncInterval {
+		if interval <= 0 {
+			_ = file.Close()
+			return nil, fmt.Errorf("wal sync interval must be positive, got %s", interval)
+		}
+		w.stop = make(chan struct{})
+		w.done = make(chan struct{})
+		go w.syncLoop(interval)
+	}
+
+	return w, nil
+}
+
+// replay feeds every intact record to apply and cuts the file right after the
+// last one, so a record torn by a crash is discarded instead of poisoning
+// later appends. A bad record with more data after it is not a crash but
+// corruption, and replay fails rather than drop the records that follow.
+func (w *wal) replay(apply func(walRecord) error) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(w.file)
+	var good int64
+	for {
+		rec, n, err := readWALRecord(reader)
+		if errors.Is(err, io.EOF) || errors.Is(err, errTornRecord) {
+			break
+		}
+		if errors.Is(err, errCorruptRecord) {
+			if _, peekErr := reader.Peek(1); errors.Is(peekErr, io.EOF) {
+				break
+			}
+			return fmt.Errorf("replay wal record at offset %d: %w", good, err)
+		}
+		if err != nil {
+			return err
+		}
+		if err := apply(rec); err != nil {
+			return fmt.Errorf("replay wal record at offset %d: %w", good, err)
+		}
+		good += n
+	}
+
+	if err := w.file.Truncate(good); err != nil {
+		return err
+	}
+	_, err := w.file.Seek(good, io.SeekStart)
+	return err
+}
+
+func readWALRecord(r io.Reader) (walRecord, int64, error) {
+	var header [walHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return walRecord{}, 0, errTornRecord
+		}
+		return walRecord{}, 0, err
+	}
+
+	size := binary.LittleEndian.Uint32(header[0:4])
+	sum := binary.LittleEndian.Uint32(header[4:8])
+
+	if size > walMaxRecordSize {
+		return walRecord{}, 0, errCorruptRecord
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return walRecord{}, 0, errTornRecord
+		}
+		return walRecord{}, 0, err
+	}
+	if crc32.Checksum(payload, walCRCTable) != sum {
+		return walRecord{}, 0, errCorruptRecord
+	}
+
+	var rec walRecord
+	if err := json.Unmarshal(payload, &rec); err != nil {
+		return walRecord{}, 0, errCorruptRecord
+	}
+
+	return rec, int64(walHeaderSize + len(payload)), nil
+}
+
+func (w *wal) append(rec walRecord) error {
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if len(payload) > walMaxRecordSize {
+		return fmt.Errorf("wal record of %d bytes exceeds %d", len(payload), walMaxRecordSize)
+	}
+
+	buf := make([]byte, walHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(buf[4:8], crc32.Checksum(payload, walCRCTable))
+	copy(buf[walHeaderSize:], payload)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.failed != nil {
+		return fmt.Errorf("wal is unusable: %w", w.failed)
+	}
+	offset, err := w.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.file.Write(buf)
+	if err == nil && w.policy == SyncAlways {
+		err = w.file.Sync()
+	}
+	if err != nil {
+		// A partial record left in place would end up in the middle of the
+		// log, where replay takes it for corruption.
+		w.rollback(offset)
+		return err
+	}
+
+	if w.policy == SyncInterval {
+		w.dirty = true
+	}
+	return nil
+}
+
+// rollback cuts the log back to offset after a failed append.
+func (w *wal) rollback(offset int64) {
+	if err := w.file.Truncate(offset); err != nil {
+		w.failed = err
+		return
+	}
+	if _, err := w.file.Seek(offset, io.SeekStart); err != nil {
+		w.failed = err
+	}
+}
+
+// reset drops every record once they are covered by a snapshot.
+func (w *wal) reset() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	w.dirty = false
+	w.failed = nil
+	return w.file.Sync()
+}
+
+func (w *wal) sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.dirty {
+		return nil
+	}
+	w.dirty = false
+	return w.file.Sync()
+}
+
+func (w *wal) syncLoop(interval time.Duration) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			_ = w.sync()
+		}
+	}
+}
+
+func (w *wal) close() error {
+	if w.stop != nil {
+		close(w.stop)
+		<-w.done
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.policy != SyncNever {
+		if err := w.file.Sync(); err != nil {
+			_ = w.file.Close()
+			return err
+		}
+	}
+	return w.file.Close()
+}
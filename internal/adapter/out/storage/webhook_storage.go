@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+)
+
+func (s *DataStorage) CreateWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prevWebhookID++
+	webhook.ID = s.prevWebhookID
+
+	s.webhooks[webhook.ID] = webhook.Clone()
+	return nil
+}
+
+func (s *DataStorage) GetWebhook(ctx context.Context, id int64) (*entity.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhook, ok := s.webhooks[id]
+	if !ok {
+		return nil, uc_errors.WebhookNotFoundError
+	}
+
+	webhook = webhook.Clone()
+	return &webhook, nil
+}
+
+func (s *DataStorage) ListWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhooks := make([]*entity.Webhook, 0, len(s.webhooks))
+	for _, webhook := range s.webhooks {
+		webhook = webhook.Clone()
+		webhooks = append(webhooks, &webhook)
+	}
+	slices.SortFunc(webhooks, func(a, b *entity.Webhook) int { return cmp.Compare(a.ID, b.ID) })
+
+	return webhooks, nil
+}
+
+func (s *DataStorage) UpdateWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[webhook.ID]; !ok {
+		return uc_errors.WebhookNotFoundError
+	}
+
+	s.webhooks[webhook.ID] = webhook.Clone()
+	return nil
+}
+
+func (s *DataStorage) DeleteWebhook(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[id]; !ok {
+		return uc_errors.WebhookNotFoundError
+	}
+
+	delete(s.webhooks, id)
+	return nil
+}
+
+func (s *DataStorage) CreateDeadLetter(ctx context.Context, letter *entity.DeadLetter) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prevDeadLetterID++
+	letter.ID = s.prevDeadLetterID
+
+	s.deadLetters[letter.ID] = letter.Clone()
+	return nil
+}
+
+func (s *DataStorage) GetDeadLetter(ctx context.Context, id int64) (*entity.DeadLetter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	letter, ok := s.deadLetters[id]
+	if !ok {
+		return nil, uc_errors.DeadLetterNotFoundError
+	}
+
+	letter = letter.Clone()
+	return &letter, nil
+}
+
+func (s *DataStorage) ListDeadLetters(ctx context.Context) ([]*entity.DeadLetter, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	letters := make([]*entity.DeadLetter, 0, len(s.deadLetters))
+	for _, letter := range s.deadLetters {
+		letter = letter.Clone()
+		letters = append(letters, &letter)
+	}
+	slices.SortFunc(letters, func(a, b *entity.DeadLetter) int { return cmp.Compare(a.ID, b.ID) })
+
+	return letters, nil
+}
+
+func (s *DataStorage) DeleteDeadLetter(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.deadLetters[id]; !ok {
+		return uc_errors.DeadLetterNotFoundError
+	}
+
+	delete(s.deadLetters, id)
+	return nil
+}
+
+// The helpers below let FileStorage drive webhooks and dead letters while
+// holding its own lock, like their todo counterparts in data_storage.go.
+
+func (s *DataStorage) webhookExists(id int64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.webhooks[id]
+	return ok
+}
+
+func (s *DataStorage) restoreWebhook(webhook entity.Webhook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.webhooks[webhook.ID] = webhook.Clone()
+}
+
+func (s *DataStorage) forgetWebhook(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.webhooks, id)
+}
+
+func (s *DataStorage) allWebhooks() []entity.Webhook {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhooks := make([]entity.Webhook, 0, len(s.webhooks))
+	for _, webhook := range s.webhooks {
+		webhooks = append(webhooks, webhook.Clone())
+	}
+	slices.SortFunc(webhooks, func(a, b entity.Webhook) int { return cmp.Compare(a.ID, b.ID) })
+
+	return webhooks
+}
+
+func (s *DataStorage) deadLetterExists(id int64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.deadLetters[id]
+	return ok
+}
+
+func (s *DataStorage) restoreDeadLetter(letter entity.DeadLetter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deadLetters[letter.ID] = letter.Clone()
+}
+
+func (s *DataStorage) forgetDeadLetter(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.deadLetters, id)
+}
+
+func (s *DataStorage) allDeadLetters() []entity.DeadLetter {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	letters := make([]entity.DeadLetter, 0, len(s.deadLetters))
+	for _, letter := range s.deadLetters {
+		letters = append(letters, letter.Clone())
+	}
+	slices.SortFunc(letters, func(a, b entity.DeadLetter) int { return cmp.Compare(a.ID, b.ID) })
+
+	return letters
+}
+
+func (s *DataStorage) lastWebhookIDs() (webhookID, deadLetterID int64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.prevWebhookID, s.prevDeadLetterID
+}
+
+func (s *DataStorage) setLastWebhookIDs(webhookID, deadLetterID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prevWebhookID = webhookID
+	s.prevDeadLetterID = deadLetterID
+}
@@ -0,0 +1,108 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+)
+
+func TestStorage_Webhooks(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, newStorage func(t *testing.T) port.DataStorage) {
+		s := newStorage(t).(port.WebhookStorage)
+		ctx := context.Background()
+
+		webhook := entity.Webhook{URL: "https://example.com/a", Secret: "s", Events: []entity.TodoEventType{entity.TodoEventCreated}}
+		_ = s.CreateWebhook(ctx, &webhook)
+		letter := entity.DeadLetter{WebhookID: webhook.ID, EventID: "e1", Payload: []byte(`{}`)}
+		_ = s.CreateDeadLetter(ctx, &letter)
+
+		t.Run("Success", func(t *testing.T) {
+			if webhook.ID != 1 || letter.ID != 1 {
+				t.Fatalf("expected ids 1 and 1, got %d and %d", webhook.ID, letter.ID)
+			}
+
+			webhook.Events[0] = entity.TodoEventDeleted
+			got, _ := s.GetWebhook(ctx, webhook.ID)
+			if got.Events[0] != entity.TodoEventCreated {
+				t.Errorf("expected the stored events not to share the caller's slice, got %v", got.Events)
+			}
+
+			webhook.URL = "https://example.com/b"
+			if err := s.UpdateWebhook(ctx, &webhook); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			list, _ := s.ListWebhooks(ctx)
+			if len(list) != 1 || list[0].URL != webhook.URL {
+				t.Errorf("expected the updated webhook, got %v", list)
+			}
+
+			letters, _ := s.ListDeadLetters(ctx)
+			if len(letters) != 1 || string(letters[0].Payload) != `{}` {
+				t.Errorf("expected the dead letter, got %v", letters)
+			}
+		})
+
+		t.Run("Error - deleted", func(t *testing.T) {
+			if err := s.DeleteWebhook(ctx, webhook.ID); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if _, err := s.GetWebhook(ctx, webhook.ID); !errors.Is(err, uc_errors.WebhookNotFoundError) {
+				t.Errorf("expected WebhookNotFoundError, got %v", err)
+			}
+			if err := s.DeleteDeadLetter(ctx, letter.ID); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if _, err := s.GetDeadLetter(ctx, letter.ID); !errors.Is(err, uc_errors.DeadLetterNotFoundError) {
+				t.Errorf("expected DeadLetterNotFoundError, got %v", err)
+			}
+		})
+	})
+}
+
+func TestFileStorage_ReplayWebhooks(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s := newFileStorage(t, dir)
+
+	webhook := entity.Webhook{URL: "https://example.com/a", Secret: "s"}
+	_ = s.CreateWebhook(ctx, &webhook)
+	if err := s.Snapshot(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	letter := entity.DeadLetter{
+		WebhookID: webhook.ID,
+		EventID:   "e1",
+		Event:     entity.TodoEventCreated,
+		Payload:   []byte(`{"id":"e1"}`),
+		Attempts:  []entity.DeliveryAttempt{{At: time.Unix(100, 0).UTC(), Error: "timeout"}},
+	}
+	_ = s.CreateDeadLetter(ctx, &letter)
+	webhook.Secret = "rotated"
+	_ = s.UpdateWebhook(ctx, &webhook)
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	reopened := newFileStorage(t, dir)
+
+	got, err := reopened.GetWebhook(ctx, webhook.ID)
+	if err != nil || got.Secret != "rotated" {
+		t.Errorf("expected the rotated secret, got %+v, %v", got, err)
+	}
+	gotLetter, err := reopened.GetDeadLetter(ctx, letter.ID)
+	if err != nil || string(gotLetter.Payload) != `{"id":"e1"}` || gotLetter.Attempts[0].Error != "timeout" {
+		t.Errorf("expected the dead letter, got %+v, %v", gotLetter, err)
+	}
+
+	next := entity.Webhook{URL: "https://example.com/b"}
+	_ = reopened.CreateWebhook(ctx, &next)
+	if next.ID != webhook.ID+1 {
+		t.Errorf("expected id %d, got %d", webhook.ID+1, next.ID)
+	}
+}
@@ -0,0 +1,339 @@
+// Package webhook delivers todo events to the subscribed webhooks in the
+// background, signed, retried and dead-lettered when they keep failing.
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	mathrand "math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/event"
+	"todo-api/internal/domain/port"
+)
+
+var errStopped = errors.New("webhook dispatcher stopped")
+
+type Config struct {
+	// QueueSize bounds the events waiting to be fanned out; events emitted
+	// while it is full are dropped and logged.
+	QueueSize int
+	// Senders bounds the requests in flight across all webhooks.
+	Senders int
+	// MaxAttempts is how many times a delivery is tried before it becomes a
+	// dead letter.
+	MaxAttempts int
+	// Backoff is the delay before the first retry; it doubles with every
+	// retry up to MaxBackoff and is jittered over [d/2, d].
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Dispatcher implements port.WebhookSender and subscribes Handle to the
+// event bus. Handle only encodes and queues the event; the deliveries run on
+// their own goroutines.
+type Dispatcher struct {
+	webhooks port.WebhookStorage
+	client   *http.Client
+	clock    port.Clock
+	log      *slog.Logger
+	cfg      Config
+
+	queue chan message
+	slots chan struct{}
+
+	mu      sync.Mutex
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running bool
+	wg      sync.WaitGroup
+}
+
+// message is one event as it goes over the wire to every subscriber.
+type message struct {
+	id      string
+	event   entity.TodoEventType
+	payload []byte
+}
+
+type payload struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Todo       dto.Todo  `json:"todo"`
+}
+
+func NewDispatcher(
+	log *slog.Logger,
+	client *http.Client,
+	webhooks port.WebhookStorage,
+	clock port.Clock,
+	cfg Config,
+) *Dispatcher {
+	cfg.QueueSize = max(cfg.QueueSize, 1)
+	cfg.Senders = max(cfg.Senders, 1)
+	cfg.MaxAttempts = max(cfg.MaxAttempts, 1)
+	return &Dispatcher{
+		webhooks: webhooks,
+		client:   client,
+		clock:    clock,
+		log:      log,
+		cfg:      cfg,
+		queue:    make(chan message, cfg.QueueSize),
+		slots:    make(chan struct{}, cfg.Senders),
+	}
+}
+
+func (d *Dispatcher) Handle(ctx context.Context, e event.Event) {
+	todo := e.Subject()
+	id, err := newEventID()
+	if err == nil {
+		var body []byte
+		body, err = json.Marshal(payload{
+			ID:         id,
+			Type:       string(e.Type()),
+			OccurredAt: d.clock.Now(),
+			Todo:       mappers.MapDomainTodoToTodoDTO(&todo),
+		})
+		if err == nil {
+			select {
+			case d.queue <- message{id: id, event: e.Type(), payload: body}:
+				return
+			default:
+				err = errors.New("queue is full")
+			}
+		}
+	}
+	d.log.ErrorContext(ctx, "webhook event dropped",
+		slog.String("event", string(e.Type())),
+		slog.Int64("todo_id", todo.ID),
+		slog.Any("err", err),
+	)
+}
+
+// Replay delivers a dead letter again with a fresh set of attempts.
+func (d *Dispatcher) Replay(_ context.Context, webhook *entity.Webhook, letter *entity.DeadLetter) error {
+	msg := message{id: letter.EventID, event: letter.Event, payload: letter.Payload}
+	if !d.spawn(func() { d.deliver(*webhook, msg) }) {
+		return errStopped
+	}
+	return nil
+}
+
+func (d *Dispatcher) Start(ctx context.Context) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.ctx != nil {
+		return
+	}
+
+	d.ctx, d.cancel = context.WithCancel(ctx)
+	d.running = true
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.loop()
+	}()
+}
+
+// Stop cancels the requests in flight and waits for the deliveries to give
+// up. Deliveries cut short, and events still queued, become dead letters,
+// so they can be replayed after a restart.
+func (d *Dispatcher) Stop(ctx context.Context) error {
+	d.mu.Lock()
+	cancel := d.cancel
+	d.running = false
+	d.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("webhooks: deliveries still running: %w", ctx.Err())
+	}
+}
+
+// spawn runs f as a delivery unless the dispatcher is stopped; the check
+// and wg.Add share the lock so that Stop never waits on a moving count.
+func (d *Dispatcher) spawn(f func()) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.running {
+		return false
+	}
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		f()
+	}()
+	return true
+}
+
+func (d *Dispatcher) loop() {
+	for {
+		select {
+		case msg := <-d.queue:
+			d.fanOut(msg)
+		case <-d.ctx.Done():
+			for {
+				select {
+				case msg := <-d.queue:
+					d.fanOut(msg)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// fanOut starts a delivery to every webhook subscribed to the event. Once
+// the dispatcher is stopping the deliveries go straight to the dead letters.
+func (d *Dispatcher) fanOut(msg message) {
+	ctx := context.WithoutCancel(d.ctx)
+	webhooks, err := d.webhooks.ListWebhooks(ctx)
+	if err != nil {
+		d.log.ErrorContext(ctx, "failed to list webhooks, event dropped",
+			slog.String("event_id", msg.id),
+			slog.Any("err", err),
+		)
+		return
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.Wants(msg.event) {
+			continue
+		}
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.deliver(*webhook, msg)
+		}()
+	}
+}
+
+func (d *Dispatcher) deliver(webhook entity.Webhook, msg message) {
+	var attempts []entity.DeliveryAttempt
+	for {
+		err := d.send(webhook, msg)
+		if err == nil {
+			return
+		}
+		attempts = append(attempts, entity.DeliveryAttempt{At: d.clock.Now(), Error: err.Error()})
+		if len(attempts) >= d.cfg.MaxAttempts || !sleep(d.ctx, d.backoff(len(attempts))) {
+			break
+		}
+	}
+
+	ctx := context.WithoutCancel(d.ctx)
+	letter := &entity.DeadLetter{
+		WebhookID: webhook.ID,
+		EventID:   msg.id,
+		Event:     msg.event,
+		Payload:   msg.payload,
+		Attempts:  attempts,
+		CreatedAt: d.clock.Now(),
+	}
+	if err := d.webhooks.CreateDeadLetter(ctx, letter); err != nil {
+		d.log.ErrorContext(ctx, "failed to store webhook dead letter",
+			slog.Int64("webhook_id", webhook.ID),
+			slog.String("event_id", msg.id),
+			slog.Any("err", err),
+		)
+		return
+	}
+	d.log.WarnContext(ctx, "webhook delivery failed",
+		slog.Int64("webhook_id", webhook.ID),
+		slog.String("event_id", msg.id),
+		slog.Int("attempts", len(attempts)),
+		slog.Int64("dead_letter_id", letter.ID),
+		slog.String("err", attempts[len(attempts)-1].Error),
+	)
+}
+
+// send makes one attempt; any 2xx response counts as delivered.
+func (d *Dispatcher) send(webhook entity.Webhook, msg message) error {
+	select {
+	case d.slots <- struct{}{}:
+		defer func() { <-d.slots }()
+	case <-d.ctx.Done():
+		return errStopped
+	}
+
+	req, err := newRequest(d.ctx, webhook, msg)
+	if err != nil {
+		return err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		if d.ctx.Err() != nil {
+			return errStopped
+		}
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	drain(resp)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.Backoff << (attempts - 1)
+	if d.cfg.MaxBackoff > 0 && (delay > d.cfg.MaxBackoff || delay <= 0) {
+		delay = d.cfg.MaxBackoff
+	}
+	return jitter(delay)
+}
+
+func newEventID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// jitter spreads d over [d/2, d].
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + mathrand.N(d-half+1)
+}
+
+// sleep waits for d and reports false when ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,157 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+	"todo-api/internal/adapter/out/clock"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/adapter/out/webhook"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/event"
+)
+
+var testConfig = webhook.Config{QueueSize: 16, Senders: 2, MaxAttempts: 3, Backoff: time.Millisecond}
+
+func newDispatcher(t *testing.T, store *storage.DataStorage, cfg webhook.Config) *webhook.Dispatcher {
+	t.Helper()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	d := webhook.NewDispatcher(log, http.DefaultClient, store, clock.System{}, cfg)
+	d.Start(context.Background())
+	t.Cleanup(func() { _ = d.Stop(context.Background()) })
+	return d
+}
+
+// waitFor polls cond, deliveries finish on their own goroutines.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+	todo := entity.Todo{ID: 7, Title: "Pay rent", Version: 1}
+
+	t.Run("Success", func(t *testing.T) {
+		deliveries := make(chan *http.Request, 4)
+		bodies := make(chan []byte, 4)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			deliveries <- r
+			bodies <- body
+		}))
+		defer srv.Close()
+
+		store := storage.NewDataStorage()
+		_ = store.CreateWebhook(ctx, &entity.Webhook{URL: srv.URL, Secret: "s3cret", Events: []entity.TodoEventType{entity.TodoEventCreated}})
+		_ = store.CreateWebhook(ctx, &entity.Webhook{URL: srv.URL, Secret: "other", Events: []entity.TodoEventType{entity.TodoEventDeleted}})
+		d := newDispatcher(t, store, testConfig)
+
+		d.Handle(ctx, event.TodoCreated{Todo: todo})
+
+		r, body := <-deliveries, <-bodies
+		if got, want := r.Header.Get(webhook.SignatureHeader), webhook.Sign("s3cret", body); got != want {
+			t.Errorf("expected signature %s, got %s", want, got)
+		}
+		if r.Header.Get(webhook.EventHeader) != "todo.created" || r.Header.Get(webhook.DeliveryHeader) == "" {
+			t.Errorf("expected event headers, got %v", r.Header)
+		}
+		var event struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+			Todo struct {
+				ID    int64  `json:"id"`
+				Title string `json:"title"`
+			} `json:"todo"`
+		}
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if event.ID != r.Header.Get(webhook.DeliveryHeader) || event.Type != "todo.created" || event.Todo.Title != "Pay rent" {
+			t.Errorf("unexpected payload %s", body)
+		}
+
+		select {
+		case r := <-deliveries:
+			t.Errorf("expected one delivery, also got %s", r.Header.Get(webhook.EventHeader))
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("Retries then dead letter and replay", func(t *testing.T) {
+		var calls, healthy atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if healthy.Load() == 0 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer srv.Close()
+
+		store := storage.NewDataStorage()
+		hook := &entity.Webhook{URL: srv.URL, Secret: "s3cret"}
+		_ = store.CreateWebhook(ctx, hook)
+		d := newDispatcher(t, store, testConfig)
+
+		d.Handle(ctx, event.TodoUpdated{Before: todo, After: todo})
+
+		var letters []*entity.DeadLetter
+		waitFor(t, func() bool {
+			letters, _ = store.ListDeadLetters(ctx)
+			return len(letters) == 1
+		})
+		letter := letters[0]
+		if calls.Load() != 3 || len(letter.Attempts) != 3 || letter.Attempts[2].Error != "webhook responded 503 Service Unavailable" {
+			t.Errorf("expected 3 failed attempts, got %d calls and %+v", calls.Load(), letter.Attempts)
+		}
+		if letter.WebhookID != hook.ID || letter.Event != entity.TodoEventUpdated {
+			t.Errorf("unexpected dead letter %+v", letter)
+		}
+
+		healthy.Store(1)
+		if err := d.Replay(ctx, hook, letter); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		waitFor(t, func() bool { return calls.Load() == 4 })
+	})
+
+	t.Run("Stop dead-letters pending deliveries", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		store := storage.NewDataStorage()
+		hook := &entity.Webhook{URL: srv.URL, Secret: "s3cret"}
+		_ = store.CreateWebhook(ctx, hook)
+		cfg := testConfig
+		cfg.Backoff = time.Hour
+		d := newDispatcher(t, store, cfg)
+
+		d.Handle(ctx, event.TodoDeleted{Todo: todo})
+		time.Sleep(50 * time.Millisecond)
+
+		if err := d.Stop(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		letters, _ := store.ListDeadLetters(ctx)
+		if len(letters) != 1 || len(letters[0].Attempts) != 1 {
+			t.Errorf("expected the delivery in the dead letters after one attempt, got %+v", letters)
+		}
+		if err := d.Replay(ctx, hook, letters[0]); err == nil {
+			t.Error("expected replay to fail once stopped")
+		}
+	})
+}
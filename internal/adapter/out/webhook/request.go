@@ -0,0 +1,43 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"todo-api/internal/domain/entity"
+)
+
+// Headers of every delivery. SignatureHeader carries "sha256=" and the hex
+// HMAC-SHA256 of the body keyed with the webhook secret.
+const (
+	SignatureHeader = "X-Signature"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newRequest(ctx context.Context, webhook entity.Webhook, msg message) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(msg.payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, msg.payload))
+	req.Header.Set(EventHeader, string(msg.event))
+	req.Header.Set(DeliveryHeader, msg.id)
+	return req, nil
+}
+
+// drain reads a little of the body so that the connection can be reused.
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+}
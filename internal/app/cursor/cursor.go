@@ -0,0 +1,154 @@
+package cursor
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/query"
+)
+
+// Cursor is a position in a sorted todo list: the sort it was issued for and
+// the sort values of the item the next page starts after (or, when Backward,
+// ends before).
+type Cursor struct {
+	Backward bool
+	Sort     []query.SortKey
+	Values   []any
+}
+
+type payload struct {
+	Backward bool              `json:"b,omitempty"`
+	Sort     []sortKey         `json:"s"`
+	Values   []json.RawMessage `json:"v"`
+}
+
+type sortKey struct {
+	Field query.Field `json:"f"`
+	Desc  bool        `json:"d,omitempty"`
+}
+
+// Codec turns cursors into opaque tokens of the form payload.signature, where
+// the signature is an HMAC-SHA256 of the payload, so clients cannot forge or
+// edit positions.
+type Codec struct {
+	secret []byte
+}
+
+func NewCodec(secret []byte) *Codec {
+	return &Codec{secret: secret}
+}
+
+func (c *Codec) Encode(cur Cursor) (string, error) {
+	p := payload{Backward: cur.Backward}
+	for _, key := range cur.Sort {
+		p.Sort = append(p.Sort, sortKey{Field: key.Field, Desc: key.Desc})
+	}
+	for _, value := range cur.Values {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		p.Values = append(p.Values, raw)
+	}
+
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw) + "." + base64.RawURLEncoding.EncodeToString(c.sign(raw)), nil
+}
+
+func (c *Codec) Decode(token string) (Cursor, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return Cursor{}, fmt.Errorf("%w: malformed", uc_errors.InvalidCursorError)
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: malformed", uc_errors.InvalidCursorError)
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, c.sign(raw)) {
+		return Cursor{}, fmt.Errorf("%w: bad signature", uc_errors.InvalidCursorError)
+	}
+
+	var p payload
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return Cursor{}, fmt.Errorf("%w: %v", uc_errors.InvalidCursorError, err)
+	}
+	if len(p.Values) != len(p.Sort) {
+		return Cursor{}, fmt.Errorf("%w: %d values for %d sort keys", uc_errors.InvalidCursorError, len(p.Values), len(p.Sort))
+	}
+
+	cur := Cursor{Backward: p.Backward}
+	for i, key := range p.Sort {
+		value, err := decodeValue(key.Field, p.Values[i])
+		if err != nil {
+			return Cursor{}, fmt.Errorf("%w: %v", uc_errors.InvalidCursorError, err)
+		}
+		cur.Sort = append(cur.Sort, query.SortKey{Field: key.Field, Desc: key.Desc})
+		cur.Values = append(cur.Values, value)
+	}
+
+	return cur, nil
+}
+
+// Matches reports whether the cursor was issued for the given sort.
+func (cur Cursor) Matches(keys []query.SortKey) bool {
+	return slices.Equal(cur.Sort, keys)
+}
+
+func (c *Codec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func decodeValue(field query.Field, raw json.RawMessage) (any, error) {
+	kind, ok := field.Kind()
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q", field)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case json.Number:
+		if kind == query.KindInt {
+			return strconv.ParseInt(v.String(), 10, 64)
+		}
+	case string:
+		switch kind {
+		case query.KindString:
+			return v, nil
+		case query.KindTime:
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return nil, err
+			}
+			return t.UTC(), nil
+		}
+	case bool:
+		if kind == query.KindBool {
+			return v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("value %s does not fit field %q", raw, field)
+}
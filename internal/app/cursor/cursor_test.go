@@ -0,0 +1,81 @@
+package cursor_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"todo-api/internal/app/cursor"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/query"
+)
+
+func TestCodec(t *testing.T) {
+	codec := cursor.NewCodec([]byte("secret"))
+
+	cur := cursor.Cursor{
+		Backward: true,
+		Sort: []query.SortKey{
+			{Field: query.FieldCompleted, Desc: true},
+			{Field: query.FieldTitle},
+			{Field: query.FieldID},
+		},
+		Values: []any{true, "Купить молоко", int64(1) << 60},
+	}
+
+	token, err := codec.Encode(cur)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	t.Run("Round trip", func(t *testing.T) {
+		got, err := codec.Decode(token)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(got, cur) {
+			t.Errorf("expected %#v, got %#v", cur, got)
+		}
+	})
+
+	t.Run("Opaque", func(t *testing.T) {
+		if strings.Contains(token, "title") {
+			t.Errorf("expected an opaque token, got %q", token)
+		}
+	})
+
+	payload, signature, _ := strings.Cut(token, ".")
+	tampered := []string{
+		"",
+		"garbage",
+		payload,
+		payload + ".",
+		"e30." + signature,
+		payload[:len(payload)-1] + "A." + signature,
+		payload + "." + signature[:len(signature)-2] + "AA",
+	}
+
+	for _, token := range tampered {
+		t.Run("Error - tampered "+token, func(t *testing.T) {
+			if _, err := codec.Decode(token); !errors.Is(err, uc_errors.InvalidCursorError) {
+				t.Errorf("expected InvalidCursorError, got %v", err)
+			}
+		})
+	}
+
+	t.Run("Error - other secret", func(t *testing.T) {
+		if _, err := cursor.NewCodec([]byte("other")).Decode(token); !errors.Is(err, uc_errors.InvalidCursorError) {
+			t.Errorf("expected InvalidCursorError, got %v", err)
+		}
+	})
+
+	t.Run("Error - value of the wrong type", func(t *testing.T) {
+		bad, _ := codec.Encode(cursor.Cursor{
+			Sort:   []query.SortKey{{Field: query.FieldID}},
+			Values: []any{"1"},
+		})
+		if _, err := codec.Decode(bad); !errors.Is(err, uc_errors.InvalidCursorError) {
+			t.Errorf("expected InvalidCursorError, got %v", err)
+		}
+	})
+}
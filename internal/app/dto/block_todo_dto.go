@@ -0,0 +1,7 @@
+package dto
+
+type BlockTodo struct {
+	ID        int64
+	Version   int64
+	BlockerID int64
+}
@@ -0,0 +1,5 @@
+package dto
+
+type CreateProject struct {
+	Project
+}
@@ -0,0 +1,5 @@
+package dto
+
+type CreateReminder struct {
+	Reminder
+}
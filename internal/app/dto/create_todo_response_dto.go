@@ -1,5 +1,6 @@
 package dto
 
 type CreateTodoResponse struct {
-	ID int64 `json:"id"`
+	ID      int64 `json:"id"`
+	Version int64 `json:"version"`
 }
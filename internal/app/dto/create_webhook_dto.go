@@ -0,0 +1,5 @@
+package dto
+
+type CreateWebhook struct {
+	Webhook
+}
@@ -0,0 +1,5 @@
+package dto
+
+type DeleteDeadLetter struct {
+	ID int64 `json:"id"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type DeleteDeadLetterResponse struct {
+	ID      int64 `json:"id"`
+	Deleted bool  `json:"deleted"`
+}
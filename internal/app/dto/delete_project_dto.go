@@ -0,0 +1,6 @@
+package dto
+
+type DeleteProject struct {
+	ID      int64 `json:"id"`
+	Version int64 `json:"version"`
+}
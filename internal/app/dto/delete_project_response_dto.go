@@ -0,0 +1,8 @@
+package dto
+
+type DeleteProjectResponse struct {
+	ID      int64 `json:"id"`
+	Deleted bool  `json:"deleted"`
+	// Todos is how many todos were deleted or moved out of the project.
+	Todos int `json:"todos"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type DeleteReminder struct {
+	ID     int64 `json:"id"`
+	TodoID int64 `json:"todo_id"`
+}
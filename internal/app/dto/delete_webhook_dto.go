@@ -0,0 +1,5 @@
+package dto
+
+type DeleteWebhook struct {
+	ID int64 `json:"id"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type FireRemindersResponse struct {
+	Sent   int `json:"sent"`
+	Failed int `json:"failed"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type GetCriticalPath struct {
+	ID int64 `json:"id"`
+}
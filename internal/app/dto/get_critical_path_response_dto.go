@@ -0,0 +1,6 @@
+package dto
+
+type GetCriticalPathResponse struct {
+	// Todos runs from the first todo to do to the requested one.
+	Todos []Todo `json:"items"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type GetDeadLettersResponse struct {
+	DeadLetters []DeadLetter `json:"items"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type GetNextTodos struct {
+	N int `json:"n"`
+}
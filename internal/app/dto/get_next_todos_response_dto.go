@@ -0,0 +1,5 @@
+package dto
+
+type GetNextTodosResponse struct {
+	Todos []Todo `json:"items"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+type GetOccurrences struct {
+	ID int64 `json:"id"`
+	// From and To bound the preview; zero values leave that side open.
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
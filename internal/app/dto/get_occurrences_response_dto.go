@@ -0,0 +1,7 @@
+package dto
+
+import "time"
+
+type GetOccurrencesResponse struct {
+	Occurrences []time.Time `json:"items"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type GetProject struct {
+	ID int64 `json:"id"`
+}
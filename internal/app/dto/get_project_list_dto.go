@@ -0,0 +1,6 @@
+package dto
+
+type GetProjectList struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
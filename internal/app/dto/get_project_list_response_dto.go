@@ -0,0 +1,8 @@
+package dto
+
+type GetProjectListResponse struct {
+	Projects []Project `json:"items"`
+	Total    int       `json:"total"`
+	Limit    int       `json:"limit"`
+	Offset   int       `json:"offset"`
+}
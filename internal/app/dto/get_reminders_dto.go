@@ -0,0 +1,5 @@
+package dto
+
+type GetReminders struct {
+	TodoID int64 `json:"todo_id"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type GetRemindersResponse struct {
+	Reminders []Reminder `json:"items"`
+}
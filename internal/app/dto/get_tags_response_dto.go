@@ -0,0 +1,10 @@
+package dto
+
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+type GetTagsResponse struct {
+	Tags []TagCount `json:"items"`
+}
@@ -1,6 +1,29 @@
 package dto
 
+import (
+	"time"
+	"todo-api/internal/domain/query"
+)
+
 type GetTodoList struct {
-	Limit  int `json:"limit"`
-	Offset int `json:"offset"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+	Cursor string          `json:"cursor"`
+	Filter query.Filter    `json:"-"`
+	Sort   []query.SortKey `json:"-"`
+
+	// ProjectID restricts the list to one project when set.
+	ProjectID int64 `json:"project_id"`
+	// ParentID restricts the list to the direct subtasks of a todo when set.
+	ParentID int64 `json:"parent_id"`
+
+	// Tags restricts the list to todos with all of the tags, or with any of
+	// them when AnyTag is set.
+	Tags   []string `json:"tags"`
+	AnyTag bool     `json:"any_tag"`
+
+	// Due date modes, combined with Filter. Zero values are ignored.
+	DueBefore time.Time     `json:"due_before"`
+	DueWithin time.Duration `json:"due_within"`
+	Overdue   *bool         `json:"overdue"`
 }
@@ -1,5 +1,10 @@
 package dto
 
 type GetTodoListResponse struct {
-	Todos []Todo `json:"items"`
+	Todos      []Todo `json:"items"`
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
@@ -2,4 +2,8 @@ package dto
 
 type GetTodoResponse struct {
 	Todo
+	// Progress counts the direct subtasks; it is nil without any.
+	Progress *Progress `json:"progress,omitempty"`
+	// Blocked is set while any of the todo's blockers is open.
+	Blocked bool `json:"blocked"`
 }
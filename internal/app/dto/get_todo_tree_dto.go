@@ -0,0 +1,5 @@
+package dto
+
+type GetTodoTree struct {
+	ID int64 `json:"id"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+type GetTodoTreeResponse struct {
+	TodoNode
+}
+
+type TodoNode struct {
+	Todo
+	Progress *Progress  `json:"progress,omitempty"`
+	Children []TodoNode `json:"children"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type GetWebhook struct {
+	ID int64 `json:"id"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type GetWebhookListResponse struct {
+	Webhooks []Webhook `json:"items"`
+}
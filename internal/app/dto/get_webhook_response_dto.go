@@ -0,0 +1,5 @@
+package dto
+
+type GetWebhookResponse struct {
+	Webhook
+}
@@ -0,0 +1,13 @@
+package dto
+
+const (
+	PatchFormatMerge = "merge-patch"
+	PatchFormatJSON  = "json-patch"
+)
+
+type PatchTodo struct {
+	ID      int64
+	Version int64
+	Format  string
+	Patch   []byte
+}
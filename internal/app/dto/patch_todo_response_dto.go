@@ -0,0 +1,6 @@
+package dto
+
+type PatchTodoResponse struct {
+	GetTodoResponse
+	NextID int64 `json:"next_id,omitempty"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type Progress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
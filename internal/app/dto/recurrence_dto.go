@@ -0,0 +1,6 @@
+package dto
+
+type Recurrence struct {
+	Rule     string `json:"rule"`
+	TimeZone string `json:"time_zone,omitempty"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+type Reminder struct {
+	ID     int64      `json:"id"`
+	TodoID int64      `json:"todo_id"`
+	At     *time.Time `json:"at,omitempty"`
+	// Before is a Go duration such as "15m" or "24h", counted back from the
+	// todo's due date.
+	Before   string            `json:"before,omitempty"`
+	Channel  string            `json:"channel"`
+	Target   string            `json:"target,omitempty"`
+	Status   string            `json:"status"`
+	FireAt   *time.Time        `json:"fire_at,omitempty"`
+	SentAt   *time.Time        `json:"sent_at,omitempty"`
+	Attempts []DeliveryAttempt `json:"attempts"`
+
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+type DeliveryAttempt struct {
+	At    time.Time `json:"at"`
+	Error string    `json:"error,omitempty"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type RenameTag struct {
+	Tag  string `json:"-"`
+	Name string `json:"name"`
+}
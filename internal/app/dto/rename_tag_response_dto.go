@@ -0,0 +1,6 @@
+package dto
+
+type RenameTagResponse struct {
+	Tag     string `json:"tag"`
+	Updated int    `json:"updated"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type ReplayDeadLetter struct {
+	ID int64 `json:"id"`
+}
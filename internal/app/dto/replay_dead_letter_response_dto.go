@@ -0,0 +1,6 @@
+package dto
+
+type ReplayDeadLetterResponse struct {
+	ID       int64 `json:"id"`
+	Replayed bool  `json:"replayed"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type TagTodo struct {
+	ID      int64
+	Version int64
+	Tag     string
+}
@@ -0,0 +1,5 @@
+package dto
+
+type TagTodoResponse struct {
+	GetTodoResponse
+}
@@ -1,8 +1,22 @@
 package dto
 
+import "time"
+
 type Todo struct {
-	ID          int64  `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Completed   bool   `json:"completed"`
+	ID          int64       `json:"id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Completed   bool        `json:"completed"`
+	Priority    string      `json:"priority"`
+	Tags        []string    `json:"tags"`
+	ProjectID   int64       `json:"project_id,omitempty"`
+	ParentID    int64       `json:"parent_id,omitempty"`
+	BlockedBy   []int64     `json:"blocked_by"`
+	Recurrence  *Recurrence `json:"recurrence,omitempty"`
+	Version     int64       `json:"version"`
+	DueAt       *time.Time  `json:"due_at,omitempty"`
+	StartAt     *time.Time  `json:"start_at,omitempty"`
+	CreatedAt   *time.Time  `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time  `json:"updated_at,omitempty"`
+	CompletedAt *time.Time  `json:"completed_at,omitempty"`
 }
@@ -0,0 +1,5 @@
+package dto
+
+type UpdateProject struct {
+	Project
+}
@@ -0,0 +1,5 @@
+package dto
+
+type UpdateProjectResponse struct {
+	Project
+}
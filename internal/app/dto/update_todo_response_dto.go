@@ -3,4 +3,10 @@ package dto
 type UpdateTodoResponse struct {
 	ID      int64 `json:"id"`
 	Updated bool  `json:"updated"`
+	Version int64 `json:"version"`
+	// NextID is the todo created for the next occurrence when completing a
+	// recurring todo.
+	NextID int64 `json:"next_id,omitempty"`
+	// View is the updated todo as GET /todos/{id} serves it, for the ETag.
+	View GetTodoResponse `json:"-"`
 }
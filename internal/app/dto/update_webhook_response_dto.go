@@ -0,0 +1,5 @@
+package dto
+
+type UpdateWebhookResponse struct {
+	Webhook
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Webhook struct {
+	ID  int64  `json:"id"`
+	URL string `json:"url"`
+	// Secret signs the deliveries. It is generated when a new webhook has
+	// none and is only returned by the calls that set it.
+	Secret string `json:"secret,omitempty"`
+	// Events lists the event types to deliver, such as "todo.created"; empty
+	// means all of them.
+	Events    []string   `json:"events"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type DeadLetter struct {
+	ID        int64             `json:"id"`
+	WebhookID int64             `json:"webhook_id"`
+	EventID   string            `json:"event_id"`
+	Event     string            `json:"event"`
+	Payload   json.RawMessage   `json:"payload"`
+	Attempts  []DeliveryAttempt `json:"attempts"`
+	CreatedAt *time.Time        `json:"created_at,omitempty"`
+}
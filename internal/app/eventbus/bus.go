@@ -0,0 +1,177 @@
+// Package eventbus hands the domain events the use cases publish to the
+// subscribers, synchronously or from a queue of their own.
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"todo-api/internal/domain/event"
+)
+
+type Mode int
+
+const (
+	// Sync handlers run inside Publish, one after another, so the use case
+	// returns only once they are done; they must not block.
+	Sync Mode = iota
+	// Async handlers run on a goroutine of their own, in publish order.
+	Async
+)
+
+type Handler func(ctx context.Context, e event.Event)
+
+type Config struct {
+	// QueueSize bounds the events waiting for each async subscriber; events
+	// published while it is full are dropped and logged.
+	QueueSize int
+}
+
+// Bus implements port.EventPublisher. A panicking handler is logged and
+// does not keep the event from the other subscribers.
+type Bus struct {
+	log *slog.Logger
+	cfg Config
+
+	mu      sync.RWMutex
+	subs    []*subscriber
+	running bool
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+type subscriber struct {
+	name   string
+	mode   Mode
+	handle Handler
+	queue  chan delivery
+}
+
+type delivery struct {
+	ctx   context.Context
+	event event.Event
+}
+
+func New(log *slog.Logger, cfg Config) *Bus {
+	cfg.QueueSize = max(cfg.QueueSize, 1)
+	return &Bus{log: log, cfg: cfg}
+}
+
+// Subscribe adds handle under name, which is only used in logs.
+func (b *Bus) Subscribe(name string, mode Mode, handle Handler) {
+	sub := &subscriber{name: name, mode: mode, handle: handle}
+	if mode == Async {
+		sub.queue = make(chan delivery, b.cfg.QueueSize)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs = append(b.subs, sub)
+	if b.running && mode == Async {
+		b.run(sub, b.stop)
+	}
+}
+
+// Publish gives the async subscribers ctx without its cancellation, as they
+// usually outlive the request.
+func (b *Bus) Publish(ctx context.Context, events ...event.Event) {
+	b.mu.RLock()
+	subs := b.subs
+	b.mu.RUnlock()
+
+	for _, e := range events {
+		for _, sub := range subs {
+			if sub.mode == Sync {
+				b.call(ctx, sub, e)
+				continue
+			}
+			select {
+			case sub.queue <- delivery{ctx: context.WithoutCancel(ctx), event: e}:
+			default:
+				b.log.ErrorContext(ctx, "event dropped, subscriber queue is full",
+					slog.String("subscriber", sub.name),
+					slog.String("event", string(e.Type())),
+					slog.Int64("todo_id", e.Subject().ID),
+				)
+			}
+		}
+	}
+}
+
+// Start may follow a Stop: every run gets a stop channel of its own.
+func (b *Bus) Start(_ context.Context) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.running {
+		return
+	}
+
+	b.running = true
+	b.stop = make(chan struct{})
+	for _, sub := range b.subs {
+		if sub.mode == Async {
+			b.run(sub, b.stop)
+		}
+	}
+}
+
+// Stop lets the async subscribers finish the events already queued.
+func (b *Bus) Stop(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.running {
+		b.mu.Unlock()
+		return nil
+	}
+	b.running = false
+	close(b.stop)
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("eventbus: subscribers still busy: %w", ctx.Err())
+	}
+}
+
+func (b *Bus) run(sub *subscriber, stop <-chan struct{}) {
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for {
+			select {
+			case d := <-sub.queue:
+				b.call(d.ctx, sub, d.event)
+			case <-stop:
+				for {
+					select {
+					case d := <-sub.queue:
+						b.call(d.ctx, sub, d.event)
+					default:
+						return
+					}
+				}
+			}
+		}
+	}()
+}
+
+func (b *Bus) call(ctx context.Context, sub *subscriber, e event.Event) {
+	defer func() {
+		if err := recover(); err != nil {
+			b.log.ErrorContext(ctx, "event handler panicked",
+				slog.String("subscriber", sub.name),
+				slog.String("event", string(e.Type())),
+				slog.Any("err", err),
+			)
+		}
+	}()
+	sub.handle(ctx, e)
+}
@@ -0,0 +1,144 @@
+package eventbus_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+	"todo-api/internal/app/eventbus"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/event"
+)
+
+func newBus(queueSize int) *eventbus.Bus {
+	testLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return eventbus.New(testLogger, eventbus.Config{QueueSize: queueSize})
+}
+
+// received collects the ids of the todos in the events a handler gets.
+type received struct {
+	mu  sync.Mutex
+	ids []int64
+}
+
+func (r *received) handle(_ context.Context, e event.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids = append(r.ids, e.Subject().ID)
+}
+
+func (r *received) get() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.ids)
+}
+
+func created(id int64) event.Event {
+	return event.TodoCreated{Todo: entity.Todo{ID: id}}
+}
+
+func TestBus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - sync", func(t *testing.T) {
+		bus := newBus(1)
+		var first, second received
+		bus.Subscribe("first", eventbus.Sync, first.handle)
+		bus.Subscribe("second", eventbus.Sync, second.handle)
+
+		bus.Publish(ctx, created(1), created(2))
+		if !slices.Equal(first.get(), []int64{1, 2}) || !slices.Equal(second.get(), []int64{1, 2}) {
+			t.Errorf("expected both events before Publish returns, got %v and %v", first.get(), second.get())
+		}
+	})
+
+	t.Run("Success - async", func(t *testing.T) {
+		bus := newBus(8)
+		release := make(chan struct{})
+		var got received
+		bus.Subscribe("slow", eventbus.Async, func(ctx context.Context, e event.Event) {
+			<-release
+			got.handle(ctx, e)
+		})
+		bus.Start(ctx)
+
+		requestCtx, cancel := context.WithCancel(ctx)
+		bus.Publish(requestCtx, created(1), created(2), created(3))
+		cancel()
+		if len(got.get()) != 0 {
+			t.Fatalf("expected Publish not to wait for async subscribers")
+		}
+
+		close(release)
+		stopCtx, stop := context.WithTimeout(ctx, time.Second)
+		defer stop()
+		if err := bus.Stop(stopCtx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !slices.Equal(got.get(), []int64{1, 2, 3}) {
+			t.Errorf("expected the queued events in order, got %v", got.get())
+		}
+	})
+
+	t.Run("Success - restart", func(t *testing.T) {
+		bus := newBus(8)
+		var got received
+		bus.Subscribe("restarted", eventbus.Async, got.handle)
+
+		for id := int64(1); id <= 2; id++ {
+			bus.Start(ctx)
+			bus.Publish(ctx, created(id))
+			if err := bus.Stop(ctx); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+		if !slices.Equal(got.get(), []int64{1, 2}) {
+			t.Errorf("expected the events of both runs, got %v", got.get())
+		}
+	})
+
+	t.Run("Success - panicking handler", func(t *testing.T) {
+		bus := newBus(1)
+		var got received
+		bus.Subscribe("broken", eventbus.Sync, func(context.Context, event.Event) { panic("boom") })
+		bus.Subscribe("fine", eventbus.Sync, got.handle)
+
+		bus.Publish(ctx, created(1))
+		if !slices.Equal(got.get(), []int64{1}) {
+			t.Errorf("expected the event to reach the other subscriber, got %v", got.get())
+		}
+	})
+
+	t.Run("Error - async queue full", func(t *testing.T) {
+		bus := newBus(1)
+		var got received
+		bus.Subscribe("idle", eventbus.Async, got.handle)
+
+		bus.Publish(ctx, created(1), created(2)) // not started: 2 does not fit
+		bus.Start(ctx)
+		if err := bus.Stop(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !slices.Equal(got.get(), []int64{1}) {
+			t.Errorf("expected only the first event, got %v", got.get())
+		}
+	})
+
+	t.Run("Error - stop timeout", func(t *testing.T) {
+		bus := newBus(1)
+		release := make(chan struct{})
+		defer close(release)
+		bus.Subscribe("stuck", eventbus.Async, func(context.Context, event.Event) { <-release })
+		bus.Start(ctx)
+		bus.Publish(ctx, created(1))
+
+		stopCtx, stop := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer stop()
+		if err := bus.Stop(stopCtx); err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
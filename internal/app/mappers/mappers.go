@@ -1,6 +1,8 @@
 package mappers
 
 import (
+	"slices"
+	"time"
 	"todo-api/internal/app/dto"
 	"todo-api/internal/domain/entity"
 )
@@ -11,6 +13,18 @@ func MapTodoDTOToDomainTodo(input dto.Todo) *entity.Todo {
 		Title:       input.Title,
 		Description: input.Description,
 		Completed:   input.Completed,
+		Priority:    mapPriority(input.Priority),
+		Tags:        slices.Clone(input.Tags),
+		BlockedBy:   slices.Clone(input.BlockedBy),
+		Recurrence:  mapRecurrence(input.Recurrence),
+		ProjectID:   input.ProjectID,
+		ParentID:    input.ParentID,
+		Version:     input.Version,
+		DueAt:       mapTime(input.DueAt),
+		StartAt:     mapTime(input.StartAt),
+		CreatedAt:   mapTime(input.CreatedAt),
+		UpdatedAt:   mapTime(input.UpdatedAt),
+		CompletedAt: mapTime(input.CompletedAt),
 	}
 }
 
@@ -20,18 +34,155 @@ func MapDomainTodoToTodoDTO(input *entity.Todo) dto.Todo {
 		Title:       input.Title,
 		Description: input.Description,
 		Completed:   input.Completed,
+		Priority:    input.Priority.String(),
+		Tags:        mapTags(input.Tags),
+		BlockedBy:   mapBlockers(input.BlockedBy),
+		Recurrence:  mapOptionalRecurrence(input.Recurrence),
+		ProjectID:   input.ProjectID,
+		ParentID:    input.ParentID,
+		Version:     input.Version,
+		DueAt:       mapOptionalTime(input.DueAt),
+		StartAt:     mapOptionalTime(input.StartAt),
+		CreatedAt:   mapOptionalTime(input.CreatedAt),
+		UpdatedAt:   mapOptionalTime(input.UpdatedAt),
+		CompletedAt: mapOptionalTime(input.CompletedAt),
 	}
 }
 
 func MapDomainTodoListToTodoListDTO(input []*entity.Todo) dto.GetTodoListResponse {
 	todos := make([]dto.Todo, len(input))
 	for i := 0; i < len(input); i++ {
-		todos[i] = dto.Todo{
-			ID:          input[i].ID,
-			Title:       input[i].Title,
-			Description: input[i].Description,
-			Completed:   input[i].Completed,
-		}
+		todos[i] = MapDomainTodoToTodoDTO(input[i])
 	}
 	return dto.GetTodoListResponse{Todos: todos}
 }
+
+func MapProjectDTOToDomainProject(input dto.Project) *entity.Project {
+	return &entity.Project{
+		ID:          input.ID,
+		Name:        input.Name,
+		Description: input.Description,
+		Version:     input.Version,
+		CreatedAt:   mapTime(input.CreatedAt),
+		UpdatedAt:   mapTime(input.UpdatedAt),
+	}
+}
+
+func MapDomainProjectToProjectDTO(input *entity.Project) dto.Project {
+	return dto.Project{
+		ID:          input.ID,
+		Name:        input.Name,
+		Description: input.Description,
+		Version:     input.Version,
+		CreatedAt:   mapOptionalTime(input.CreatedAt),
+		UpdatedAt:   mapOptionalTime(input.UpdatedAt),
+	}
+}
+
+// MapDomainReminderToReminderDTO fills FireAt from todo when it is given.
+func MapDomainReminderToReminderDTO(input *entity.Reminder, todo *entity.Todo) dto.Reminder {
+	out := dto.Reminder{
+		ID:        input.ID,
+		TodoID:    input.TodoID,
+		At:        mapOptionalTime(input.At),
+		Channel:   input.Channel,
+		Target:    input.Target,
+		Status:    string(input.Status),
+		SentAt:    mapOptionalTime(input.SentAt),
+		Attempts:  make([]dto.DeliveryAttempt, len(input.Attempts)),
+		CreatedAt: mapOptionalTime(input.CreatedAt),
+	}
+	if input.At.IsZero() {
+		out.Before = input.Before.String()
+	}
+	if todo != nil {
+		if fireAt, ok := input.FireAt(todo); ok {
+			out.FireAt = &fireAt
+		}
+	}
+	for i, attempt := range input.Attempts {
+		out.Attempts[i] = dto.DeliveryAttempt{At: attempt.At, Error: attempt.Error}
+	}
+	return out
+}
+
+// MapDomainWebhookToWebhookDTO leaves the secret out.
+func MapDomainWebhookToWebhookDTO(input *entity.Webhook) dto.Webhook {
+	out := dto.Webhook{
+		ID:        input.ID,
+		URL:       input.URL,
+		Events:    make([]string, len(input.Events)),
+		CreatedAt: mapOptionalTime(input.CreatedAt),
+		UpdatedAt: mapOptionalTime(input.UpdatedAt),
+	}
+	for i, event := range input.Events {
+		out.Events[i] = string(event)
+	}
+	return out
+}
+
+func MapDomainDeadLetterToDeadLetterDTO(input *entity.DeadLetter) dto.DeadLetter {
+	out := dto.DeadLetter{
+		ID:        input.ID,
+		WebhookID: input.WebhookID,
+		EventID:   input.EventID,
+		Event:     string(input.Event),
+		Payload:   input.Payload,
+		Attempts:  make([]dto.DeliveryAttempt, len(input.Attempts)),
+		CreatedAt: mapOptionalTime(input.CreatedAt),
+	}
+	for i, attempt := range input.Attempts {
+		out.Attempts[i] = dto.DeliveryAttempt{At: attempt.At, Error: attempt.Error}
+	}
+	return out
+}
+
+// mapPriority expects a name the use case has validated; "" means none.
+func mapPriority(name string) entity.Priority {
+	p, _ := entity.ParsePriority(name)
+	return p
+}
+
+// mapTags always returns a list so that untagged todos encode as [].
+func mapTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return slices.Clone(tags)
+}
+
+func mapBlockers(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
+	}
+	return slices.Clone(ids)
+}
+
+func mapRecurrence(r *dto.Recurrence) entity.Recurrence {
+	if r == nil {
+		return entity.Recurrence{}
+	}
+	return entity.Recurrence{Rule: r.Rule, TimeZone: r.TimeZone}
+}
+
+func mapOptionalRecurrence(r entity.Recurrence) *dto.Recurrence {
+	if r.IsZero() {
+		return nil
+	}
+	return &dto.Recurrence{Rule: r.Rule, TimeZone: r.TimeZone}
+}
+
+// Times are kept in UTC so that every storage adapter returns them unchanged.
+func mapTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
+
+func mapOptionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,361 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"todo-api/internal/app/uc_errors"
+)
+
+// Merge applies an RFC 7386 JSON Merge Patch to doc.
+func Merge(doc, patch []byte) ([]byte, error) {
+	var target, p any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("%w: %v", uc_errors.InvalidPatchError, err)
+	}
+
+	return json.Marshal(merge(target, p))
+}
+
+func merge(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any)
+	}
+
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+		t[key] = merge(t[key], value)
+	}
+
+	return t
+}
+
+type operation struct {
+	op    string
+	path  []string
+	from  []string
+	value any
+}
+
+// Apply applies an RFC 6902 JSON Patch to doc. Operations run in order and
+// the whole patch fails if any of them does, including a failing "test".
+func Apply(doc, patch []byte) ([]byte, error) {
+	var target any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
+	}
+
+	ops, err := parseOperations(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, op := range ops {
+		target, err = op.apply(target)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.op, pointer(op.path), err)
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+func parseOperations(patch []byte) ([]operation, error) {
+	var raw []map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", uc_errors.InvalidPatchError, err)
+	}
+
+	ops := make([]operation, 0, len(raw))
+	for i, fields := range raw {
+		var op operation
+
+		if err := json.Unmarshal(fields["op"], &op.op); err != nil {
+			return nil, fmt.Errorf("%w: operation %d has no op", uc_errors.InvalidPatchError, i)
+		}
+
+		var path string
+		if err := json.Unmarshal(fields["path"], &path); err != nil {
+			return nil, fmt.Errorf("%w: operation %d has no path", uc_errors.InvalidPatchError, i)
+		}
+		tokens, err := parsePointer(path)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+		op.path = tokens
+
+		switch op.op {
+		case "add", "replace", "test":
+			rawValue, ok := fields["value"]
+			if !ok {
+				return nil, fmt.Errorf("%w: operation %d has no value", uc_errors.InvalidPatchError, i)
+			}
+			if err := json.Unmarshal(rawValue, &op.value); err != nil {
+				return nil, fmt.Errorf("%w: operation %d: %v", uc_errors.InvalidPatchError, i, err)
+			}
+		case "move", "copy":
+			var from string
+			if err := json.Unmarshal(fields["from"], &from); err != nil {
+				return nil, fmt.Errorf("%w: operation %d has no from", uc_errors.InvalidPatchError, i)
+			}
+			if op.from, err = parsePointer(from); err != nil {
+				return nil, fmt.Errorf("operation %d: %w", i, err)
+			}
+		case "remove":
+		default:
+			return nil, fmt.Errorf("%w: unknown op %q", uc_errors.InvalidPatchError, op.op)
+		}
+
+		ops = append(ops, op)
+	}
+
+	return ops, nil
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: pointer %q must start with /", uc_errors.InvalidPatchError, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func pointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+func (op operation) apply(doc any) (any, error) {
+	switch op.op {
+	case "add":
+		return add(doc, op.path, op.value)
+	case "remove":
+		doc, _, err := remove(doc, op.path)
+		return doc, err
+	case "replace":
+		if _, err := get(doc, op.path); err != nil {
+			return nil, err
+		}
+		doc, _, err := remove(doc, op.path)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, op.path, op.value)
+	case "move":
+		if isPrefix(op.from, op.path) && len(op.from) < len(op.path) {
+			return nil, fmt.Errorf("%w: cannot move a value into itself", uc_errors.InvalidPatchError)
+		}
+		doc, value, err := remove(doc, op.from)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, op.path, value)
+	case "copy":
+		value, err := get(doc, op.from)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, op.path, deepCopy(value))
+	case "test":
+		value, err := get(doc, op.path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", uc_errors.PatchTestFailedError, err)
+		}
+		if !reflect.DeepEqual(value, op.value) {
+			return nil, fmt.Errorf("%w: value differs", uc_errors.PatchTestFailedError)
+		}
+		return doc, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown op %q", uc_errors.InvalidPatchError, op.op)
+}
+
+func get(node any, path []string) (any, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			child, ok := n[token]
+			if !ok {
+				return nil, errPathNotFound
+			}
+			node = child
+		case []any:
+			idx, err := arrayIndex(token, len(n)-1)
+			if err != nil {
+				return nil, errPathNotFound
+			}
+			node = n[idx]
+		default:
+			return nil, errPathNotFound
+		}
+	}
+
+	return node, nil
+}
+
+func add(node any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	token, rest := path[0], path[1:]
+	switch n := node.(type) {
+	case map[string]any:
+		if len(rest) == 0 {
+			n[token] = value
+			return n, nil
+		}
+		child, ok := n[token]
+		if !ok {
+			return nil, errPathNotFound
+		}
+		updated, err := add(child, rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = updated
+		return n, nil
+	case []any:
+		if len(rest) == 0 {
+			idx := len(n)
+			if token != "-" {
+				var err error
+				if idx, err = arrayIndex(token, len(n)); err != nil {
+					return nil, errPathNotFound
+				}
+			}
+			n = append(n, nil)
+			copy(n[idx+1:], n[idx:])
+			n[idx] = value
+			return n, nil
+		}
+		idx, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, errPathNotFound
+		}
+		updated, err := add(n[idx], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[idx] = updated
+		return n, nil
+	}
+
+	return nil, errPathNotFound
+}
+
+func remove(node any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, node, nil
+	}
+
+	token, rest := path[0], path[1:]
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[token]
+		if !ok {
+			return nil, nil, errPathNotFound
+		}
+		if len(rest) == 0 {
+			delete(n, token)
+			return n, child, nil
+		}
+		updated, removed, err := remove(child, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		n[token] = updated
+		return n, removed, nil
+	case []any:
+		idx, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, nil, errPathNotFound
+		}
+		if len(rest) == 0 {
+			removed := n[idx]
+			return append(n[:idx], n[idx+1:]...), removed, nil
+		}
+		updated, removed, err := remove(n[idx], rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		n[idx] = updated
+		return n, removed, nil
+	}
+
+	return nil, nil, errPathNotFound
+}
+
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, strconv.ErrSyntax
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, err
+	}
+	if idx < 0 || idx > max {
+		return 0, strconv.ErrRange
+	}
+	return idx, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(v))
+		for k, child := range v {
+			c[k] = deepCopy(child)
+		}
+		return c
+	case []any:
+		c := make([]any, len(v))
+		for i, child := range v {
+			c[i] = deepCopy(child)
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+var errPathNotFound = fmt.Errorf("%w: path does not exist", uc_errors.InvalidPatchError)
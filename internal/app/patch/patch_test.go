@@ -0,0 +1,118 @@
+package patch_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"todo-api/internal/app/patch"
+	"todo-api/internal/app/uc_errors"
+)
+
+func assertJSONEqual(t *testing.T, expected string, got []byte) {
+	t.Helper()
+
+	var want, have any
+	_ = json.Unmarshal([]byte(expected), &want)
+	_ = json.Unmarshal(got, &have)
+	if !reflect.DeepEqual(want, have) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	// Examples from RFC 7386 appendix A.
+	cases := []struct {
+		name, doc, patch, expected string
+	}{
+		{"Replace", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"Add", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"Remove", `{"a":"b"}`, `{"a":null}`, `{}`},
+		{"Nested", `{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{"Array replaces", `{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"Non-object target", `["c"]`, `{"a":"b"}`, `{"a":"b"}`},
+		{"Non-object patch", `{"a":"foo"}`, `"bar"`, `"bar"`},
+		{"Null inside array kept", `{}`, `{"a":[null]}`, `{"a":[null]}`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := patch.Merge([]byte(tc.doc), []byte(tc.patch))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			assertJSONEqual(t, tc.expected, got)
+		})
+	}
+
+	t.Run("Error - malformed patch", func(t *testing.T) {
+		if _, err := patch.Merge([]byte(`{}`), []byte(`{`)); !errors.Is(err, uc_errors.InvalidPatchError) {
+			t.Errorf("expected InvalidPatchError, got %v", err)
+		}
+	})
+}
+
+func TestApply(t *testing.T) {
+	// Mostly examples from RFC 6902 appendix A.
+	cases := []struct {
+		name, doc, patch, expected string
+	}{
+		{"Add member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{"Add array element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"Append with dash", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+		{"Remove member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"Remove array element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"Replace", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{"Move member", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{"Move array element", `{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{"Copy", `{"a":{"b":1}}`, `[{"op":"copy","from":"/a","path":"/c"}]`, `{"a":{"b":1},"c":{"b":1}}`},
+		{"Test passes", `{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{"Escaped pointer", `{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10},{"op":"replace","path":"/~1","value":1}]`, `{"/":1,"~1":10}`},
+		{"Add null value", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":null}]`, `{"baz":null,"foo":"bar"}`},
+		{"Replace root", `{"foo":"bar"}`, `[{"op":"replace","path":"","value":{"baz":"qux"}}]`, `{"baz":"qux"}`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := patch.Apply([]byte(tc.doc), []byte(tc.patch))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			assertJSONEqual(t, tc.expected, got)
+		})
+	}
+
+	failures := []struct {
+		name, doc, patch string
+		expected         error
+	}{
+		{"Test fails", `{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`, uc_errors.PatchTestFailedError},
+		{"Test on missing path", `{}`, `[{"op":"test","path":"/baz","value":"bar"}]`, uc_errors.PatchTestFailedError},
+		{"Add to nonexistent target", `{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`, uc_errors.InvalidPatchError},
+		{"Remove missing", `{"foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, uc_errors.InvalidPatchError},
+		{"Replace missing", `{"foo":"bar"}`, `[{"op":"replace","path":"/baz","value":1}]`, uc_errors.InvalidPatchError},
+		{"Array index out of bounds", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/5","value":1}]`, uc_errors.InvalidPatchError},
+		{"Leading zero index", `{"foo":["a","b"]}`, `[{"op":"remove","path":"/foo/01"}]`, uc_errors.InvalidPatchError},
+		{"Unknown op", `{}`, `[{"op":"frobnicate","path":"/a"}]`, uc_errors.InvalidPatchError},
+		{"Missing value", `{}`, `[{"op":"add","path":"/a"}]`, uc_errors.InvalidPatchError},
+		{"Move into child", `{"a":{"b":{}}}`, `[{"op":"move","from":"/a","path":"/a/b/c"}]`, uc_errors.InvalidPatchError},
+		{"Not an array", `{}`, `{"op":"add"}`, uc_errors.InvalidPatchError},
+	}
+
+	for _, tc := range failures {
+		t.Run("Error - "+tc.name, func(t *testing.T) {
+			if _, err := patch.Apply([]byte(tc.doc), []byte(tc.patch)); !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+
+	t.Run("Failed patch is atomic", func(t *testing.T) {
+		doc := []byte(`{"a":1}`)
+		_, err := patch.Apply(doc, []byte(`[{"op":"replace","path":"/a","value":2},{"op":"test","path":"/a","value":3}]`))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		assertJSONEqual(t, `{"a":1}`, doc)
+	})
+}
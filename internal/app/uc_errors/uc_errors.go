@@ -3,15 +3,77 @@ package uc_errors
 import "errors"
 
 var (
-	InvalidTodoIDError     = errors.New("todo id must be positive digit")
-	EmptyTitleError        = errors.New("empty todo title")
-	InvalidLimitError      = errors.New("limit must be a positive digit or 0")
-	InvalidOffsetError     = errors.New("offset must be a positive digit or 0")
-	TodoNotFoundError      = errors.New("todo with this id is not found")
-	TodoAlreadyExistsError = errors.New("todo with this id already exists")
-	CreateTodoError        = errors.New("failed to create todo")
-	GetTodoError           = errors.New("failed to get todo")
-	GetTodoListError       = errors.New("failed to get todo list")
-	UpdateTodoError        = errors.New("failed to update todo")
-	DeleteTodoError        = errors.New("failed to delete todo")
+	InvalidTodoIDError          = errors.New("todo id must be positive digit")
+	EmptyTitleError             = errors.New("empty todo title")
+	InvalidScheduleError        = errors.New("start date must not be after due date")
+	InvalidPriorityError        = errors.New("priority must be one of none, low, medium, high, urgent")
+	InvalidRecurrenceError      = errors.New("invalid recurrence")
+	InvalidRangeError           = errors.New("invalid range")
+	InvalidReminderError        = errors.New("invalid reminder")
+	InvalidReminderIDError      = errors.New("reminder id must be positive digit")
+	InvalidWebhookError         = errors.New("invalid webhook")
+	InvalidWebhookIDError       = errors.New("webhook id must be positive digit")
+	InvalidDeadLetterIDError    = errors.New("dead letter id must be positive digit")
+	InvalidNextCountError       = errors.New("n must be between 1 and 100")
+	InvalidTagError             = errors.New("tag must be 1 to 32 characters without control characters")
+	TooManyTagsError            = errors.New("todo can have at most 20 tags")
+	InvalidProjectIDError       = errors.New("project id must be positive digit")
+	EmptyProjectNameError       = errors.New("empty project name")
+	UnknownProjectError         = errors.New("project_id refers to a project that does not exist")
+	UnknownParentError          = errors.New("parent_id refers to a todo that does not exist")
+	ParentCycleError            = errors.New("parent_id would make the todo its own ancestor")
+	OpenSubtasksError           = errors.New("todo has open subtasks")
+	TodoHasSubtasksError        = errors.New("todo has subtasks")
+	UnknownBlockerError         = errors.New("blocker refers to a todo that does not exist")
+	DependencyCycleError        = errors.New("dependency would make a cycle")
+	BlockedTodoError            = errors.New("todo is blocked by open todos")
+	InvalidPatchError           = errors.New("invalid patch")
+	PatchTestFailedError        = errors.New("patch test operation failed")
+	UnsupportedPatchError       = errors.New("unsupported patch format")
+	ReadOnlyFieldError          = errors.New("patch modifies a read-only field")
+	InvalidLimitError           = errors.New("limit must be a positive digit or 0")
+	InvalidOffsetError          = errors.New("offset must be a positive digit or 0")
+	InvalidFilterError          = errors.New("invalid filter")
+	InvalidSortError            = errors.New("invalid sort")
+	InvalidCursorError          = errors.New("invalid cursor")
+	TodoNotFoundError           = errors.New("todo with this id is not found")
+	TodoAlreadyExistsError      = errors.New("todo with this id already exists")
+	TodoVersionConflictError    = errors.New("todo has been modified by someone else")
+	TagNotFoundError            = errors.New("tag is not used by any todo")
+	ProjectNotFoundError        = errors.New("project with this id is not found")
+	ProjectNotEmptyError        = errors.New("project still has todos")
+	ProjectVersionConflictError = errors.New("project has been modified by someone else")
+	ReminderNotFoundError       = errors.New("reminder with this id is not found")
+	WebhookNotFoundError        = errors.New("webhook with this id is not found")
+	DeadLetterNotFoundError     = errors.New("dead letter with this id is not found")
+	CreateTodoError             = errors.New("failed to create todo")
+	GetTodoError                = errors.New("failed to get todo")
+	GetTodoListError            = errors.New("failed to get todo list")
+	UpdateTodoError             = errors.New("failed to update todo")
+	DeleteTodoError             = errors.New("failed to delete todo")
+	PatchTodoError              = errors.New("failed to patch todo")
+	GetTodoTreeError            = errors.New("failed to get todo tree")
+	BlockTodoError              = errors.New("failed to change todo blockers")
+	GetCriticalPathError        = errors.New("failed to get critical path")
+	GetOccurrencesError         = errors.New("failed to get occurrences")
+	CreateReminderError         = errors.New("failed to create reminder")
+	GetRemindersError           = errors.New("failed to get reminders")
+	DeleteReminderError         = errors.New("failed to delete reminder")
+	FireRemindersError          = errors.New("failed to fire reminders")
+	CreateWebhookError          = errors.New("failed to create webhook")
+	GetWebhookError             = errors.New("failed to get webhook")
+	GetWebhookListError         = errors.New("failed to get webhook list")
+	UpdateWebhookError          = errors.New("failed to update webhook")
+	DeleteWebhookError          = errors.New("failed to delete webhook")
+	GetDeadLettersError         = errors.New("failed to get dead letters")
+	ReplayDeadLetterError       = errors.New("failed to replay dead letter")
+	DeleteDeadLetterError       = errors.New("failed to delete dead letter")
+	TagTodoError                = errors.New("failed to tag todo")
+	GetTagsError                = errors.New("failed to get tags")
+	RenameTagError              = errors.New("failed to rename tag")
+	CreateProjectError          = errors.New("failed to create project")
+	GetProjectError             = errors.New("failed to get project")
+	GetProjectListError         = errors.New("failed to get project list")
+	UpdateProjectError          = errors.New("failed to update project")
+	DeleteProjectError          = errors.New("failed to delete project")
 )
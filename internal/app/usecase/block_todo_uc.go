@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+)
+
+type AddTodoBlockerUC struct {
+	Storage   port.DataStorage
+	Clock     port.Clock
+	Publisher port.EventPublisher
+}
+
+func NewAddTodoBlockerUC(storage port.DataStorage, clock port.Clock, publisher port.EventPublisher) *AddTodoBlockerUC {
+	return &AddTodoBlockerUC{Storage: storage, Clock: clock, Publisher: publisher}
+}
+
+// Execute makes the todo wait for in.BlockerID; adding an existing edge is a
+// no-op.
+func (uc *AddTodoBlockerUC) Execute(ctx context.Context, in dto.BlockTodo) (dto.BlockTodoResponse, error) {
+	return executeBlockTodo(ctx, uc.Storage, uc.Clock, uc.Publisher, in, func(todo *entity.Todo) (bool, error) {
+		i, found := slices.BinarySearch(todo.BlockedBy, in.BlockerID)
+		if found {
+			return false, nil
+		}
+
+		current := *todo
+		todo.BlockedBy = slices.Insert(slices.Clone(todo.BlockedBy), i, in.BlockerID)
+		if err := checkBlockers(ctx, uc.Storage, &current, todo); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+type RemoveTodoBlockerUC struct {
+	Storage   port.DataStorage
+	Clock     port.Clock
+	Publisher port.EventPublisher
+}
+
+func NewRemoveTodoBlockerUC(storage port.DataStorage, clock port.Clock, publisher port.EventPublisher) *RemoveTodoBlockerUC {
+	return &RemoveTodoBlockerUC{Storage: storage, Clock: clock, Publisher: publisher}
+}
+
+// Execute drops the edge to in.BlockerID; removing a missing edge is a no-op.
+func (uc *RemoveTodoBlockerUC) Execute(ctx context.Context, in dto.BlockTodo) (dto.BlockTodoResponse, error) {
+	return executeBlockTodo(ctx, uc.Storage, uc.Clock, uc.Publisher, in, func(todo *entity.Todo) (bool, error) {
+		i, found := slices.BinarySearch(todo.BlockedBy, in.BlockerID)
+		if !found {
+			return false, nil
+		}
+		todo.BlockedBy = slices.Delete(slices.Clone(todo.BlockedBy), i, i+1)
+		if len(todo.BlockedBy) == 0 {
+			todo.BlockedBy = nil
+		}
+		return true, nil
+	})
+}
+
+func executeBlockTodo(
+	ctx context.Context,
+	storage port.DataStorage,
+	clock port.Clock,
+	publisher port.EventPublisher,
+	in dto.BlockTodo,
+	change func(todo *entity.Todo) (bool, error),
+) (dto.BlockTodoResponse, error) {
+	failed := dto.BlockTodoResponse{GetTodoResponse: dto.GetTodoResponse{Todo: dto.Todo{ID: in.ID}}}
+	if in.ID <= 0 {
+		return failed, uc_errors.InvalidTodoIDError
+	}
+	if in.BlockerID <= 0 {
+		return failed, uc_errors.UnknownBlockerError
+	}
+
+	todo, err := modifyTodo(ctx, storage, clock, publisher, in.ID, in.Version, change)
+	if err != nil {
+		if !errors.Is(err, uc_errors.TodoNotFoundError) &&
+			!errors.Is(err, uc_errors.TodoVersionConflictError) &&
+			!isBlockerError(err) {
+			return failed, uc_errors.Wrap(uc_errors.BlockTodoError, err)
+		}
+		return failed, err
+	}
+
+	view, err := viewTodo(ctx, storage, todo)
+	if err != nil {
+		return failed, uc_errors.Wrap(uc_errors.BlockTodoError, err)
+	}
+	return dto.BlockTodoResponse{GetTodoResponse: view}, nil
+}
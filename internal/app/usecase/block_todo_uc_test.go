@@ -0,0 +1,135 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/entity"
+)
+
+func TestBlockTodoUC(t *testing.T) {
+	store := storage.NewDataStorage()
+	clock := newFakeClock()
+	add := usecase.NewAddTodoBlockerUC(store, clock, nil)
+	remove := usecase.NewRemoveTodoBlockerUC(store, clock, nil)
+	ctx := context.Background()
+
+	// 3 waits for 2, which waits for 1.
+	for _, todo := range []entity.Todo{
+		{Title: "Buy paint"},
+		{Title: "Paint walls", BlockedBy: []int64{1}},
+		{Title: "Hang pictures", BlockedBy: []int64{2}},
+	} {
+		_ = store.CreateTodo(ctx, &todo)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := add.Execute(ctx, dto.BlockTodo{ID: 3, BlockerID: 1})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !slices.Equal(result.BlockedBy, []int64{1, 2}) || result.Version != 2 {
+			t.Errorf("expected blockers [1 2] at version 2, got %v at %d", result.BlockedBy, result.Version)
+		}
+
+		result, err = remove.Execute(ctx, dto.BlockTodo{ID: 3, BlockerID: 1})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !slices.Equal(result.BlockedBy, []int64{2}) {
+			t.Errorf("expected blockers [2], got %v", result.BlockedBy)
+		}
+	})
+
+	t.Run("Error - cycle", func(t *testing.T) {
+		for _, in := range []dto.BlockTodo{{ID: 1, BlockerID: 3}, {ID: 1, BlockerID: 1}} {
+			if _, err := add.Execute(ctx, in); !errors.Is(err, uc_errors.DependencyCycleError) {
+				t.Errorf("%+v: expected DependencyCycleError, got %v", in, err)
+			}
+		}
+	})
+
+	t.Run("Error - unknown blocker", func(t *testing.T) {
+		if _, err := add.Execute(ctx, dto.BlockTodo{ID: 1, BlockerID: 42}); !errors.Is(err, uc_errors.UnknownBlockerError) {
+			t.Errorf("expected UnknownBlockerError, got %v", err)
+		}
+	})
+
+	t.Run("Error - cycle through update", func(t *testing.T) {
+		update := usecase.NewUpdateTodoUC(store, store, clock, nil, usecase.SubtaskCompleteAllow)
+		in := dto.UpdateTodo{Todo: dto.Todo{ID: 1, Title: "Buy paint", BlockedBy: []int64{3}}}
+		if _, err := update.Execute(ctx, in); !errors.Is(err, uc_errors.DependencyCycleError) {
+			t.Errorf("expected DependencyCycleError, got %v", err)
+		}
+	})
+
+	t.Run("Error - complete a blocked todo", func(t *testing.T) {
+		update := usecase.NewUpdateTodoUC(store, store, clock, nil, usecase.SubtaskCompleteAllow)
+		in := dto.UpdateTodo{Todo: dto.Todo{ID: 2, Title: "Paint walls", Completed: true, BlockedBy: []int64{1}}}
+		if _, err := update.Execute(ctx, in); !errors.Is(err, uc_errors.BlockedTodoError) {
+			t.Errorf("expected BlockedTodoError, got %v", err)
+		}
+
+		get := usecase.NewGetTodoUC(store)
+		if result, _ := get.Execute(ctx, dto.GetTodo{ID: 2}); !result.Blocked {
+			t.Error("expected todo 2 to be blocked")
+		}
+		if result, _ := get.Execute(ctx, dto.GetTodo{ID: 1}); result.Blocked {
+			t.Error("expected todo 1 not to be blocked")
+		}
+	})
+}
+
+func TestGetCriticalPathUC(t *testing.T) {
+	store := storage.NewDataStorage()
+	uc := usecase.NewGetCriticalPathUC(store)
+	ctx := context.Background()
+
+	// 5 waits for 2 and 4; 4 waits for 3, which waits for 1. 6 is done.
+	for _, todo := range []entity.Todo{
+		{Title: "Buy paint"},
+		{Title: "Move furniture", BlockedBy: []int64{6}},
+		{Title: "Sand walls", BlockedBy: []int64{1}},
+		{Title: "Paint walls", BlockedBy: []int64{3}},
+		{Title: "Hang pictures", BlockedBy: []int64{2, 4}},
+		{Title: "Get keys", Completed: true},
+	} {
+		_ = store.CreateTodo(ctx, &todo)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := uc.Execute(ctx, dto.GetCriticalPath{ID: 5})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		ids := make([]int64, len(result.Todos))
+		for i, todo := range result.Todos {
+			ids[i] = todo.ID
+		}
+		if !slices.Equal(ids, []int64{1, 3, 4, 5}) {
+			t.Errorf("expected path [1 3 4 5], got %v", ids)
+		}
+	})
+
+	t.Run("Success - completed blockers are skipped", func(t *testing.T) {
+		result, err := uc.Execute(ctx, dto.GetCriticalPath{ID: 2})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.Todos) != 1 || result.Todos[0].ID != 2 {
+			t.Errorf("expected path [2], got %+v", result.Todos)
+		}
+	})
+
+	t.Run("Error - todo not found", func(t *testing.T) {
+		if _, err := uc.Execute(ctx, dto.GetCriticalPath{ID: 42}); !errors.Is(err, uc_errors.TodoNotFoundError) {
+			t.Errorf("expected TodoNotFoundError, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+type CreateProjectUC struct {
+	Projects port.ProjectStorage
+	Clock    port.Clock
+}
+
+func NewCreateProjectUC(projects port.ProjectStorage, clock port.Clock) *CreateProjectUC {
+	return &CreateProjectUC{Projects: projects, Clock: clock}
+}
+
+func (uc *CreateProjectUC) Execute(ctx context.Context, in dto.CreateProject) (dto.CreateProjectResponse, error) {
+	if strings.TrimSpace(in.Name) == "" {
+		return dto.CreateProjectResponse{}, uc_errors.EmptyProjectNameError
+	}
+
+	project := mappers.MapProjectDTOToDomainProject(in.Project)
+	project.ID = 0
+	project.CreatedAt = uc.Clock.Now()
+	project.UpdatedAt = project.CreatedAt
+
+	if err := uc.Projects.CreateProject(ctx, project); err != nil {
+		return dto.CreateProjectResponse{}, uc_errors.Wrap(uc_errors.CreateProjectError, err)
+	}
+
+	return dto.CreateProjectResponse{Project: mappers.MapDomainProjectToProjectDTO(project)}, nil
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+)
+
+type CreateReminderUC struct {
+	Storage   port.DataStorage
+	Reminders port.ReminderStorage
+	Clock     port.Clock
+	// Notifiers maps channel names to the notifiers that deliver over them.
+	Notifiers map[string]port.Notifier
+}
+
+func NewCreateReminderUC(
+	storage port.DataStorage,
+	reminders port.ReminderStorage,
+	clock port.Clock,
+	notifiers map[string]port.Notifier,
+) *CreateReminderUC {
+	return &CreateReminderUC{Storage: storage, Reminders: reminders, Clock: clock, Notifiers: notifiers}
+}
+
+// Execute attaches a reminder to a todo. A relative reminder of a todo
+// without a due date waits until the todo gets one.
+func (uc *CreateReminderUC) Execute(ctx context.Context, in dto.CreateReminder) (dto.CreateReminderResponse, error) {
+	if in.TodoID <= 0 {
+		return dto.CreateReminderResponse{}, uc_errors.InvalidTodoIDError
+	}
+
+	reminder := &entity.Reminder{
+		TodoID:    in.TodoID,
+		Channel:   in.Channel,
+		Target:    in.Target,
+		Status:    entity.ReminderPending,
+		CreatedAt: uc.Clock.Now(),
+	}
+	switch {
+	case in.At != nil && in.Before != "":
+		return dto.CreateReminderResponse{}, fmt.Errorf("%w: set either at or before", uc_errors.InvalidReminderError)
+	case in.At != nil:
+		reminder.At = in.At.UTC()
+	case in.Before != "":
+		before, err := time.ParseDuration(in.Before)
+		if err != nil || before < 0 {
+			return dto.CreateReminderResponse{}, fmt.Errorf("%w: before must be a non-negative duration such as 15m", uc_errors.InvalidReminderError)
+		}
+		reminder.Before = before
+	default:
+		return dto.CreateReminderResponse{}, fmt.Errorf("%w: at or before is required", uc_errors.InvalidReminderError)
+	}
+
+	notifier, ok := uc.Notifiers[in.Channel]
+	if !ok {
+		return dto.CreateReminderResponse{}, fmt.Errorf("%w: unknown channel %q", uc_errors.InvalidReminderError, in.Channel)
+	}
+	if err := notifier.CheckTarget(in.Target); err != nil {
+		return dto.CreateReminderResponse{}, fmt.Errorf("%w: %v", uc_errors.InvalidReminderError, err)
+	}
+
+	todo, err := uc.Storage.GetTodo(ctx, in.TodoID)
+	if err != nil {
+		if !errors.Is(err, uc_errors.TodoNotFoundError) {
+			return dto.CreateReminderResponse{}, uc_errors.Wrap(uc_errors.CreateReminderError, err)
+		}
+		return dto.CreateReminderResponse{}, err
+	}
+
+	if err := uc.Reminders.CreateReminder(ctx, reminder); err != nil {
+		return dto.CreateReminderResponse{}, uc_errors.Wrap(uc_errors.CreateReminderError, err)
+	}
+
+	return dto.CreateReminderResponse{Reminder: mappers.MapDomainReminderToReminderDTO(reminder, todo)}, nil
+}
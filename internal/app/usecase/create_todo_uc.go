@@ -6,23 +6,68 @@ import (
 	"todo-api/internal/app/dto"
 	"todo-api/internal/app/mappers"
 	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
 	"todo-api/internal/domain/port"
 )
 
 type CreateTodoUC struct {
-	Storage port.DataStorage
+	Storage   port.DataStorage
+	Projects  port.ProjectStorage
+	Clock     port.Clock
+	Publisher port.EventPublisher
 }
 
-func NewCreateTodoUC(storage port.DataStorage) *CreateTodoUC {
-	return &CreateTodoUC{Storage: storage}
+func NewCreateTodoUC(
+	storage port.DataStorage,
+	projects port.ProjectStorage,
+	clock port.Clock,
+	publisher port.EventPublisher,
+) *CreateTodoUC {
+	return &CreateTodoUC{Storage: storage, Projects: projects, Clock: clock, Publisher: publisher}
 }
 
 func (uc *CreateTodoUC) Execute(ctx context.Context, in dto.CreateTodo) (dto.CreateTodoResponse, error) {
 	if in.Title == "" {
 		return dto.CreateTodoResponse{ID: in.ID}, uc_errors.EmptyTitleError
 	}
+	if err := validateSchedule(in.Todo); err != nil {
+		return dto.CreateTodoResponse{ID: in.ID}, err
+	}
+	if err := validatePriority(in.Todo); err != nil {
+		return dto.CreateTodoResponse{ID: in.ID}, err
+	}
+	if err := normalizeRecurrence(&in.Todo); err != nil {
+		return dto.CreateTodoResponse{ID: in.ID}, err
+	}
+	tags, err := normalizeTodoTags(in.Tags)
+	if err != nil {
+		return dto.CreateTodoResponse{ID: in.ID}, err
+	}
+	in.Tags = tags
+	if err := checkProjectRef(ctx, uc.Projects, in.ProjectID); err != nil {
+		if !errors.Is(err, uc_errors.UnknownProjectError) {
+			return dto.CreateTodoResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.CreateTodoError, err)
+		}
+		return dto.CreateTodoResponse{ID: in.ID}, err
+	}
+	if err := checkParentRef(ctx, uc.Storage, 0, in.ParentID); err != nil {
+		if !errors.Is(err, uc_errors.UnknownParentError) {
+			return dto.CreateTodoResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.CreateTodoError, err)
+		}
+		return dto.CreateTodoResponse{ID: in.ID}, err
+	}
+
+	in.BlockedBy = normalizeBlockers(in.BlockedBy)
 
 	mappedIn := mappers.MapTodoDTOToDomainTodo(in.Todo)
+	if err := checkBlockers(ctx, uc.Storage, &entity.Todo{}, mappedIn); err != nil {
+		if !isBlockerError(err) {
+			return dto.CreateTodoResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.CreateTodoError, err)
+		}
+		return dto.CreateTodoResponse{ID: in.ID}, err
+	}
+	stamp(mappedIn, nil, uc.Clock.Now())
+
 	if err := uc.Storage.CreateTodo(ctx, mappedIn); err != nil {
 		if !errors.Is(err, uc_errors.TodoAlreadyExistsError) {
 			return dto.CreateTodoResponse{ID: mappedIn.ID}, uc_errors.Wrap(uc_errors.CreateTodoError, err)
@@ -30,5 +75,7 @@ func (uc *CreateTodoUC) Execute(ctx context.Context, in dto.CreateTodo) (dto.Cre
 		return dto.CreateTodoResponse{ID: mappedIn.ID}, err
 	}
 
-	return dto.CreateTodoResponse{ID: mappedIn.ID}, nil
+	publishWrite(ctx, uc.Publisher, nil, mappedIn)
+
+	return dto.CreateTodoResponse{ID: mappedIn.ID, Version: mappedIn.Version}, nil
 }
@@ -4,15 +4,17 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 	"todo-api/internal/adapter/out/storage"
 	"todo-api/internal/app/dto"
 	"todo-api/internal/app/uc_errors"
 	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/entity"
 )
 
 func TestCreateTodoUC(t *testing.T) {
 	store := storage.NewDataStorage()
-	uc := usecase.NewCreateTodoUC(store)
+	uc := usecase.NewCreateTodoUC(store, store, newFakeClock(), nil)
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -49,6 +51,57 @@ func TestCreateTodoUC(t *testing.T) {
 		}
 	})
 
+	t.Run("Success - schedule", func(t *testing.T) {
+		due := time.Date(2026, 5, 1, 18, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+		start := due.Add(-24 * time.Hour)
+		in := dto.CreateTodo{Todo: dto.Todo{Title: "Pay taxes", DueAt: &due, StartAt: &start}}
+
+		result, err := uc.Execute(ctx, in)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		created, _ := store.GetTodo(ctx, result.ID)
+		if !created.DueAt.Equal(due) || created.DueAt.Location() != time.UTC {
+			t.Errorf("expected due %v in UTC, got %v", due, created.DueAt)
+		}
+	})
+
+	t.Run("Error - start after due", func(t *testing.T) {
+		due := time.Date(2026, 5, 1, 18, 0, 0, 0, time.UTC)
+		start := due.Add(time.Minute)
+		in := dto.CreateTodo{Todo: dto.Todo{Title: "Pay taxes", DueAt: &due, StartAt: &start}}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.InvalidScheduleError) {
+			t.Errorf("expected InvalidScheduleError, got %v", err)
+		}
+	})
+
+	t.Run("Success - priority", func(t *testing.T) {
+		result, err := uc.Execute(ctx, dto.CreateTodo{Todo: dto.Todo{Title: "Call mom", Priority: "urgent"}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		created, _ := store.GetTodo(ctx, result.ID)
+		if created.Priority != entity.PriorityUrgent {
+			t.Errorf("expected urgent, got %v", created.Priority)
+		}
+	})
+
+	t.Run("Error - unknown priority", func(t *testing.T) {
+		in := dto.CreateTodo{Todo: dto.Todo{Title: "Call mom", Priority: "asap"}}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.InvalidPriorityError) {
+			t.Errorf("expected InvalidPriorityError, got %v", err)
+		}
+	})
+
+	t.Run("Error - unknown project", func(t *testing.T) {
+		in := dto.CreateTodo{Todo: dto.Todo{Title: "Call mom", ProjectID: 42}}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.UnknownProjectError) {
+			t.Errorf("expected UnknownProjectError, got %v", err)
+		}
+	})
+
 	t.Run("Error - duplicate id", func(t *testing.T) {
 		testID := int64(200)
 		in := dto.CreateTodo{
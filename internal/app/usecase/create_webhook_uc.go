@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+)
+
+type CreateWebhookUC struct {
+	Webhooks port.WebhookStorage
+	Clock    port.Clock
+}
+
+func NewCreateWebhookUC(webhooks port.WebhookStorage, clock port.Clock) *CreateWebhookUC {
+	return &CreateWebhookUC{Webhooks: webhooks, Clock: clock}
+}
+
+// Execute subscribes a URL to todo events and returns the secret the
+// deliveries are signed with.
+func (uc *CreateWebhookUC) Execute(ctx context.Context, in dto.CreateWebhook) (dto.CreateWebhookResponse, error) {
+	events, err := checkWebhook(in.Webhook)
+	if err != nil {
+		return dto.CreateWebhookResponse{}, err
+	}
+
+	secret := in.Secret
+	if secret == "" {
+		if secret, err = newWebhookSecret(); err != nil {
+			return dto.CreateWebhookResponse{}, uc_errors.Wrap(uc_errors.CreateWebhookError, err)
+		}
+	}
+
+	now := uc.Clock.Now()
+	webhook := &entity.Webhook{
+		URL:       in.URL,
+		Secret:    secret,
+		Events:    events,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.Webhooks.CreateWebhook(ctx, webhook); err != nil {
+		return dto.CreateWebhookResponse{}, uc_errors.Wrap(uc_errors.CreateWebhookError, err)
+	}
+
+	out := dto.CreateWebhookResponse{Webhook: mappers.MapDomainWebhookToWebhookDTO(webhook)}
+	out.Secret = webhook.Secret
+	return out, nil
+}
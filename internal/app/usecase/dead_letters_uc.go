@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+type GetDeadLettersUC struct {
+	Webhooks port.WebhookStorage
+}
+
+func NewGetDeadLettersUC(webhooks port.WebhookStorage) *GetDeadLettersUC {
+	return &GetDeadLettersUC{Webhooks: webhooks}
+}
+
+func (uc *GetDeadLettersUC) Execute(ctx context.Context) (dto.GetDeadLettersResponse, error) {
+	letters, err := uc.Webhooks.ListDeadLetters(ctx)
+	if err != nil {
+		return dto.GetDeadLettersResponse{}, uc_errors.Wrap(uc_errors.GetDeadLettersError, err)
+	}
+
+	response := dto.GetDeadLettersResponse{DeadLetters: make([]dto.DeadLetter, len(letters))}
+	for i, letter := range letters {
+		response.DeadLetters[i] = mappers.MapDomainDeadLetterToDeadLetterDTO(letter)
+	}
+	return response, nil
+}
+
+type ReplayDeadLetterUC struct {
+	Webhooks port.WebhookStorage
+	Sender   port.WebhookSender
+}
+
+func NewReplayDeadLetterUC(webhooks port.WebhookStorage, sender port.WebhookSender) *ReplayDeadLetterUC {
+	return &ReplayDeadLetterUC{Webhooks: webhooks, Sender: sender}
+}
+
+// Execute hands the dead letter back to the sender and removes it from the
+// list; the delivery itself happens in the background.
+func (uc *ReplayDeadLetterUC) Execute(ctx context.Context, in dto.ReplayDeadLetter) (dto.ReplayDeadLetterResponse, error) {
+	if in.ID <= 0 {
+		return dto.ReplayDeadLetterResponse{ID: in.ID}, uc_errors.InvalidDeadLetterIDError
+	}
+
+	letter, err := uc.Webhooks.GetDeadLetter(ctx, in.ID)
+	if err != nil {
+		if !errors.Is(err, uc_errors.DeadLetterNotFoundError) {
+			return dto.ReplayDeadLetterResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.ReplayDeadLetterError, err)
+		}
+		return dto.ReplayDeadLetterResponse{ID: in.ID}, err
+	}
+	webhook, err := uc.Webhooks.GetWebhook(ctx, letter.WebhookID)
+	if err != nil {
+		if !errors.Is(err, uc_errors.WebhookNotFoundError) {
+			return dto.ReplayDeadLetterResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.ReplayDeadLetterError, err)
+		}
+		return dto.ReplayDeadLetterResponse{ID: in.ID}, err
+	}
+
+	if err := uc.Sender.Replay(ctx, webhook, letter); err != nil {
+		return dto.ReplayDeadLetterResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.ReplayDeadLetterError, err)
+	}
+	if err := ignoreMissingDeadLetter(uc.Webhooks.DeleteDeadLetter(ctx, in.ID)); err != nil {
+		return dto.ReplayDeadLetterResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.ReplayDeadLetterError, err)
+	}
+
+	return dto.ReplayDeadLetterResponse{ID: in.ID, Replayed: true}, nil
+}
+
+type DeleteDeadLetterUC struct {
+	Webhooks port.WebhookStorage
+}
+
+func NewDeleteDeadLetterUC(webhooks port.WebhookStorage) *DeleteDeadLetterUC {
+	return &DeleteDeadLetterUC{Webhooks: webhooks}
+}
+
+func (uc *DeleteDeadLetterUC) Execute(ctx context.Context, in dto.DeleteDeadLetter) (dto.DeleteDeadLetterResponse, error) {
+	if in.ID <= 0 {
+		return dto.DeleteDeadLetterResponse{ID: in.ID}, uc_errors.InvalidDeadLetterIDError
+	}
+
+	if err := uc.Webhooks.DeleteDeadLetter(ctx, in.ID); err != nil {
+		if !errors.Is(err, uc_errors.DeadLetterNotFoundError) {
+			return dto.DeleteDeadLetterResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.DeleteDeadLetterError, err)
+		}
+		return dto.DeleteDeadLetterResponse{ID: in.ID}, err
+	}
+
+	return dto.DeleteDeadLetterResponse{ID: in.ID, Deleted: true}, nil
+}
+
+// ignoreMissingDeadLetter treats a dead letter removed concurrently as gone.
+func ignoreMissingDeadLetter(err error) error {
+	if errors.Is(err, uc_errors.DeadLetterNotFoundError) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+	"todo-api/internal/domain/query"
+)
+
+// ProjectDeletePolicy decides what happens to the todos of a deleted project.
+type ProjectDeletePolicy string
+
+const (
+	// ProjectDeleteRefuse fails with uc_errors.ProjectNotEmptyError while the
+	// project has todos.
+	ProjectDeleteRefuse ProjectDeletePolicy = "refuse"
+	// ProjectDeleteCascade deletes the todos with the project.
+	ProjectDeleteCascade ProjectDeletePolicy = "cascade"
+	// ProjectDeleteOrphan keeps the todos outside any project.
+	ProjectDeleteOrphan ProjectDeletePolicy = "orphan"
+)
+
+func ParseProjectDeletePolicy(s string) (ProjectDeletePolicy, error) {
+	switch p := ProjectDeletePolicy(s); p {
+	case ProjectDeleteRefuse, ProjectDeleteCascade, ProjectDeleteOrphan:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown project delete policy %q", s)
+	}
+}
+
+type DeleteProjectUC struct {
+	Projects  port.ProjectStorage
+	Todos     port.DataStorage
+	Clock     port.Clock
+	Policy    ProjectDeletePolicy
+	Publisher port.EventPublisher
+}
+
+func NewDeleteProjectUC(
+	projects port.ProjectStorage,
+	todos port.DataStorage,
+	clock port.Clock,
+	publisher port.EventPublisher,
+	policy ProjectDeletePolicy,
+) *DeleteProjectUC {
+	return &DeleteProjectUC{Projects: projects, Todos: todos, Clock: clock, Policy: policy, Publisher: publisher}
+}
+
+// Execute applies the policy to the project's todos one by one and then
+// deletes the project. A failure part way leaves the project in place with
+// fewer todos; repeating the request finishes the job.
+func (uc *DeleteProjectUC) Execute(ctx context.Context, in dto.DeleteProject) (dto.DeleteProjectResponse, error) {
+	failed := dto.DeleteProjectResponse{ID: in.ID}
+	if in.ID <= 0 {
+		return failed, uc_errors.InvalidProjectIDError
+	}
+
+	project, err := uc.Projects.GetProject(ctx, in.ID)
+	if err != nil {
+		return failed, uc.wrap(err)
+	}
+	if in.Version != 0 && project.Version != in.Version {
+		return failed, uc_errors.ProjectVersionConflictError
+	}
+
+	todos, err := uc.Todos.QueryTodos(ctx, query.Query{
+		Filter: query.Predicate{Field: query.FieldProjectID, Op: query.OpEq, Value: in.ID},
+	})
+	if err != nil {
+		return failed, uc.wrap(err)
+	}
+
+	switch uc.Policy {
+	case ProjectDeleteCascade:
+		if err := uc.cascade(ctx, in.ID, todos); err != nil {
+			return failed, err
+		}
+	case ProjectDeleteOrphan:
+		for _, todo := range todos {
+			_, err := modifyTodo(ctx, uc.Todos, uc.Clock, uc.Publisher, todo.ID, 0, func(todo *entity.Todo) (bool, error) {
+				if todo.ProjectID != in.ID {
+					return false, nil
+				}
+				todo.ProjectID = 0
+				return true, nil
+			})
+			if err != nil && !errors.Is(err, uc_errors.TodoNotFoundError) {
+				return failed, uc.wrap(err)
+			}
+		}
+	default:
+		if len(todos) > 0 {
+			return failed, uc_errors.ProjectNotEmptyError
+		}
+	}
+
+	if err := uc.Projects.DeleteProject(ctx, in.ID, project.Version); err != nil {
+		return failed, uc.wrap(err)
+	}
+
+	return dto.DeleteProjectResponse{ID: in.ID, Deleted: true, Todos: len(todos)}, nil
+}
+
+// cascade deletes the todos of project id through DeleteTodoUC, subtasks
+// before their parents. It refuses with uc_errors.TodoHasSubtasksError,
+// before deleting anything, when a todo has subtasks outside the project.
+func (uc *DeleteProjectUC) cascade(ctx context.Context, id int64, todos []*entity.Todo) error {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	filter := make(query.Or, len(todos))
+	for i, todo := range todos {
+		filter[i] = query.Predicate{Field: query.FieldParentID, Op: query.OpEq, Value: todo.ID}
+	}
+	children, err := uc.Todos.QueryTodos(ctx, query.Query{Filter: filter})
+	if err != nil {
+		return uc.wrap(err)
+	}
+	open := make(map[int64]int, len(todos))
+	for _, child := range children {
+		if child.ProjectID != id {
+			return uc_errors.TodoHasSubtasksError
+		}
+		open[child.ParentID]++
+	}
+
+	parents := make(map[int64]int64, len(todos))
+	for _, todo := range todos {
+		parents[todo.ID] = todo.ParentID
+	}
+
+	remove := NewDeleteTodoUC(uc.Todos, uc.Publisher)
+	for len(parents) > 0 {
+		deleted := 0
+		for _, todo := range todos {
+			parent, pending := parents[todo.ID]
+			if !pending || open[todo.ID] > 0 {
+				continue
+			}
+			_, err := remove.Execute(ctx, dto.DeleteTodo{ID: todo.ID})
+			if err != nil && !errors.Is(err, uc_errors.TodoNotFoundError) {
+				return uc.wrap(err)
+			}
+			delete(parents, todo.ID)
+			open[parent]--
+			deleted++
+		}
+		if deleted == 0 {
+			// Only a parent cycle, which writes refuse, can get here.
+			return uc_errors.ParentCycleError
+		}
+	}
+	return nil
+}
+
+func (uc *DeleteProjectUC) wrap(err error) error {
+	if errors.Is(err, uc_errors.ProjectNotFoundError) ||
+		errors.Is(err, uc_errors.ProjectVersionConflictError) ||
+		errors.Is(err, uc_errors.TodoHasSubtasksError) {
+		return err
+	}
+	return uc_errors.Wrap(uc_errors.DeleteProjectError, err)
+}
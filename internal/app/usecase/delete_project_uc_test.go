@@ -0,0 +1,113 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/entity"
+)
+
+func TestDeleteProjectUC(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(policy usecase.ProjectDeletePolicy) (*storage.DataStorage, *usecase.DeleteProjectUC, int64) {
+		store := storage.NewDataStorage()
+		project := entity.Project{Name: "Home"}
+		_ = store.CreateProject(ctx, &project)
+		_ = store.CreateTodo(ctx, &entity.Todo{Title: "Sweep", ProjectID: project.ID})
+		_ = store.CreateTodo(ctx, &entity.Todo{Title: "Buy milk"})
+		return store, usecase.NewDeleteProjectUC(store, store, newFakeClock(), nil, policy), project.ID
+	}
+
+	t.Run("Error - refuse keeps a project with todos", func(t *testing.T) {
+		store, uc, id := setup(usecase.ProjectDeleteRefuse)
+
+		if _, err := uc.Execute(ctx, dto.DeleteProject{ID: id}); !errors.Is(err, uc_errors.ProjectNotEmptyError) {
+			t.Fatalf("expected ProjectNotEmptyError, got %v", err)
+		}
+		if _, err := store.GetProject(ctx, id); err != nil {
+			t.Errorf("expected the project to stay, got %v", err)
+		}
+	})
+
+	t.Run("Success - cascade", func(t *testing.T) {
+		store, uc, id := setup(usecase.ProjectDeleteCascade)
+
+		result, err := uc.Execute(ctx, dto.DeleteProject{ID: id})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !result.Deleted || result.Todos != 1 {
+			t.Errorf("expected 1 todo deleted, got %+v", result)
+		}
+		if _, err := store.GetTodo(ctx, 1); !errors.Is(err, uc_errors.TodoNotFoundError) {
+			t.Errorf("expected TodoNotFoundError, got %v", err)
+		}
+		if _, err := store.GetTodo(ctx, 2); err != nil {
+			t.Errorf("expected the other todo to stay, got %v", err)
+		}
+	})
+
+	t.Run("Success - cascade deletes subtasks first", func(t *testing.T) {
+		store, uc, id := setup(usecase.ProjectDeleteCascade)
+		_ = store.CreateTodo(ctx, &entity.Todo{Title: "Mop", ProjectID: id, ParentID: 1})
+		_ = store.CreateTodo(ctx, &entity.Todo{Title: "Rinse", ProjectID: id, ParentID: 3})
+
+		result, err := uc.Execute(ctx, dto.DeleteProject{ID: id})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result.Todos != 3 {
+			t.Errorf("expected 3 todos deleted, got %+v", result)
+		}
+		if list, _ := store.GetTodoList(ctx, 0, 0); len(list) != 1 || list[0].ID != 2 {
+			t.Errorf("expected only the todo outside the project, got %v", list)
+		}
+	})
+
+	t.Run("Error - cascade with subtasks outside the project", func(t *testing.T) {
+		store, uc, id := setup(usecase.ProjectDeleteCascade)
+		_ = store.CreateTodo(ctx, &entity.Todo{Title: "Mop", ProjectID: id, ParentID: 1})
+		_ = store.CreateTodo(ctx, &entity.Todo{Title: "Buy a mop", ParentID: 3})
+
+		if _, err := uc.Execute(ctx, dto.DeleteProject{ID: id}); !errors.Is(err, uc_errors.TodoHasSubtasksError) {
+			t.Fatalf("expected TodoHasSubtasksError, got %v", err)
+		}
+		if list, _ := store.GetTodoList(ctx, 0, 0); len(list) != 4 {
+			t.Errorf("expected nothing deleted, got %v", list)
+		}
+		if _, err := store.GetProject(ctx, id); err != nil {
+			t.Errorf("expected the project to stay, got %v", err)
+		}
+	})
+
+	t.Run("Success - orphan", func(t *testing.T) {
+		store, uc, id := setup(usecase.ProjectDeleteOrphan)
+
+		if _, err := uc.Execute(ctx, dto.DeleteProject{ID: id}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		todo, err := store.GetTodo(ctx, 1)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if todo.ProjectID != 0 || todo.Version != 2 {
+			t.Errorf("expected todo outside any project at version 2, got %+v", todo)
+		}
+		if _, err := store.GetProject(ctx, id); !errors.Is(err, uc_errors.ProjectNotFoundError) {
+			t.Errorf("expected ProjectNotFoundError, got %v", err)
+		}
+	})
+
+	t.Run("Error - version conflict", func(t *testing.T) {
+		_, uc, id := setup(usecase.ProjectDeleteCascade)
+
+		if _, err := uc.Execute(ctx, dto.DeleteProject{ID: id, Version: 7}); !errors.Is(err, uc_errors.ProjectVersionConflictError) {
+			t.Errorf("expected ProjectVersionConflictError, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+type DeleteReminderUC struct {
+	Reminders port.ReminderStorage
+}
+
+func NewDeleteReminderUC(reminders port.ReminderStorage) *DeleteReminderUC {
+	return &DeleteReminderUC{Reminders: reminders}
+}
+
+// Execute deletes a reminder of todo in.TodoID; a reminder of another todo
+// is reported as not found.
+func (uc *DeleteReminderUC) Execute(ctx context.Context, in dto.DeleteReminder) (dto.DeleteReminderResponse, error) {
+	if in.TodoID <= 0 {
+		return dto.DeleteReminderResponse{ID: in.ID}, uc_errors.InvalidTodoIDError
+	}
+	if in.ID <= 0 {
+		return dto.DeleteReminderResponse{ID: in.ID}, uc_errors.InvalidReminderIDError
+	}
+
+	reminder, err := uc.Reminders.GetReminder(ctx, in.ID)
+	if err == nil && reminder.TodoID != in.TodoID {
+		err = uc_errors.ReminderNotFoundError
+	}
+	if err == nil {
+		err = uc.Reminders.DeleteReminder(ctx, in.ID)
+	}
+	if err != nil {
+		if !errors.Is(err, uc_errors.ReminderNotFoundError) {
+			return dto.DeleteReminderResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.DeleteReminderError, err)
+		}
+		return dto.DeleteReminderResponse{ID: in.ID}, err
+	}
+
+	return dto.DeleteReminderResponse{ID: in.ID, Deleted: true}, nil
+}
@@ -6,14 +6,16 @@ import (
 	"todo-api/internal/app/dto"
 	"todo-api/internal/app/uc_errors"
 	"todo-api/internal/domain/port"
+	"todo-api/internal/domain/query"
 )
 
 type DeleteTodoUC struct {
-	Storage port.DataStorage
+	Storage   port.DataStorage
+	Publisher port.EventPublisher
 }
 
-func NewDeleteTodoUC(storage port.DataStorage) *DeleteTodoUC {
-	return &DeleteTodoUC{Storage: storage}
+func NewDeleteTodoUC(storage port.DataStorage, publisher port.EventPublisher) *DeleteTodoUC {
+	return &DeleteTodoUC{Storage: storage, Publisher: publisher}
 }
 
 func (uc *DeleteTodoUC) Execute(ctx context.Context, in dto.DeleteTodo) (dto.DeleteTodoResponse, error) {
@@ -21,13 +23,33 @@ func (uc *DeleteTodoUC) Execute(ctx context.Context, in dto.DeleteTodo) (dto.Del
 		return dto.DeleteTodoResponse{ID: in.ID}, uc_errors.InvalidTodoIDError
 	}
 
-	if err := uc.Storage.DeleteTodo(ctx, in.ID); err != nil {
+	subtasks, err := uc.Storage.CountTodos(ctx, query.Predicate{Field: query.FieldParentID, Op: query.OpEq, Value: in.ID})
+	if err != nil {
+		return dto.DeleteTodoResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.DeleteTodoError, err)
+	}
+	if subtasks > 0 {
+		return dto.DeleteTodoResponse{ID: in.ID}, uc_errors.TodoHasSubtasksError
+	}
+
+	// The todo is read for the event; the write below still checks the
+	// version against the stored one.
+	todo, err := uc.Storage.GetTodo(ctx, in.ID)
+	if err != nil {
 		if !errors.Is(err, uc_errors.TodoNotFoundError) {
 			return dto.DeleteTodoResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.DeleteTodoError, err)
 		}
 		return dto.DeleteTodoResponse{ID: in.ID}, err
 	}
 
+	if err := uc.Storage.DeleteTodo(ctx, in.ID, in.Version); err != nil {
+		if !errors.Is(err, uc_errors.TodoNotFoundError) && !errors.Is(err, uc_errors.TodoVersionConflictError) {
+			return dto.DeleteTodoResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.DeleteTodoError, err)
+		}
+		return dto.DeleteTodoResponse{ID: in.ID}, err
+	}
+
+	publishDeleted(ctx, uc.Publisher, todo)
+
 	return dto.DeleteTodoResponse{
 		ID:      in.ID,
 		Deleted: true,
@@ -13,7 +13,7 @@ import (
 
 func TestDeleteTodoUC(t *testing.T) {
 	store := storage.NewDataStorage()
-	uc := usecase.NewDeleteTodoUC(store)
+	uc := usecase.NewDeleteTodoUC(store, nil)
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -40,6 +40,16 @@ func TestDeleteTodoUC(t *testing.T) {
 		}
 	})
 
+	t.Run("Error - todo has subtasks", func(t *testing.T) {
+		parent := entity.Todo{Title: "Move out"}
+		_ = store.CreateTodo(ctx, &parent)
+		_ = store.CreateTodo(ctx, &entity.Todo{Title: "Pack books", ParentID: parent.ID})
+
+		if _, err := uc.Execute(ctx, dto.DeleteTodo{ID: parent.ID}); !errors.Is(err, uc_errors.TodoHasSubtasksError) {
+			t.Errorf("expected TodoHasSubtasksError, got %v", err)
+		}
+	})
+
 	t.Run("Error - invalid ID", func(t *testing.T) {
 		in := dto.DeleteTodo{ID: 0}
 		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.InvalidTodoIDError) {
@@ -54,6 +64,16 @@ func TestDeleteTodoUC(t *testing.T) {
 		}
 	})
 
+	t.Run("Error - version conflict", func(t *testing.T) {
+		todo := &entity.Todo{Title: "Wash clothes"}
+		_ = store.CreateTodo(ctx, todo)
+
+		in := dto.DeleteTodo{ID: todo.ID, Version: todo.Version + 1}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.TodoVersionConflictError) {
+			t.Errorf("expected TodoVersionConflictError, got %v", err)
+		}
+	})
+
 	t.Run("Error - canceled context", func(t *testing.T) {
 		cancelCtx, cancel := context.WithCancel(context.Background())
 		cancel()
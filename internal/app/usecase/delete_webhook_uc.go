@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+type DeleteWebhookUC struct {
+	Webhooks port.WebhookStorage
+}
+
+func NewDeleteWebhookUC(webhooks port.WebhookStorage) *DeleteWebhookUC {
+	return &DeleteWebhookUC{Webhooks: webhooks}
+}
+
+// Execute drops the webhook together with its dead letters, which could not
+// be replayed without it.
+func (uc *DeleteWebhookUC) Execute(ctx context.Context, in dto.DeleteWebhook) (dto.DeleteWebhookResponse, error) {
+	if in.ID <= 0 {
+		return dto.DeleteWebhookResponse{ID: in.ID}, uc_errors.InvalidWebhookIDError
+	}
+
+	if err := uc.Webhooks.DeleteWebhook(ctx, in.ID); err != nil {
+		if !errors.Is(err, uc_errors.WebhookNotFoundError) {
+			return dto.DeleteWebhookResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.DeleteWebhookError, err)
+		}
+		return dto.DeleteWebhookResponse{ID: in.ID}, err
+	}
+
+	letters, err := uc.Webhooks.ListDeadLetters(ctx)
+	if err != nil {
+		return dto.DeleteWebhookResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.DeleteWebhookError, err)
+	}
+	for _, letter := range letters {
+		if letter.WebhookID != in.ID {
+			continue
+		}
+		if err := ignoreMissingDeadLetter(uc.Webhooks.DeleteDeadLetter(ctx, letter.ID)); err != nil {
+			return dto.DeleteWebhookResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.DeleteWebhookError, err)
+		}
+	}
+
+	return dto.DeleteWebhookResponse{ID: in.ID, Deleted: true}, nil
+}
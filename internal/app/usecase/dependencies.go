@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+)
+
+// normalizeBlockers sorts and dedupes blocker ids; an empty list becomes nil.
+func normalizeBlockers(ids []int64) []int64 {
+	if len(ids) == 0 {
+		return nil
+	}
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// checkBlockers guards the dependency graph when current is about to be
+// replaced by next: every added blocker must exist and must not depend on
+// the todo, and a todo cannot be completed while a blocker is open. Blockers
+// that are already in place are not checked again, so a todo whose blocker
+// was deleted can still be edited.
+func checkBlockers(ctx context.Context, storage port.DataStorage, current, next *entity.Todo) error {
+	for _, id := range next.BlockedBy {
+		if slices.Contains(current.BlockedBy, id) {
+			continue
+		}
+		if id <= 0 {
+			return uc_errors.UnknownBlockerError
+		}
+		if id == current.ID {
+			return uc_errors.DependencyCycleError
+		}
+
+		cycle, err := dependsOn(ctx, storage, id, current.ID)
+		if errors.Is(err, uc_errors.TodoNotFoundError) {
+			return uc_errors.UnknownBlockerError
+		}
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return uc_errors.DependencyCycleError
+		}
+	}
+
+	if next.Completed && !current.Completed {
+		open, err := isBlocked(ctx, storage, next.BlockedBy)
+		if err != nil {
+			return err
+		}
+		if open {
+			return uc_errors.BlockedTodoError
+		}
+	}
+	return nil
+}
+
+func isBlockerError(err error) bool {
+	return errors.Is(err, uc_errors.UnknownBlockerError) ||
+		errors.Is(err, uc_errors.DependencyCycleError) ||
+		errors.Is(err, uc_errors.BlockedTodoError)
+}
+
+// dependsOn reports whether todo id is blocked by target, directly or
+// through other blockers. It fails with uc_errors.TodoNotFoundError only when
+// id itself does not exist.
+func dependsOn(ctx context.Context, storage port.DataStorage, id, target int64) (bool, error) {
+	if target == 0 {
+		if _, err := storage.GetTodo(ctx, id); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	seen := map[int64]bool{id: true}
+	stack := []int64{id}
+	for len(stack) > 0 {
+		next := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		todo, err := storage.GetTodo(ctx, next)
+		if errors.Is(err, uc_errors.TodoNotFoundError) && next != id {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+
+		for _, blocker := range todo.BlockedBy {
+			if blocker == target {
+				return true, nil
+			}
+			if !seen[blocker] {
+				seen[blocker] = true
+				stack = append(stack, blocker)
+			}
+		}
+	}
+	return false, nil
+}
+
+// isBlocked reports whether any of the blockers exists and is still open.
+func isBlocked(ctx context.Context, storage port.DataStorage, blockers []int64) (bool, error) {
+	for _, id := range blockers {
+		todo, err := storage.GetTodo(ctx, id)
+		if errors.Is(err, uc_errors.TodoNotFoundError) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		if !todo.Completed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/event"
+	"todo-api/internal/domain/port"
+)
+
+// The publish helpers are only called once the storage write they describe
+// has returned without error, so that no subscriber hears of a change that
+// was not stored. A nil publisher drops the events.
+
+// publishWrite publishes a stored write of after; before is nil for a
+// created todo.
+func publishWrite(ctx context.Context, publisher port.EventPublisher, before, after *entity.Todo) {
+	if publisher == nil {
+		return
+	}
+	if before == nil {
+		publisher.Publish(ctx, event.TodoCreated{Todo: *after})
+		return
+	}
+	events := []event.Event{event.TodoUpdated{Before: *before, After: *after}}
+	if after.Completed && !before.Completed {
+		events = append(events, event.TodoCompleted{Todo: *after})
+	}
+	publisher.Publish(ctx, events...)
+}
+
+func publishDeleted(ctx context.Context, publisher port.EventPublisher, todo *entity.Todo) {
+	if publisher != nil {
+		publisher.Publish(ctx, event.TodoDeleted{Todo: *todo})
+	}
+}
@@ -0,0 +1,126 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"slices"
+	"testing"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/eventbus"
+	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/event"
+)
+
+// recordedEvents keeps what the use cases publish.
+type recordedEvents struct {
+	events []event.Event
+}
+
+func (r *recordedEvents) Publish(_ context.Context, events ...event.Event) {
+	r.events = append(r.events, events...)
+}
+
+func (r *recordedEvents) types() []entity.TodoEventType {
+	types := make([]entity.TodoEventType, len(r.events))
+	for i, e := range r.events {
+		types[i] = e.Type()
+	}
+	return types
+}
+
+// failingWrites stores nothing.
+type failingWrites struct {
+	*storage.DataStorage
+}
+
+func (s failingWrites) CreateTodo(context.Context, *entity.Todo) error {
+	return errors.New("disk full")
+}
+
+func (s failingWrites) UpdateTodo(context.Context, *entity.Todo, int64) error {
+	return errors.New("disk full")
+}
+
+func TestTodoEvents(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewDataStorage()
+	clock := newFakeClock()
+	events := &recordedEvents{}
+
+	create := usecase.NewCreateTodoUC(store, store, clock, events)
+	update := usecase.NewUpdateTodoUC(store, store, clock, events, usecase.SubtaskCompleteCascade)
+	remove := usecase.NewDeleteTodoUC(store, events)
+
+	t.Run("Success", func(t *testing.T) {
+		parent, _ := create.Execute(ctx, dto.CreateTodo{Todo: dto.Todo{Title: "Move"}})
+		child, _ := create.Execute(ctx, dto.CreateTodo{Todo: dto.Todo{Title: "Pack", ParentID: parent.ID}})
+
+		if _, err := update.Execute(ctx, dto.UpdateTodo{Todo: dto.Todo{ID: parent.ID, Title: "Move", Completed: true}}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if _, err := remove.Execute(ctx, dto.DeleteTodo{ID: child.ID}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		want := []entity.TodoEventType{
+			entity.TodoEventCreated, entity.TodoEventCreated,
+			entity.TodoEventUpdated, entity.TodoEventCompleted, // the cascade completes the child first
+			entity.TodoEventUpdated, entity.TodoEventCompleted,
+			entity.TodoEventDeleted,
+		}
+		if got := events.types(); !slices.Equal(got, want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+		if events.events[2].Subject().ID != child.ID || events.events[6].Subject().Title != "Pack" {
+			t.Errorf("unexpected event todos %+v", events.events)
+		}
+		updated, ok := events.events[4].(event.TodoUpdated)
+		if !ok || updated.Before.Completed || !updated.After.Completed || updated.After.Version != updated.Before.Version+1 {
+			t.Errorf("expected the parent before and after completing, got %+v", events.events[4])
+		}
+	})
+
+	t.Run("Success - subscribers see the stored change", func(t *testing.T) {
+		bus := eventbus.New(slog.New(slog.NewTextHandler(os.Stdout, nil)), eventbus.Config{})
+		var stored []int64
+		bus.Subscribe("check", eventbus.Sync, func(ctx context.Context, e event.Event) {
+			todo, err := store.GetTodo(ctx, e.Subject().ID)
+			if err == nil && todo.Version == e.Subject().Version {
+				stored = append(stored, todo.ID)
+			}
+		})
+		create := usecase.NewCreateTodoUC(store, store, clock, bus)
+
+		created, err := create.Execute(ctx, dto.CreateTodo{Todo: dto.Todo{Title: "Unpack"}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !slices.Equal(stored, []int64{created.ID}) {
+			t.Errorf("expected the subscriber to find todo %d stored, got %v", created.ID, stored)
+		}
+	})
+
+	t.Run("Error - failed writes publish nothing", func(t *testing.T) {
+		events.events = nil
+		_, _ = update.Execute(ctx, dto.UpdateTodo{Todo: dto.Todo{ID: 1, Title: "Move", Version: 42}})
+		_, _ = remove.Execute(ctx, dto.DeleteTodo{ID: 42})
+
+		broken := failingWrites{store}
+		create := usecase.NewCreateTodoUC(broken, store, clock, events)
+		update := usecase.NewUpdateTodoUC(broken, store, clock, events, usecase.SubtaskCompleteAllow)
+		if _, err := create.Execute(ctx, dto.CreateTodo{Todo: dto.Todo{Title: "Lost"}}); err == nil {
+			t.Errorf("expected an error")
+		}
+		if _, err := update.Execute(ctx, dto.UpdateTodo{Todo: dto.Todo{ID: 1, Title: "Lost"}}); err == nil {
+			t.Errorf("expected an error")
+		}
+
+		if len(events.events) != 0 {
+			t.Errorf("expected no events, got %v", events.types())
+		}
+	})
+}
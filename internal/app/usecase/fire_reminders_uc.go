@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+)
+
+// DefaultReminderAttempts is how many deliveries a reminder gets before it
+// is marked failed.
+const DefaultReminderAttempts = 5
+
+type FireRemindersUC struct {
+	Storage     port.DataStorage
+	Reminders   port.ReminderStorage
+	Clock       port.Clock
+	Notifiers   map[string]port.Notifier
+	MaxAttempts int
+}
+
+func NewFireRemindersUC(
+	storage port.DataStorage,
+	reminders port.ReminderStorage,
+	clock port.Clock,
+	notifiers map[string]port.Notifier,
+	maxAttempts int,
+) *FireRemindersUC {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultReminderAttempts
+	}
+	return &FireRemindersUC{Storage: storage, Reminders: reminders, Clock: clock, Notifiers: notifiers, MaxAttempts: maxAttempts}
+}
+
+// Execute delivers every pending reminder that is due. A failed delivery is
+// recorded and retried on the next call until MaxAttempts is used up.
+// Reminders of deleted todos are dropped and those of completed todos are
+// skipped. One reminder failing to be stored does not stop the others.
+func (uc *FireRemindersUC) Execute(ctx context.Context) (dto.FireRemindersResponse, error) {
+	var out dto.FireRemindersResponse
+
+	reminders, err := uc.Reminders.PendingReminders(ctx)
+	if err != nil {
+		return out, uc_errors.Wrap(uc_errors.FireRemindersError, err)
+	}
+
+	var errs []error
+	for _, reminder := range reminders {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		result, err := uc.fire(ctx, reminder)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("reminder %d: %w", reminder.ID, err))
+			continue
+		}
+		switch result {
+		case delivered:
+			out.Sent++
+		case undelivered:
+			out.Failed++
+		}
+	}
+
+	if len(errs) > 0 {
+		return out, uc_errors.Wrap(uc_errors.FireRemindersError, errors.Join(errs...))
+	}
+	return out, nil
+}
+
+type delivery int
+
+const (
+	notDelivered delivery = iota
+	delivered
+	undelivered
+)
+
+// fire tells whether a delivery was made and whether it succeeded.
+func (uc *FireRemindersUC) fire(ctx context.Context, reminder *entity.Reminder) (delivery, error) {
+	todo, err := uc.Storage.GetTodo(ctx, reminder.TodoID)
+	if errors.Is(err, uc_errors.TodoNotFoundError) {
+		return notDelivered, ignoreMissing(uc.Reminders.DeleteReminder(ctx, reminder.ID))
+	}
+	if err != nil {
+		return notDelivered, err
+	}
+
+	now := uc.Clock.Now()
+	if todo.Completed {
+		reminder.Status = entity.ReminderSkipped
+		return notDelivered, ignoreMissing(uc.Reminders.UpdateReminder(ctx, reminder))
+	}
+	if fireAt, ok := reminder.FireAt(todo); !ok || fireAt.After(now) {
+		return notDelivered, nil
+	}
+
+	err = fmt.Errorf("channel %q is not configured", reminder.Channel)
+	if notifier, ok := uc.Notifiers[reminder.Channel]; ok {
+		err = notifier.Notify(ctx, reminder, todo)
+	}
+
+	attempt := entity.DeliveryAttempt{At: now}
+	result := delivered
+	if err == nil {
+		reminder.Status = entity.ReminderSent
+		reminder.SentAt = now
+	} else {
+		attempt.Error = err.Error()
+		result = undelivered
+		if len(reminder.Attempts)+1 >= uc.MaxAttempts {
+			reminder.Status = entity.ReminderFailed
+		}
+	}
+	reminder.Attempts = append(reminder.Attempts, attempt)
+
+	return result, ignoreMissing(uc.Reminders.UpdateReminder(ctx, reminder))
+}
+
+// ignoreMissing drops uc_errors.ReminderNotFoundError, which only means the
+// reminder was deleted while it was being fired.
+func ignoreMissing(err error) error {
+	if errors.Is(err, uc_errors.ReminderNotFoundError) {
+		return nil
+	}
+	return err
+}
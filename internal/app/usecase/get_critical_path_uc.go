@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+)
+
+type GetCriticalPathUC struct {
+	Storage port.DataStorage
+}
+
+func NewGetCriticalPathUC(storage port.DataStorage) *GetCriticalPathUC {
+	return &GetCriticalPathUC{Storage: storage}
+}
+
+// Execute returns the longest chain of open blockers that ends at the todo,
+// i.e. the todos that have to be done one after another before it. Ties go
+// to the blocker with the lower id.
+func (uc *GetCriticalPathUC) Execute(ctx context.Context, in dto.GetCriticalPath) (dto.GetCriticalPathResponse, error) {
+	if in.ID <= 0 {
+		return dto.GetCriticalPathResponse{}, uc_errors.InvalidTodoIDError
+	}
+
+	todo, err := uc.Storage.GetTodo(ctx, in.ID)
+	if err != nil {
+		if !errors.Is(err, uc_errors.TodoNotFoundError) {
+			return dto.GetCriticalPathResponse{}, uc_errors.Wrap(uc_errors.GetCriticalPathError, err)
+		}
+		return dto.GetCriticalPathResponse{}, err
+	}
+
+	p := &pathFinder{storage: uc.Storage, paths: make(map[int64][]*entity.Todo)}
+	path, err := p.longest(ctx, todo)
+	if err != nil {
+		return dto.GetCriticalPathResponse{}, uc_errors.Wrap(uc_errors.GetCriticalPathError, err)
+	}
+
+	response := dto.GetCriticalPathResponse{Todos: make([]dto.Todo, len(path))}
+	for i, todo := range path {
+		response.Todos[i] = mappers.MapDomainTodoToTodoDTO(todo)
+	}
+	return response, nil
+}
+
+type pathFinder struct {
+	storage port.DataStorage
+	// paths memoizes the longest path ending at each todo; a nil entry marks
+	// a todo whose path is being computed and guards against cycles.
+	paths map[int64][]*entity.Todo
+}
+
+func (p *pathFinder) longest(ctx context.Context, todo *entity.Todo) ([]*entity.Todo, error) {
+	if path, ok := p.paths[todo.ID]; ok {
+		return path, nil
+	}
+	p.paths[todo.ID] = nil
+
+	var best []*entity.Todo
+	for _, id := range todo.BlockedBy {
+		blocker, err := p.storage.GetTodo(ctx, id)
+		if errors.Is(err, uc_errors.TodoNotFoundError) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if blocker.Completed {
+			continue
+		}
+
+		path, err := p.longest(ctx, blocker)
+		if err != nil {
+			return nil, err
+		}
+		if len(path) > len(best) {
+			best = path
+		}
+	}
+
+	path := append(slices.Clip(best), todo)
+	p.paths[todo.ID] = path
+	return path, nil
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+	"todo-api/internal/domain/query"
+)
+
+const MaxNextTodos = 100
+
+type GetNextTodosUC struct {
+	Storage port.DataStorage
+}
+
+func NewGetNextTodosUC(storage port.DataStorage) *GetNextTodosUC {
+	return &GetNextTodosUC{Storage: storage}
+}
+
+// Execute returns the n most pressing incomplete todos: higher priority first,
+// then earlier due date with undated todos last, then the oldest.
+//
+// The query language orders unset dates first, so dated and undated todos are
+// fetched separately, each already in rank order, and merged.
+func (uc *GetNextTodosUC) Execute(ctx context.Context, in dto.GetNextTodos) (dto.GetNextTodosResponse, error) {
+	if in.N < 1 || in.N > MaxNextTodos {
+		return dto.GetNextTodosResponse{}, uc_errors.InvalidNextCountError
+	}
+
+	open := query.Predicate{Field: query.FieldCompleted, Op: query.OpEq, Value: false}
+	dated, err := uc.Storage.QueryTodos(ctx, query.Query{
+		Filter: query.And{open, query.IsSet(query.FieldDueAt)},
+		Sort: []query.SortKey{
+			{Field: query.FieldPriority, Desc: true},
+			{Field: query.FieldDueAt},
+			{Field: query.FieldCreatedAt},
+		},
+		Limit: in.N,
+	})
+	if err != nil {
+		return dto.GetNextTodosResponse{}, uc_errors.Wrap(uc_errors.GetTodoListError, err)
+	}
+	undated, err := uc.Storage.QueryTodos(ctx, query.Query{
+		Filter: query.And{open, query.Not{Filter: query.IsSet(query.FieldDueAt)}},
+		Sort: []query.SortKey{
+			{Field: query.FieldPriority, Desc: true},
+			{Field: query.FieldCreatedAt},
+		},
+		Limit: in.N,
+	})
+	if err != nil {
+		return dto.GetNextTodosResponse{}, uc_errors.Wrap(uc_errors.GetTodoListError, err)
+	}
+
+	ranked := mergeRanked(dated, undated, in.N)
+	todos := make([]dto.Todo, 0, len(ranked))
+	for _, todo := range ranked {
+		todos = append(todos, mappers.MapDomainTodoToTodoDTO(todo))
+	}
+	return dto.GetNextTodosResponse{Todos: todos}, nil
+}
+
+func mergeRanked(a, b []*entity.Todo, n int) []*entity.Todo {
+	out := make([]*entity.Todo, 0, min(n, len(a)+len(b)))
+	for len(out) < n && (len(a) > 0 || len(b) > 0) {
+		if len(b) == 0 || len(a) > 0 && compareNext(a[0], b[0]) <= 0 {
+			out, a = append(out, a[0]), a[1:]
+		} else {
+			out, b = append(out, b[0]), b[1:]
+		}
+	}
+	return out
+}
+
+func compareNext(a, b *entity.Todo) int {
+	if a.Priority != b.Priority {
+		return int(b.Priority - a.Priority)
+	}
+	if ad, bd := a.DueAt.IsZero(), b.DueAt.IsZero(); ad != bd {
+		if ad {
+			return 1
+		}
+		return -1
+	}
+	if c := a.DueAt.Compare(b.DueAt); c != 0 {
+		return c
+	}
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
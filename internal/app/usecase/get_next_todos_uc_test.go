@@ -0,0 +1,71 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/entity"
+)
+
+func TestGetNextTodosUC(t *testing.T) {
+	store := storage.NewDataStorage()
+	uc := usecase.NewGetNextTodosUC(store)
+	ctx := context.Background()
+
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	todos := []entity.Todo{
+		{Title: "low dated", Priority: entity.PriorityLow, DueAt: base.Add(day), CreatedAt: base},
+		{Title: "high undated", Priority: entity.PriorityHigh, CreatedAt: base},
+		{Title: "high later", Priority: entity.PriorityHigh, DueAt: base.Add(3 * day), CreatedAt: base},
+		{Title: "high sooner", Priority: entity.PriorityHigh, DueAt: base.Add(2 * day), CreatedAt: base.Add(time.Hour)},
+		{Title: "urgent done", Priority: entity.PriorityUrgent, Completed: true, CreatedAt: base},
+		{Title: "none newer", CreatedAt: base.Add(time.Hour)},
+		{Title: "none older", CreatedAt: base},
+	}
+	for i := range todos {
+		_ = store.CreateTodo(ctx, &todos[i])
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := uc.Execute(ctx, dto.GetNextTodos{N: 10})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		expected := []string{"high sooner", "high later", "high undated", "low dated", "none older", "none newer"}
+		if len(result.Todos) != len(expected) {
+			t.Fatalf("expected %d todos, got %v", len(expected), result.Todos)
+		}
+		for i, title := range expected {
+			if result.Todos[i].Title != title {
+				t.Errorf("expected %q at %d, got %q", title, i, result.Todos[i].Title)
+			}
+		}
+		if result.Todos[0].Priority != "high" {
+			t.Errorf("expected priority high, got %q", result.Todos[0].Priority)
+		}
+	})
+
+	t.Run("Success - top n", func(t *testing.T) {
+		result, err := uc.Execute(ctx, dto.GetNextTodos{N: 2})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.Todos) != 2 || result.Todos[1].Title != "high later" {
+			t.Errorf("expected the two dated high todos, got %v", result.Todos)
+		}
+	})
+
+	t.Run("Error - invalid n", func(t *testing.T) {
+		for _, n := range []int{0, -1, usecase.MaxNextTodos + 1} {
+			if _, err := uc.Execute(ctx, dto.GetNextTodos{N: n}); !errors.Is(err, uc_errors.InvalidNextCountError) {
+				t.Errorf("n=%d: expected InvalidNextCountError, got %v", n, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+// MaxOccurrences caps one preview, as a series without COUNT or UNTIL never
+// ends.
+const MaxOccurrences = 100
+
+// maxOccurrenceSteps bounds the occurrences walked through to reach from, so
+// that a window far ahead of the series' start cannot make a preview spin.
+const maxOccurrenceSteps = 5000
+
+type GetOccurrencesUC struct {
+	Storage port.DataStorage
+}
+
+func NewGetOccurrencesUC(storage port.DataStorage) *GetOccurrencesUC {
+	return &GetOccurrencesUC{Storage: storage}
+}
+
+// Execute previews when a todo falls due from its own due date on. A one-off
+// todo has one occurrence, and none without a due date.
+func (uc *GetOccurrencesUC) Execute(ctx context.Context, in dto.GetOccurrences) (dto.GetOccurrencesResponse, error) {
+	if in.ID <= 0 {
+		return dto.GetOccurrencesResponse{}, uc_errors.InvalidTodoIDError
+	}
+	if !in.To.IsZero() && in.To.Before(in.From) {
+		return dto.GetOccurrencesResponse{}, fmt.Errorf("%w: to must not be before from", uc_errors.InvalidRangeError)
+	}
+
+	todo, err := uc.Storage.GetTodo(ctx, in.ID)
+	if err != nil {
+		if !errors.Is(err, uc_errors.TodoNotFoundError) {
+			return dto.GetOccurrencesResponse{}, uc_errors.Wrap(uc_errors.GetOccurrencesError, err)
+		}
+		return dto.GetOccurrencesResponse{}, err
+	}
+
+	list, err := occurrences(todo, in.From, in.To, MaxOccurrences)
+	if errors.Is(err, uc_errors.InvalidRangeError) {
+		return dto.GetOccurrencesResponse{}, err
+	}
+	if err != nil {
+		return dto.GetOccurrencesResponse{}, uc_errors.Wrap(uc_errors.GetOccurrencesError, err)
+	}
+	return dto.GetOccurrencesResponse{Occurrences: list}, nil
+}
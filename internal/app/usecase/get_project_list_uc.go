@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+type GetProjectListUC struct {
+	Projects port.ProjectStorage
+}
+
+func NewGetProjectListUC(projects port.ProjectStorage) *GetProjectListUC {
+	return &GetProjectListUC{Projects: projects}
+}
+
+func (uc *GetProjectListUC) Execute(ctx context.Context, in dto.GetProjectList) (dto.GetProjectListResponse, error) {
+	if in.Limit < 0 {
+		return dto.GetProjectListResponse{}, uc_errors.InvalidLimitError
+	}
+	if in.Offset < 0 {
+		return dto.GetProjectListResponse{}, uc_errors.InvalidOffsetError
+	}
+
+	projects, err := uc.Projects.ListProjects(ctx, in.Limit, in.Offset)
+	if err != nil {
+		return dto.GetProjectListResponse{}, uc_errors.Wrap(uc_errors.GetProjectListError, err)
+	}
+	total, err := uc.Projects.CountProjects(ctx)
+	if err != nil {
+		return dto.GetProjectListResponse{}, uc_errors.Wrap(uc_errors.GetProjectListError, err)
+	}
+
+	response := dto.GetProjectListResponse{
+		Projects: make([]dto.Project, len(projects)),
+		Total:    total,
+		Limit:    in.Limit,
+		Offset:   in.Offset,
+	}
+	for i, project := range projects {
+		response.Projects[i] = mappers.MapDomainProjectToProjectDTO(project)
+	}
+	return response, nil
+}
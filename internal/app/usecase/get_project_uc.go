@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+type GetProjectUC struct {
+	Projects port.ProjectStorage
+}
+
+func NewGetProjectUC(projects port.ProjectStorage) *GetProjectUC {
+	return &GetProjectUC{Projects: projects}
+}
+
+func (uc *GetProjectUC) Execute(ctx context.Context, in dto.GetProject) (dto.GetProjectResponse, error) {
+	if in.ID <= 0 {
+		return dto.GetProjectResponse{Project: dto.Project{ID: in.ID}}, uc_errors.InvalidProjectIDError
+	}
+
+	project, err := uc.Projects.GetProject(ctx, in.ID)
+	if err != nil {
+		if !errors.Is(err, uc_errors.ProjectNotFoundError) {
+			return dto.GetProjectResponse{Project: dto.Project{ID: in.ID}}, uc_errors.Wrap(uc_errors.GetProjectError, err)
+		}
+		return dto.GetProjectResponse{Project: dto.Project{ID: in.ID}}, err
+	}
+
+	return dto.GetProjectResponse{Project: mappers.MapDomainProjectToProjectDTO(project)}, nil
+}
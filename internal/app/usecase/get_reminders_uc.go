@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+type GetRemindersUC struct {
+	Storage   port.DataStorage
+	Reminders port.ReminderStorage
+}
+
+func NewGetRemindersUC(storage port.DataStorage, reminders port.ReminderStorage) *GetRemindersUC {
+	return &GetRemindersUC{Storage: storage, Reminders: reminders}
+}
+
+func (uc *GetRemindersUC) Execute(ctx context.Context, in dto.GetReminders) (dto.GetRemindersResponse, error) {
+	if in.TodoID <= 0 {
+		return dto.GetRemindersResponse{}, uc_errors.InvalidTodoIDError
+	}
+
+	todo, err := uc.Storage.GetTodo(ctx, in.TodoID)
+	if err != nil {
+		if !errors.Is(err, uc_errors.TodoNotFoundError) {
+			return dto.GetRemindersResponse{}, uc_errors.Wrap(uc_errors.GetRemindersError, err)
+		}
+		return dto.GetRemindersResponse{}, err
+	}
+
+	reminders, err := uc.Reminders.ListReminders(ctx, in.TodoID)
+	if err != nil {
+		return dto.GetRemindersResponse{}, uc_errors.Wrap(uc_errors.GetRemindersError, err)
+	}
+
+	out := dto.GetRemindersResponse{Reminders: make([]dto.Reminder, len(reminders))}
+	for i, reminder := range reminders {
+		out.Reminders[i] = mappers.MapDomainReminderToReminderDTO(reminder, todo)
+	}
+	return out, nil
+}
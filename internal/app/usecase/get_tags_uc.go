@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+type GetTagsUC struct {
+	Storage port.DataStorage
+}
+
+func NewGetTagsUC(storage port.DataStorage) *GetTagsUC {
+	return &GetTagsUC{Storage: storage}
+}
+
+func (uc *GetTagsUC) Execute(ctx context.Context) (dto.GetTagsResponse, error) {
+	tags, err := uc.Storage.ListTags(ctx)
+	if err != nil {
+		return dto.GetTagsResponse{}, uc_errors.Wrap(uc_errors.GetTagsError, err)
+	}
+
+	response := dto.GetTagsResponse{Tags: make([]dto.TagCount, len(tags))}
+	for i, tag := range tags {
+		response.Tags[i] = dto.TagCount{Tag: tag.Tag, Count: tag.Count}
+	}
+	return response, nil
+}
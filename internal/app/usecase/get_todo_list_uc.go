@@ -2,18 +2,26 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"time"
+	"todo-api/internal/app/cursor"
 	"todo-api/internal/app/dto"
 	"todo-api/internal/app/mappers"
 	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
 	"todo-api/internal/domain/port"
+	"todo-api/internal/domain/query"
 )
 
 type GetTodoListUC struct {
 	Storage port.DataStorage
+	Cursors *cursor.Codec
+	Clock   port.Clock
 }
 
-func NewGetTodoListUC(storage port.DataStorage) *GetTodoListUC {
-	return &GetTodoListUC{Storage: storage}
+func NewGetTodoListUC(storage port.DataStorage, cursors *cursor.Codec, clock port.Clock) *GetTodoListUC {
+	return &GetTodoListUC{Storage: storage, Cursors: cursors, Clock: clock}
 }
 
 func (uc *GetTodoListUC) Execute(ctx context.Context, in dto.GetTodoList) (dto.GetTodoListResponse, error) {
@@ -24,10 +32,173 @@ func (uc *GetTodoListUC) Execute(ctx context.Context, in dto.GetTodoList) (dto.G
 		return dto.GetTodoListResponse{}, uc_errors.InvalidOffsetError
 	}
 
-	todos, err := uc.Storage.GetTodoList(ctx, in.Limit, in.Offset)
+	// Cursors pin a position by the sort values of an item, so the sort has
+	// to end with a unique key.
+	keys := in.Sort
+	if !slices.ContainsFunc(keys, func(key query.SortKey) bool { return key.Field == query.FieldID }) {
+		keys = append(slices.Clone(keys), query.SortKey{Field: query.FieldID})
+	}
+
+	if in.DueWithin < 0 {
+		return dto.GetTodoListResponse{}, fmt.Errorf("%w: due_within must not be negative", uc_errors.InvalidFilterError)
+	}
+	if in.ProjectID != 0 {
+		in.Filter = narrow(in.Filter, query.Predicate{Field: query.FieldProjectID, Op: query.OpEq, Value: in.ProjectID})
+	}
+	if in.ParentID != 0 {
+		in.Filter = narrow(in.Filter, query.Predicate{Field: query.FieldParentID, Op: query.OpEq, Value: in.ParentID})
+	}
+	if len(in.Tags) > 0 {
+		byTags, err := tagsFilter(in.Tags, in.AnyTag)
+		if err != nil {
+			return dto.GetTodoListResponse{}, err
+		}
+		in.Filter = narrow(in.Filter, byTags)
+	}
+	filter := dueFilter(in, uc.Clock.Now())
+
+	q := query.Query{
+		Filter: filter,
+		Sort:   in.Sort,
+		Limit:  in.Limit,
+		Offset: in.Offset,
+	}
+
+	var cur cursor.Cursor
+	if in.Cursor != "" {
+		if in.Offset != 0 {
+			return dto.GetTodoListResponse{}, fmt.Errorf("%w: cannot be combined with offset", uc_errors.InvalidCursorError)
+		}
+
+		var err error
+		if cur, err = uc.Cursors.Decode(in.Cursor); err != nil {
+			return dto.GetTodoListResponse{}, err
+		}
+		if !cur.Matches(keys) {
+			return dto.GetTodoListResponse{}, fmt.Errorf("%w: issued for a different sort", uc_errors.InvalidCursorError)
+		}
+
+		q.Sort = keys
+		if cur.Backward {
+			q.Sort = query.Reverse(keys)
+		}
+		q.Filter = query.Seek(q.Sort, cur.Values)
+		if filter != nil {
+			q.Filter = query.And{filter, q.Filter}
+		}
+	}
+
+	// One extra item tells whether there is a page beyond this one.
+	if q.Limit > 0 {
+		q.Limit++
+	}
+
+	todos, err := uc.Storage.QueryTodos(ctx, q)
+	if err != nil {
+		return dto.GetTodoListResponse{}, uc_errors.Wrap(uc_errors.GetTodoListError, err)
+	}
+
+	more := in.Limit > 0 && len(todos) > in.Limit
+	if more {
+		todos = todos[:in.Limit]
+	}
+	if cur.Backward {
+		slices.Reverse(todos)
+	}
+
+	total, err := uc.Storage.CountTodos(ctx, filter)
 	if err != nil {
 		return dto.GetTodoListResponse{}, uc_errors.Wrap(uc_errors.GetTodoListError, err)
 	}
 
-	return mappers.MapDomainTodoListToTodoListDTO(todos), nil
+	response := mappers.MapDomainTodoListToTodoListDTO(todos)
+	response.Total = total
+	response.Limit = in.Limit
+	response.Offset = in.Offset
+	if in.Limit == 0 || len(todos) == 0 {
+		return response, nil
+	}
+
+	var hasNext, hasPrev bool
+	switch {
+	case in.Cursor == "":
+		hasNext, hasPrev = more, in.Offset > 0
+	case cur.Backward:
+		hasNext, hasPrev = true, more
+	default:
+		hasNext, hasPrev = more, true
+	}
+
+	if hasNext {
+		if response.NextCursor, err = uc.encode(keys, todos[len(todos)-1], false); err != nil {
+			return dto.GetTodoListResponse{}, uc_errors.Wrap(uc_errors.GetTodoListError, err)
+		}
+	}
+	if hasPrev {
+		if response.PrevCursor, err = uc.encode(keys, todos[0], true); err != nil {
+			return dto.GetTodoListResponse{}, uc_errors.Wrap(uc_errors.GetTodoListError, err)
+		}
+	}
+
+	return response, nil
+}
+
+// narrow adds f to filter, which may be nil.
+func narrow(filter, f query.Filter) query.Filter {
+	if filter == nil {
+		return f
+	}
+	return query.And{filter, f}
+}
+
+// dueFilter adds the due date list modes to in.Filter.
+func dueFilter(in dto.GetTodoList, now time.Time) query.Filter {
+	var filters query.And
+	if in.Filter != nil {
+		filters = append(filters, in.Filter)
+	}
+
+	if !in.DueBefore.IsZero() {
+		filters = append(filters,
+			query.IsSet(query.FieldDueAt),
+			query.Predicate{Field: query.FieldDueAt, Op: query.OpLt, Value: in.DueBefore},
+		)
+	}
+
+	if in.DueWithin > 0 {
+		filters = append(filters,
+			query.Predicate{Field: query.FieldDueAt, Op: query.OpGe, Value: now},
+			query.Predicate{Field: query.FieldDueAt, Op: query.OpLe, Value: now.Add(in.DueWithin)},
+		)
+	}
+
+	if in.Overdue != nil {
+		overdue := query.And{
+			query.IsSet(query.FieldDueAt),
+			query.Predicate{Field: query.FieldDueAt, Op: query.OpLt, Value: now},
+			query.Predicate{Field: query.FieldCompleted, Op: query.OpEq, Value: false},
+		}
+		if *in.Overdue {
+			filters = append(filters, overdue...)
+		} else {
+			filters = append(filters, query.Not{Filter: overdue})
+		}
+	}
+
+	switch len(filters) {
+	case 0:
+		return nil
+	case 1:
+		return filters[0]
+	}
+	return filters
+}
+
+func (uc *GetTodoListUC) encode(keys []query.SortKey, todo *entity.Todo, backward bool) (string, error) {
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		values[i] = key.Field.Value(todo)
+	}
+
+	return uc.Cursors.Encode(cursor.Cursor{Backward: backward, Sort: keys, Values: values})
 }
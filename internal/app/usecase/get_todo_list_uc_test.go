@@ -3,17 +3,21 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/cursor"
 	"todo-api/internal/app/dto"
 	"todo-api/internal/app/uc_errors"
 	"todo-api/internal/app/usecase"
 	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/query"
 )
 
 func TestGetTodoListUC(t *testing.T) {
 	store := storage.NewDataStorage()
-	uc := usecase.NewGetTodoListUC(store)
+	uc := usecase.NewGetTodoListUC(store, cursor.NewCodec([]byte("secret")), newFakeClock())
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -65,3 +69,172 @@ func TestGetTodoListUC(t *testing.T) {
 		}
 	})
 }
+
+func TestGetTodoListUC_Cursor(t *testing.T) {
+	store := storage.NewDataStorage()
+	uc := usecase.NewGetTodoListUC(store, cursor.NewCodec([]byte("secret")), newFakeClock())
+	ctx := context.Background()
+
+	for i := 1; i <= 6; i++ {
+		_ = store.CreateTodo(ctx, &entity.Todo{Title: fmt.Sprintf("Todo %d", i), Completed: i%2 == 0})
+	}
+
+	ids := func(result dto.GetTodoListResponse) []int64 {
+		ids := make([]int64, len(result.Todos))
+		for i, todo := range result.Todos {
+			ids[i] = todo.ID
+		}
+		return ids
+	}
+
+	t.Run("Stable under inserts and deletes", func(t *testing.T) {
+		first, err := uc.Execute(ctx, dto.GetTodoList{Limit: 2})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if first.PrevCursor != "" || first.NextCursor == "" {
+			t.Fatalf("expected only a next cursor, got %+v", first)
+		}
+
+		// With offsets, deleting an item from the first page would skip id 3
+		// and the new todo would not shift anything into view twice.
+		_ = store.DeleteTodo(ctx, 2, 0)
+		_ = store.CreateTodo(ctx, &entity.Todo{Title: "Todo 7"})
+
+		second, err := uc.Execute(ctx, dto.GetTodoList{Limit: 2, Cursor: first.NextCursor})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if got := ids(second); fmt.Sprint(got) != "[3 4]" {
+			t.Errorf("expected [3 4], got %v", got)
+		}
+
+		back, err := uc.Execute(ctx, dto.GetTodoList{Limit: 2, Cursor: second.PrevCursor})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if got := ids(back); fmt.Sprint(got) != "[1]" {
+			t.Errorf("expected [1], got %v", got)
+		}
+		if back.PrevCursor != "" || back.NextCursor == "" {
+			t.Errorf("expected only a next cursor on the first page, got %+v", back)
+		}
+
+		var all []int64
+		page := dto.GetTodoList{Limit: 2}
+		for {
+			result, err := uc.Execute(ctx, page)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			all = append(all, ids(result)...)
+			if result.NextCursor == "" {
+				break
+			}
+			page.Cursor = result.NextCursor
+		}
+		if fmt.Sprint(all) != "[1 3 4 5 6 7]" {
+			t.Errorf("expected [1 3 4 5 6 7], got %v", all)
+		}
+	})
+
+	t.Run("Custom sort and filter", func(t *testing.T) {
+		in := dto.GetTodoList{
+			Limit:  2,
+			Filter: query.Predicate{Field: query.FieldID, Op: query.OpGe, Value: int64(3)},
+			Sort:   []query.SortKey{{Field: query.FieldCompleted, Desc: true}, {Field: query.FieldTitle, Desc: true}},
+		}
+
+		var all []int64
+		for {
+			result, err := uc.Execute(ctx, in)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			all = append(all, ids(result)...)
+			if result.NextCursor == "" {
+				break
+			}
+			in.Cursor = result.NextCursor
+		}
+		if fmt.Sprint(all) != "[6 4 7 5 3]" {
+			t.Errorf("expected [6 4 7 5 3], got %v", all)
+		}
+	})
+
+	t.Run("Error - cursor for another sort", func(t *testing.T) {
+		result, _ := uc.Execute(ctx, dto.GetTodoList{Limit: 1})
+
+		in := dto.GetTodoList{Limit: 1, Cursor: result.NextCursor, Sort: []query.SortKey{{Field: query.FieldTitle}}}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.InvalidCursorError) {
+			t.Errorf("expected InvalidCursorError, got %v", err)
+		}
+	})
+
+	t.Run("Error - cursor with offset", func(t *testing.T) {
+		result, _ := uc.Execute(ctx, dto.GetTodoList{Limit: 1})
+
+		in := dto.GetTodoList{Limit: 1, Offset: 1, Cursor: result.NextCursor}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.InvalidCursorError) {
+			t.Errorf("expected InvalidCursorError, got %v", err)
+		}
+	})
+}
+
+func TestGetTodoListUC_Due(t *testing.T) {
+	store := storage.NewDataStorage()
+	clock := newFakeClock()
+	uc := usecase.NewGetTodoListUC(store, cursor.NewCodec([]byte("secret")), clock)
+	ctx := context.Background()
+	now := clock.Now()
+
+	for _, todo := range []entity.Todo{
+		{Title: "No due date"},
+		{Title: "Overdue", DueAt: now.Add(-time.Hour)},
+		{Title: "Done late", DueAt: now.Add(-2 * time.Hour), Completed: true},
+		{Title: "Due tomorrow", DueAt: now.Add(24 * time.Hour)},
+		{Title: "Due next week", DueAt: now.Add(7 * 24 * time.Hour)},
+	} {
+		_ = store.CreateTodo(ctx, &todo)
+	}
+
+	overdue, notOverdue := true, false
+	cases := []struct {
+		name     string
+		in       dto.GetTodoList
+		expected []string
+	}{
+		{"Due before", dto.GetTodoList{DueBefore: now.Add(48 * time.Hour)}, []string{"Overdue", "Done late", "Due tomorrow"}},
+		{"Due within", dto.GetTodoList{DueWithin: 48 * time.Hour}, []string{"Due tomorrow"}},
+		{"Overdue", dto.GetTodoList{Overdue: &overdue}, []string{"Overdue"}},
+		{"Not overdue", dto.GetTodoList{Overdue: &notOverdue}, []string{"No due date", "Done late", "Due tomorrow", "Due next week"}},
+		{"Combined with sort", dto.GetTodoList{
+			DueBefore: now.Add(30 * 24 * time.Hour),
+			Sort:      []query.SortKey{{Field: query.FieldDueAt, Desc: true}},
+			Limit:     2,
+		}, []string{"Due next week", "Due tomorrow"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := uc.Execute(ctx, tc.in)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			titles := make([]string, len(result.Todos))
+			for i, todo := range result.Todos {
+				titles[i] = todo.Title
+			}
+			if fmt.Sprint(titles) != fmt.Sprint(tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, titles)
+			}
+		})
+	}
+
+	t.Run("Error - negative window", func(t *testing.T) {
+		if _, err := uc.Execute(ctx, dto.GetTodoList{DueWithin: -time.Hour}); !errors.Is(err, uc_errors.InvalidFilterError) {
+			t.Errorf("expected InvalidFilterError, got %v", err)
+		}
+	})
+}
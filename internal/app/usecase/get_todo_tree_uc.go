@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+)
+
+type GetTodoTreeUC struct {
+	Storage port.DataStorage
+}
+
+func NewGetTodoTreeUC(storage port.DataStorage) *GetTodoTreeUC {
+	return &GetTodoTreeUC{Storage: storage}
+}
+
+// Execute returns the todo with all of its descendants nested under their
+// parents in id order.
+func (uc *GetTodoTreeUC) Execute(ctx context.Context, in dto.GetTodoTree) (dto.GetTodoTreeResponse, error) {
+	failed := dto.GetTodoTreeResponse{TodoNode: dto.TodoNode{Todo: dto.Todo{ID: in.ID}}}
+	if in.ID <= 0 {
+		return failed, uc_errors.InvalidTodoIDError
+	}
+
+	root, err := uc.Storage.GetTodo(ctx, in.ID)
+	if err != nil {
+		if !errors.Is(err, uc_errors.TodoNotFoundError) {
+			return failed, uc_errors.Wrap(uc_errors.GetTodoTreeError, err)
+		}
+		return failed, err
+	}
+
+	levels, err := descendants(ctx, uc.Storage, root.ID)
+	if err != nil {
+		return failed, uc_errors.Wrap(uc_errors.GetTodoTreeError, err)
+	}
+
+	children := make(map[int64][]*entity.Todo)
+	for _, level := range levels {
+		for _, todo := range level {
+			children[todo.ParentID] = append(children[todo.ParentID], todo)
+		}
+	}
+
+	return dto.GetTodoTreeResponse{TodoNode: buildNode(root, children)}, nil
+}
+
+func buildNode(todo *entity.Todo, children map[int64][]*entity.Todo) dto.TodoNode {
+	node := dto.TodoNode{
+		Todo:     mappers.MapDomainTodoToTodoDTO(todo),
+		Children: make([]dto.TodoNode, 0, len(children[todo.ID])),
+	}
+
+	done := 0
+	for _, child := range children[todo.ID] {
+		if child.Completed {
+			done++
+		}
+		node.Children = append(node.Children, buildNode(child, children))
+	}
+	if len(node.Children) > 0 {
+		node.Progress = &dto.Progress{Done: done, Total: len(node.Children)}
+	}
+
+	return node
+}
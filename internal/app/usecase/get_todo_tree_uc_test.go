@@ -0,0 +1,76 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/entity"
+)
+
+// newSubtaskStore holds the tree
+//
+//	1 Move out
+//	├── 2 Pack
+//	│   ├── 4 Pack books (done)
+//	│   └── 5 Pack dishes
+//	└── 3 Clean (done)
+func newSubtaskStore() *storage.DataStorage {
+	store := storage.NewDataStorage()
+	for _, todo := range []entity.Todo{
+		{Title: "Move out"},
+		{Title: "Pack", ParentID: 1},
+		{Title: "Clean", ParentID: 1, Completed: true},
+		{Title: "Pack books", ParentID: 2, Completed: true},
+		{Title: "Pack dishes", ParentID: 2},
+	} {
+		_ = store.CreateTodo(context.Background(), &todo)
+	}
+	return store
+}
+
+func TestGetTodoTreeUC(t *testing.T) {
+	store := newSubtaskStore()
+	uc := usecase.NewGetTodoTreeUC(store)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := uc.Execute(ctx, dto.GetTodoTree{ID: 1})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.Children) != 2 || result.Children[0].ID != 2 || result.Children[1].ID != 3 {
+			t.Fatalf("expected children 2 and 3, got %+v", result.Children)
+		}
+		if p := result.Progress; p == nil || p.Done != 1 || p.Total != 2 {
+			t.Errorf("expected progress 1/2, got %+v", p)
+		}
+
+		pack := result.Children[0]
+		if len(pack.Children) != 2 || pack.Children[1].Title != "Pack dishes" {
+			t.Errorf("expected Pack books and Pack dishes under Pack, got %+v", pack.Children)
+		}
+		if leaf := result.Children[1]; leaf.Progress != nil || leaf.Children == nil {
+			t.Errorf("expected a leaf without progress and with empty children, got %+v", leaf)
+		}
+	})
+
+	t.Run("Success - progress on get", func(t *testing.T) {
+		result, err := usecase.NewGetTodoUC(store).Execute(ctx, dto.GetTodo{ID: 2})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if p := result.Progress; p == nil || p.Done != 1 || p.Total != 2 {
+			t.Errorf("expected progress 1/2, got %+v", p)
+		}
+	})
+
+	t.Run("Error - todo not found", func(t *testing.T) {
+		if _, err := uc.Execute(ctx, dto.GetTodoTree{ID: 42}); !errors.Is(err, uc_errors.TodoNotFoundError) {
+			t.Errorf("expected TodoNotFoundError, got %v", err)
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"todo-api/internal/app/dto"
 	"todo-api/internal/app/mappers"
 	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
 	"todo-api/internal/domain/port"
 )
 
@@ -30,7 +31,30 @@ func (uc *GetTodoUC) Execute(ctx context.Context, in dto.GetTodo) (dto.GetTodoRe
 		return dto.GetTodoResponse{Todo: dto.Todo{ID: in.ID}}, err
 	}
 
+	out, err := viewTodo(ctx, uc.Storage, todo)
+	if err != nil {
+		return dto.GetTodoResponse{Todo: dto.Todo{ID: in.ID}}, uc_errors.Wrap(uc_errors.GetTodoError, err)
+	}
+	return out, nil
+}
+
+// viewTodo is the representation of todo that GET /todos/{id} serves, with
+// the fields computed from its subtasks and blockers. The write use cases
+// answer with it too, so that their ETag matches the one GET returns.
+func viewTodo(ctx context.Context, storage port.DataStorage, todo *entity.Todo) (dto.GetTodoResponse, error) {
+	subtasks, err := progress(ctx, storage, todo.ID)
+	if err != nil {
+		return dto.GetTodoResponse{}, err
+	}
+
+	blocked, err := isBlocked(ctx, storage, todo.BlockedBy)
+	if err != nil {
+		return dto.GetTodoResponse{}, err
+	}
+
 	return dto.GetTodoResponse{
-		Todo: mappers.MapDomainTodoToTodoDTO(todo),
+		Todo:     mappers.MapDomainTodoToTodoDTO(todo),
+		Progress: subtasks,
+		Blocked:  blocked,
 	}, nil
 }
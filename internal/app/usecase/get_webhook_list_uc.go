@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+type GetWebhookListUC struct {
+	Webhooks port.WebhookStorage
+}
+
+func NewGetWebhookListUC(webhooks port.WebhookStorage) *GetWebhookListUC {
+	return &GetWebhookListUC{Webhooks: webhooks}
+}
+
+func (uc *GetWebhookListUC) Execute(ctx context.Context) (dto.GetWebhookListResponse, error) {
+	webhooks, err := uc.Webhooks.ListWebhooks(ctx)
+	if err != nil {
+		return dto.GetWebhookListResponse{}, uc_errors.Wrap(uc_errors.GetWebhookListError, err)
+	}
+
+	response := dto.GetWebhookListResponse{Webhooks: make([]dto.Webhook, len(webhooks))}
+	for i, webhook := range webhooks {
+		response.Webhooks[i] = mappers.MapDomainWebhookToWebhookDTO(webhook)
+	}
+	return response, nil
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+type GetWebhookUC struct {
+	Webhooks port.WebhookStorage
+}
+
+func NewGetWebhookUC(webhooks port.WebhookStorage) *GetWebhookUC {
+	return &GetWebhookUC{Webhooks: webhooks}
+}
+
+func (uc *GetWebhookUC) Execute(ctx context.Context, in dto.GetWebhook) (dto.GetWebhookResponse, error) {
+	if in.ID <= 0 {
+		return dto.GetWebhookResponse{}, uc_errors.InvalidWebhookIDError
+	}
+
+	webhook, err := uc.Webhooks.GetWebhook(ctx, in.ID)
+	if err != nil {
+		if !errors.Is(err, uc_errors.WebhookNotFoundError) {
+			return dto.GetWebhookResponse{}, uc_errors.Wrap(uc_errors.GetWebhookError, err)
+		}
+		return dto.GetWebhookResponse{}, err
+	}
+
+	return dto.GetWebhookResponse{Webhook: mappers.MapDomainWebhookToWebhookDTO(webhook)}, nil
+}
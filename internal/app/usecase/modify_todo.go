@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+)
+
+// modifyTodo loads a todo, lets change edit it and writes it back guarded by
+// the loaded version. Without a client version a lost race is retried. When
+// change reports no change the stored todo is returned without a write.
+// A write is published to publisher.
+func modifyTodo(
+	ctx context.Context,
+	storage port.DataStorage,
+	clock port.Clock,
+	publisher port.EventPublisher,
+	id, version int64,
+	change func(todo *entity.Todo) (bool, error),
+) (*entity.Todo, error) {
+	for attempt := 1; ; attempt++ {
+		todo, err := modifyTodoOnce(ctx, storage, clock, publisher, id, version, change)
+		if errors.Is(err, uc_errors.TodoVersionConflictError) && version == 0 && attempt < writeAttempts {
+			continue
+		}
+		return todo, err
+	}
+}
+
+func modifyTodoOnce(
+	ctx context.Context,
+	storage port.DataStorage,
+	clock port.Clock,
+	publisher port.EventPublisher,
+	id, version int64,
+	change func(todo *entity.Todo) (bool, error),
+) (*entity.Todo, error) {
+	current, err := storage.GetTodo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && current.Version != version {
+		return nil, uc_errors.TodoVersionConflictError
+	}
+
+	todo := *current
+	changed, err := change(&todo)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return current, nil
+	}
+	stamp(&todo, current, clock.Now())
+
+	if err := storage.UpdateTodo(ctx, &todo, current.Version); err != nil {
+		return nil, err
+	}
+	publishWrite(ctx, publisher, current, &todo)
+	return &todo, nil
+}
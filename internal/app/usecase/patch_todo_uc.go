@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/patch"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+type PatchTodoUC struct {
+	todoWriter
+}
+
+func NewPatchTodoUC(
+	storage port.DataStorage,
+	projects port.ProjectStorage,
+	clock port.Clock,
+	publisher port.EventPublisher,
+	subtasks SubtaskCompletePolicy,
+) *PatchTodoUC {
+	return &PatchTodoUC{todoWriter{
+		Storage:   storage,
+		Projects:  projects,
+		Clock:     clock,
+		Subtasks:  subtasks,
+		Publisher: publisher,
+		failure:   uc_errors.PatchTodoError,
+	}}
+}
+
+func (uc *PatchTodoUC) Execute(ctx context.Context, in dto.PatchTodo) (dto.PatchTodoResponse, error) {
+	if in.ID <= 0 {
+		return dto.PatchTodoResponse{GetTodoResponse: dto.GetTodoResponse{Todo: dto.Todo{ID: in.ID}}}, uc_errors.InvalidTodoIDError
+	}
+
+	var apply func(doc, patch []byte) ([]byte, error)
+	switch in.Format {
+	case dto.PatchFormatMerge:
+		apply = patch.Merge
+	case dto.PatchFormatJSON:
+		apply = patch.Apply
+	default:
+		return dto.PatchTodoResponse{GetTodoResponse: dto.GetTodoResponse{Todo: dto.Todo{ID: in.ID}}}, uc_errors.UnsupportedPatchError
+	}
+
+	for attempt := 1; ; attempt++ {
+		out, err := uc.patch(ctx, in, apply)
+		if errors.Is(err, uc_errors.TodoVersionConflictError) && in.Version == 0 && attempt < writeAttempts {
+			continue
+		}
+		return out, err
+	}
+}
+
+func (uc *PatchTodoUC) patch(ctx context.Context, in dto.PatchTodo, apply func(doc, patch []byte) ([]byte, error)) (dto.PatchTodoResponse, error) {
+	failed := dto.PatchTodoResponse{GetTodoResponse: dto.GetTodoResponse{Todo: dto.Todo{ID: in.ID}}}
+
+	current, err := uc.Storage.GetTodo(ctx, in.ID)
+	if err != nil {
+		if !errors.Is(err, uc_errors.TodoNotFoundError) {
+			return failed, uc_errors.Wrap(uc_errors.PatchTodoError, err)
+		}
+		return failed, err
+	}
+	if in.Version != 0 && current.Version != in.Version {
+		return failed, uc_errors.TodoVersionConflictError
+	}
+
+	before := mappers.MapDomainTodoToTodoDTO(current)
+	doc, err := json.Marshal(before)
+	if err != nil {
+		return failed, uc_errors.Wrap(uc_errors.PatchTodoError, err)
+	}
+
+	patched, err := apply(doc, in.Patch)
+	if err != nil {
+		return failed, err
+	}
+
+	var after dto.Todo
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&after); err != nil {
+		return failed, fmt.Errorf("%w: %v", uc_errors.InvalidPatchError, err)
+	}
+
+	if after.ID != before.ID || after.Version != before.Version ||
+		!sameTime(after.CreatedAt, before.CreatedAt) ||
+		!sameTime(after.UpdatedAt, before.UpdatedAt) ||
+		!sameTime(after.CompletedAt, before.CompletedAt) {
+		return failed, uc_errors.ReadOnlyFieldError
+	}
+	if after.Title == "" {
+		return failed, uc_errors.EmptyTitleError
+	}
+	if err := validateSchedule(after); err != nil {
+		return failed, err
+	}
+	if err := validatePriority(after); err != nil {
+		return failed, err
+	}
+	if err := normalizeRecurrence(&after); err != nil {
+		return failed, err
+	}
+	if after.Tags, err = normalizeTodoTags(after.Tags); err != nil {
+		return failed, err
+	}
+	after.BlockedBy = normalizeBlockers(after.BlockedBy)
+
+	todo := mappers.MapTodoDTOToDomainTodo(after)
+	next, err := uc.applyWrite(ctx, current, todo)
+	if err != nil {
+		return failed, err
+	}
+
+	view, err := viewTodo(ctx, uc.Storage, todo)
+	if err != nil {
+		return failed, uc_errors.Wrap(uc_errors.PatchTodoError, err)
+	}
+	out := dto.PatchTodoResponse{GetTodoResponse: view}
+	if next != nil {
+		out.NextID = next.ID
+	}
+	return out, nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
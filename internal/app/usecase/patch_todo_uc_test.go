@@ -0,0 +1,133 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/entity"
+)
+
+func TestPatchTodoUC(t *testing.T) {
+	store := storage.NewDataStorage()
+	uc := usecase.NewPatchTodoUC(store, store, newFakeClock(), nil, usecase.SubtaskCompleteAllow)
+	ctx := context.Background()
+
+	newTodo := func() *entity.Todo {
+		todo := &entity.Todo{Title: "Wash clothes", Description: "use a washing machine"}
+		_ = store.CreateTodo(ctx, todo)
+		return todo
+	}
+
+	t.Run("Success - merge patch", func(t *testing.T) {
+		todo := newTodo()
+
+		in := dto.PatchTodo{ID: todo.ID, Format: dto.PatchFormatMerge, Patch: []byte(`{"completed": true}`)}
+		result, err := uc.Execute(ctx, in)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !result.Completed || result.Title != todo.Title || result.Description != todo.Description {
+			t.Errorf("expected only completed to change, got %+v", result.Todo)
+		}
+		if result.Version != todo.Version+1 {
+			t.Errorf("expected version %d, got %d", todo.Version+1, result.Version)
+		}
+	})
+
+	t.Run("Success - json patch", func(t *testing.T) {
+		todo := newTodo()
+
+		in := dto.PatchTodo{ID: todo.ID, Format: dto.PatchFormatJSON, Patch: []byte(`[
+			{"op": "test", "path": "/completed", "value": false},
+			{"op": "replace", "path": "/completed", "value": true},
+			{"op": "replace", "path": "/description", "value": ""}
+		]`)}
+		if _, err := uc.Execute(ctx, in); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if patched, _ := store.GetTodo(ctx, todo.ID); !patched.Completed || patched.Description != "" {
+			t.Errorf("expected patched todo, got %+v", patched)
+		}
+	})
+
+	t.Run("Error - invalid id", func(t *testing.T) {
+		in := dto.PatchTodo{ID: 0, Format: dto.PatchFormatMerge, Patch: []byte(`{}`)}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.InvalidTodoIDError) {
+			t.Errorf("expected InvalidTodoIDError, got %v", err)
+		}
+	})
+
+	t.Run("Error - unsupported format", func(t *testing.T) {
+		in := dto.PatchTodo{ID: 1, Format: "xml-patch", Patch: []byte(`{}`)}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.UnsupportedPatchError) {
+			t.Errorf("expected UnsupportedPatchError, got %v", err)
+		}
+	})
+
+	t.Run("Error - todo not found", func(t *testing.T) {
+		in := dto.PatchTodo{ID: 100, Format: dto.PatchFormatMerge, Patch: []byte(`{}`)}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.TodoNotFoundError) {
+			t.Errorf("expected TodoNotFoundError, got %v", err)
+		}
+	})
+
+	t.Run("Error - empty title", func(t *testing.T) {
+		todo := newTodo()
+
+		in := dto.PatchTodo{ID: todo.ID, Format: dto.PatchFormatMerge, Patch: []byte(`{"title": null}`)}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.EmptyTitleError) {
+			t.Errorf("expected EmptyTitleError, got %v", err)
+		}
+	})
+
+	t.Run("Error - read-only field", func(t *testing.T) {
+		todo := newTodo()
+
+		for _, p := range []string{`{"id": 999}`, `{"version": 42}`} {
+			in := dto.PatchTodo{ID: todo.ID, Format: dto.PatchFormatMerge, Patch: []byte(p)}
+			if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.ReadOnlyFieldError) {
+				t.Errorf("expected ReadOnlyFieldError for %s, got %v", p, err)
+			}
+		}
+	})
+
+	t.Run("Error - unknown field or wrong type", func(t *testing.T) {
+		todo := newTodo()
+
+		for _, p := range []string{`{"owner": "me"}`, `{"completed": "yes"}`} {
+			in := dto.PatchTodo{ID: todo.ID, Format: dto.PatchFormatMerge, Patch: []byte(p)}
+			if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.InvalidPatchError) {
+				t.Errorf("expected InvalidPatchError for %s, got %v", p, err)
+			}
+		}
+	})
+
+	t.Run("Error - failed test op", func(t *testing.T) {
+		todo := newTodo()
+
+		in := dto.PatchTodo{ID: todo.ID, Format: dto.PatchFormatJSON, Patch: []byte(`[
+			{"op": "test", "path": "/title", "value": "Wash dishes"},
+			{"op": "replace", "path": "/completed", "value": true}
+		]`)}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.PatchTestFailedError) {
+			t.Errorf("expected PatchTestFailedError, got %v", err)
+		}
+		if unchanged, _ := store.GetTodo(ctx, todo.ID); unchanged.Completed || unchanged.Version != todo.Version {
+			t.Errorf("expected todo to be unchanged, got %+v", unchanged)
+		}
+	})
+
+	t.Run("Error - version conflict", func(t *testing.T) {
+		todo := newTodo()
+
+		in := dto.PatchTodo{ID: todo.ID, Version: todo.Version + 1, Format: dto.PatchFormatMerge, Patch: []byte(`{"completed": true}`)}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.TodoVersionConflictError) {
+			t.Errorf("expected TodoVersionConflictError, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+// checkProjectRef verifies that a todo's project_id, when set, names an
+// existing project.
+func checkProjectRef(ctx context.Context, projects port.ProjectStorage, id int64) error {
+	if id == 0 {
+		return nil
+	}
+	if id < 0 {
+		return uc_errors.UnknownProjectError
+	}
+
+	if _, err := projects.GetProject(ctx, id); err != nil {
+		if errors.Is(err, uc_errors.ProjectNotFoundError) {
+			return uc_errors.UnknownProjectError
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/recurrence"
+)
+
+// normalizeRecurrence checks the todo's recurrence and rewrites the rule in
+// its canonical form; an empty rule drops the recurrence.
+func normalizeRecurrence(todo *dto.Todo) error {
+	r := todo.Recurrence
+	if r == nil || strings.TrimSpace(r.Rule) == "" {
+		todo.Recurrence = nil
+		return nil
+	}
+
+	rule, err := recurrence.Parse(r.Rule)
+	if err != nil {
+		return fmt.Errorf("%w: %v", uc_errors.InvalidRecurrenceError, err)
+	}
+	if _, err := loadZone(r.TimeZone); err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", uc_errors.InvalidRecurrenceError, r.TimeZone)
+	}
+	if todo.DueAt == nil {
+		return fmt.Errorf("%w: a recurring todo needs due_at", uc_errors.InvalidRecurrenceError)
+	}
+
+	todo.Recurrence = &dto.Recurrence{Rule: rule.String(), TimeZone: r.TimeZone}
+	return nil
+}
+
+// completeOccurrence hands the series of a recurring todo that is being
+// completed over to the todo for the next occurrence, which the caller creates
+// once todo is written. next is nil unless todo is being completed or when the
+// series ends with it.
+func completeOccurrence(current, todo *entity.Todo) (next *entity.Todo, err error) {
+	if !todo.Completed || current.Completed || todo.Recurrence.IsZero() || todo.DueAt.IsZero() {
+		return nil, nil
+	}
+
+	rule, err := recurrence.Parse(todo.Recurrence.Rule)
+	if err != nil {
+		return nil, err
+	}
+	zone, err := loadZone(todo.Recurrence.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	series := todo.Recurrence
+	todo.Recurrence = entity.Recurrence{}
+
+	due, rest, ok := rule.Next(todo.DueAt.In(zone))
+	if !ok {
+		return nil, nil
+	}
+
+	next = &entity.Todo{
+		Title:       todo.Title,
+		Description: todo.Description,
+		Priority:    todo.Priority,
+		Tags:        slices.Clone(todo.Tags),
+		ProjectID:   todo.ProjectID,
+		ParentID:    todo.ParentID,
+		Recurrence:  entity.Recurrence{Rule: rest.String(), TimeZone: series.TimeZone},
+		DueAt:       due.UTC(),
+	}
+	if !todo.StartAt.IsZero() {
+		next.StartAt = next.DueAt.Add(todo.StartAt.Sub(todo.DueAt))
+	}
+	return next, nil
+}
+
+// occurrences lists when the todo falls due between from and to, at most
+// limit times; a zero to means no end. It gives up with InvalidRangeError
+// after maxOccurrenceSteps occurrences, listed or not.
+func occurrences(todo *entity.Todo, from, to time.Time, limit int) ([]time.Time, error) {
+	result := []time.Time{}
+	if todo.DueAt.IsZero() {
+		return result, nil
+	}
+
+	all := func(yield func(time.Time) bool) { yield(todo.DueAt) }
+	if !todo.Recurrence.IsZero() {
+		rule, err := recurrence.Parse(todo.Recurrence.Rule)
+		if err != nil {
+			return nil, err
+		}
+		zone, err := loadZone(todo.Recurrence.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		all = rule.All(todo.DueAt.In(zone))
+	}
+
+	steps := 0
+	for t := range all {
+		if !to.IsZero() && !t.Before(to) || len(result) == limit {
+			break
+		}
+		if steps++; steps > maxOccurrenceSteps {
+			return nil, fmt.Errorf("%w: from is too far past the first occurrence", uc_errors.InvalidRangeError)
+		}
+		if !t.Before(from) {
+			result = append(result, t.UTC())
+		}
+	}
+	return result, nil
+}
+
+// loadZone differs from time.LoadLocation in refusing "Local", which would
+// tie stored todos to the server's configuration.
+func loadZone(name string) (*time.Location, error) {
+	if name == "Local" {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return time.LoadLocation(name)
+}
@@ -0,0 +1,231 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/entity"
+)
+
+// failingCreates stores no new todos.
+type failingCreates struct {
+	*storage.DataStorage
+}
+
+func (s failingCreates) CreateTodo(context.Context, *entity.Todo) error {
+	return errors.New("disk full")
+}
+
+// failingUpdates stores no changes to todos.
+type failingUpdates struct {
+	*storage.DataStorage
+}
+
+func (s failingUpdates) UpdateTodo(context.Context, *entity.Todo, int64) error {
+	return errors.New("disk full")
+}
+
+func TestUpdateTodoUC_Recurrence(t *testing.T) {
+	ctx := context.Background()
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+	due := time.Date(2026, 3, 2, 9, 0, 0, 0, moscow)
+
+	store := storage.NewDataStorage()
+	create := usecase.NewCreateTodoUC(store, store, newFakeClock(), nil)
+	update := usecase.NewUpdateTodoUC(store, store, newFakeClock(), nil, usecase.SubtaskCompleteAllow)
+	get := usecase.NewGetOccurrencesUC(store)
+
+	series := dto.Todo{
+		Title:      "Weekly review",
+		Tags:       []string{"work"},
+		DueAt:      &due,
+		Recurrence: &dto.Recurrence{Rule: "rrule:freq=weekly;byday=mo,th;count=3", TimeZone: "Europe/Moscow"},
+	}
+	created, err := create.Execute(ctx, dto.CreateTodo{Todo: series})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	t.Run("Success - canonical rule", func(t *testing.T) {
+		todo, _ := store.GetTodo(ctx, created.ID)
+		if todo.Recurrence.Rule != "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=3" {
+			t.Errorf("expected canonical rule, got %q", todo.Recurrence.Rule)
+		}
+	})
+
+	t.Run("Success - occurrences", func(t *testing.T) {
+		result, err := get.Execute(ctx, dto.GetOccurrences{ID: created.ID})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		want := []time.Time{due, due.AddDate(0, 0, 3), due.AddDate(0, 0, 7)}
+		if len(result.Occurrences) != len(want) {
+			t.Fatalf("expected %d occurrences, got %v", len(want), result.Occurrences)
+		}
+		for i, occurrence := range result.Occurrences {
+			if !occurrence.Equal(want[i]) {
+				t.Errorf("expected occurrence %d at %v, got %v", i, want[i], occurrence)
+			}
+		}
+	})
+
+	t.Run("Success - complete spawns the next occurrence", func(t *testing.T) {
+		id := created.ID
+		for _, want := range []time.Time{due.AddDate(0, 0, 3), due.AddDate(0, 0, 7)} {
+			current, _ := store.GetTodo(ctx, id)
+			in := dto.UpdateTodo{Todo: series}
+			in.ID, in.Completed, in.DueAt = id, true, &current.DueAt
+			in.Recurrence = &dto.Recurrence{Rule: current.Recurrence.Rule, TimeZone: current.Recurrence.TimeZone}
+
+			result, err := update.Execute(ctx, in)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if result.NextID == 0 {
+				t.Fatalf("expected a next occurrence after %v", current.DueAt)
+			}
+
+			completed, _ := store.GetTodo(ctx, id)
+			if !completed.Recurrence.IsZero() {
+				t.Errorf("expected completed todo to leave the series, got %+v", completed.Recurrence)
+			}
+			next, _ := store.GetTodo(ctx, result.NextID)
+			if !next.DueAt.Equal(want) || next.Completed || len(next.Tags) != 1 {
+				t.Errorf("expected open todo due %v, got %+v", want, next)
+			}
+			id = result.NextID
+		}
+
+		last, _ := store.GetTodo(ctx, id)
+		if last.Recurrence.Rule != "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=1" {
+			t.Errorf("expected COUNT to count down, got %q", last.Recurrence.Rule)
+		}
+		in := dto.UpdateTodo{Todo: series}
+		in.ID, in.Completed, in.DueAt = id, true, &last.DueAt
+		in.Recurrence = &dto.Recurrence{Rule: last.Recurrence.Rule, TimeZone: last.Recurrence.TimeZone}
+		result, err := update.Execute(ctx, in)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result.NextID != 0 {
+			t.Errorf("expected the series to end, got next todo %d", result.NextID)
+		}
+	})
+
+	t.Run("Error - invalid recurrence", func(t *testing.T) {
+		for name, todo := range map[string]dto.Todo{
+			"bad rule":    {Title: "Gym", DueAt: &due, Recurrence: &dto.Recurrence{Rule: "FREQ=HOURLY"}},
+			"bad zone":    {Title: "Gym", DueAt: &due, Recurrence: &dto.Recurrence{Rule: "FREQ=DAILY", TimeZone: "Mars/Olympus"}},
+			"without due": {Title: "Gym", Recurrence: &dto.Recurrence{Rule: "FREQ=DAILY"}},
+		} {
+			if _, err := create.Execute(ctx, dto.CreateTodo{Todo: todo}); !errors.Is(err, uc_errors.InvalidRecurrenceError) {
+				t.Errorf("%s: expected InvalidRecurrenceError, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("Error - invalid range", func(t *testing.T) {
+		in := dto.GetOccurrences{ID: created.ID, From: due, To: due.Add(-time.Hour)}
+		if _, err := get.Execute(ctx, in); !errors.Is(err, uc_errors.InvalidRangeError) {
+			t.Errorf("expected InvalidRangeError, got %v", err)
+		}
+	})
+
+	t.Run("Error - failing create keeps the series", func(t *testing.T) {
+		store := storage.NewDataStorage()
+		_ = store.CreateTodo(ctx, &entity.Todo{Title: "Gym", DueAt: due, Recurrence: entity.Recurrence{Rule: "FREQ=DAILY"}})
+		broken := failingCreates{store}
+		update := usecase.NewUpdateTodoUC(broken, store, newFakeClock(), nil, usecase.SubtaskCompleteAllow)
+
+		in := dto.UpdateTodo{Todo: dto.Todo{ID: 1, Title: "Gym", Completed: true, DueAt: &due, Recurrence: &dto.Recurrence{Rule: "FREQ=DAILY"}}}
+		if _, err := update.Execute(ctx, in); !errors.Is(err, uc_errors.UpdateTodoError) {
+			t.Fatalf("expected UpdateTodoError, got %v", err)
+		}
+		todo, _ := store.GetTodo(ctx, 1)
+		if todo.Completed || todo.Recurrence.IsZero() {
+			t.Errorf("expected the todo left open in its series, got %+v", todo)
+		}
+		if _, err := update.Execute(ctx, in); !errors.Is(err, uc_errors.UpdateTodoError) {
+			t.Errorf("expected the retry to fail the same way, got %v", err)
+		}
+	})
+
+	t.Run("Error - failing update removes the next occurrence", func(t *testing.T) {
+		store := storage.NewDataStorage()
+		_ = store.CreateTodo(ctx, &entity.Todo{Title: "Gym", DueAt: due, Recurrence: entity.Recurrence{Rule: "FREQ=DAILY"}})
+		update := usecase.NewUpdateTodoUC(failingUpdates{store}, store, newFakeClock(), nil, usecase.SubtaskCompleteAllow)
+
+		in := dto.UpdateTodo{Todo: dto.Todo{ID: 1, Title: "Gym", Completed: true, DueAt: &due, Recurrence: &dto.Recurrence{Rule: "FREQ=DAILY"}}}
+		if _, err := update.Execute(ctx, in); !errors.Is(err, uc_errors.UpdateTodoError) {
+			t.Fatalf("expected UpdateTodoError, got %v", err)
+		}
+		if _, err := store.GetTodo(ctx, 2); !errors.Is(err, uc_errors.TodoNotFoundError) {
+			t.Errorf("expected the next occurrence removed, got %v", err)
+		}
+	})
+}
+
+func TestGetOccurrencesUC(t *testing.T) {
+	ctx := context.Background()
+	due := time.Date(2026, 1, 31, 18, 0, 0, 0, time.UTC)
+
+	store := storage.NewDataStorage()
+	for _, todo := range []entity.Todo{
+		{Title: "Pay rent", DueAt: due, Recurrence: entity.Recurrence{Rule: "FREQ=MONTHLY;BYMONTHDAY=-1"}},
+		{Title: "Call mom", DueAt: due},
+		{Title: "Someday"},
+	} {
+		_ = store.CreateTodo(ctx, &todo)
+	}
+	uc := usecase.NewGetOccurrencesUC(store)
+
+	t.Run("Success - window", func(t *testing.T) {
+		result, err := uc.Execute(ctx, dto.GetOccurrences{ID: 1, From: due.Add(time.Hour), To: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(result.Occurrences) != 2 || result.Occurrences[0].Day() != 28 || result.Occurrences[1].Day() != 31 {
+			t.Errorf("expected Feb 28 and Mar 31, got %v", result.Occurrences)
+		}
+	})
+
+	t.Run("Success - capped", func(t *testing.T) {
+		result, _ := uc.Execute(ctx, dto.GetOccurrences{ID: 1})
+		if len(result.Occurrences) != usecase.MaxOccurrences {
+			t.Errorf("expected %d occurrences, got %d", usecase.MaxOccurrences, len(result.Occurrences))
+		}
+	})
+
+	t.Run("Success - one-off todos", func(t *testing.T) {
+		if result, _ := uc.Execute(ctx, dto.GetOccurrences{ID: 2}); len(result.Occurrences) != 1 {
+			t.Errorf("expected the due date only, got %v", result.Occurrences)
+		}
+		if result, _ := uc.Execute(ctx, dto.GetOccurrences{ID: 3}); len(result.Occurrences) != 0 {
+			t.Errorf("expected no occurrences, got %v", result.Occurrences)
+		}
+	})
+
+	t.Run("Error - from far ahead", func(t *testing.T) {
+		_ = store.CreateTodo(ctx, &entity.Todo{ID: 4, Title: "Gym", DueAt: due, Recurrence: entity.Recurrence{Rule: "FREQ=DAILY"}})
+
+		in := dto.GetOccurrences{ID: 4, From: due.AddDate(100, 0, 0)}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.InvalidRangeError) {
+			t.Errorf("expected InvalidRangeError, got %v", err)
+		}
+		in.From = due.AddDate(1, 0, 0)
+		if result, err := uc.Execute(ctx, in); err != nil || len(result.Occurrences) != usecase.MaxOccurrences {
+			t.Errorf("expected %d occurrences a year ahead, got %v and %v", usecase.MaxOccurrences, len(result.Occurrences), err)
+		}
+	})
+
+	t.Run("Error - not found", func(t *testing.T) {
+		if _, err := uc.Execute(ctx, dto.GetOccurrences{ID: 42}); !errors.Is(err, uc_errors.TodoNotFoundError) {
+			t.Errorf("expected TodoNotFoundError, got %v", err)
+		}
+	})
+}
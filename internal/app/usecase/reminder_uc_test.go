@@ -0,0 +1,152 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+)
+
+// fakeNotifier records deliveries and fails the first fail of them.
+type fakeNotifier struct {
+	sent []int64
+	fail int
+}
+
+func (n *fakeNotifier) CheckTarget(target string) error {
+	if target == "" {
+		return errors.New("target is required")
+	}
+	return nil
+}
+
+func (n *fakeNotifier) Notify(_ context.Context, reminder *entity.Reminder, _ *entity.Todo) error {
+	if n.fail > 0 {
+		n.fail--
+		return errors.New("connection refused")
+	}
+	n.sent = append(n.sent, reminder.ID)
+	return nil
+}
+
+func TestCreateReminderUC(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewDataStorage()
+	_ = store.CreateTodo(ctx, &entity.Todo{Title: "Pay rent"})
+	uc := usecase.NewCreateReminderUC(store, store, newFakeClock(), map[string]port.Notifier{"fake": &fakeNotifier{}})
+
+	at := time.Date(2026, 3, 1, 9, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := uc.Execute(ctx, dto.CreateReminder{Reminder: dto.Reminder{TodoID: 1, At: &at, Channel: "fake", Target: "me"}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result.ID == 0 || result.Status != "pending" || result.FireAt == nil || !result.FireAt.Equal(at) {
+			t.Errorf("expected a pending reminder at %v, got %+v", at, result.Reminder)
+		}
+	})
+
+	t.Run("Error - invalid reminder", func(t *testing.T) {
+		for name, in := range map[string]dto.Reminder{
+			"no time":         {TodoID: 1, Channel: "fake", Target: "me"},
+			"both times":      {TodoID: 1, At: &at, Before: "1h", Channel: "fake", Target: "me"},
+			"bad offset":      {TodoID: 1, Before: "-1h", Channel: "fake", Target: "me"},
+			"unknown channel": {TodoID: 1, Before: "1h", Channel: "pigeon", Target: "me"},
+			"rejected target": {TodoID: 1, Before: "1h", Channel: "fake"},
+		} {
+			if _, err := uc.Execute(ctx, dto.CreateReminder{Reminder: in}); !errors.Is(err, uc_errors.InvalidReminderError) {
+				t.Errorf("%s: expected InvalidReminderError, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("Error - not found", func(t *testing.T) {
+		in := dto.CreateReminder{Reminder: dto.Reminder{TodoID: 42, Before: "1h", Channel: "fake", Target: "me"}}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.TodoNotFoundError) {
+			t.Errorf("expected TodoNotFoundError, got %v", err)
+		}
+	})
+}
+
+func TestFireRemindersUC(t *testing.T) {
+	ctx := context.Background()
+	clock := newFakeClock()
+	now := clock.Now()
+
+	store := storage.NewDataStorage()
+	for _, todo := range []entity.Todo{
+		{Title: "Pay rent", DueAt: now.Add(time.Hour)},
+		{Title: "Call mom", DueAt: now.Add(3 * time.Hour)},
+		{Title: "Done already", DueAt: now, Completed: true},
+		{Title: "Someday"},
+	} {
+		_ = store.CreateTodo(ctx, &todo)
+	}
+	for _, reminder := range []entity.Reminder{
+		{TodoID: 1, Before: 2 * time.Hour, Channel: "fake"},
+		{TodoID: 2, Before: 2 * time.Hour, Channel: "fake"},
+		{TodoID: 3, At: now, Channel: "fake"},
+		{TodoID: 4, Before: time.Hour, Channel: "fake"},
+		{TodoID: 42, At: now, Channel: "fake"},
+		{TodoID: 1, At: now, Channel: "sms"},
+	} {
+		reminder.Status = entity.ReminderPending
+		_ = store.CreateReminder(ctx, &reminder)
+	}
+
+	notifier := &fakeNotifier{}
+	uc := usecase.NewFireRemindersUC(store, store, clock, map[string]port.Notifier{"fake": notifier}, 2)
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := uc.Execute(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result.Sent != 1 || result.Failed != 1 || len(notifier.sent) != 1 || notifier.sent[0] != 1 {
+			t.Errorf("expected reminder 1 sent and 6 failed, got %+v, sent %v", result, notifier.sent)
+		}
+
+		for id, want := range map[int64]entity.ReminderStatus{
+			1: entity.ReminderSent,
+			2: entity.ReminderPending,
+			3: entity.ReminderSkipped,
+			4: entity.ReminderPending,
+			6: entity.ReminderPending,
+		} {
+			if got, _ := store.GetReminder(ctx, id); got.Status != want {
+				t.Errorf("expected reminder %d %s, got %s", id, want, got.Status)
+			}
+		}
+		if _, err := store.GetReminder(ctx, 5); !errors.Is(err, uc_errors.ReminderNotFoundError) {
+			t.Errorf("expected the reminder of a deleted todo dropped, got %v", err)
+		}
+	})
+
+	t.Run("Success - retries until attempts run out", func(t *testing.T) {
+		clock.Advance(time.Hour)
+		notifier.fail = 1
+
+		_, _ = uc.Execute(ctx)
+		second, _ := store.GetReminder(ctx, 2)
+		if second.Status != entity.ReminderPending || len(second.Attempts) != 1 || second.Attempts[0].Error == "" {
+			t.Errorf("expected one failed attempt, got %+v", second)
+		}
+
+		_, _ = uc.Execute(ctx)
+		second, _ = store.GetReminder(ctx, 2)
+		if second.Status != entity.ReminderSent || len(second.Attempts) != 2 {
+			t.Errorf("expected sent on the second attempt, got %+v", second)
+		}
+		unknown, _ := store.GetReminder(ctx, 6)
+		if unknown.Status != entity.ReminderFailed || len(unknown.Attempts) != 2 {
+			t.Errorf("expected failed after 2 attempts, got %+v", unknown)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+	"todo-api/internal/domain/query"
+)
+
+type RenameTagUC struct {
+	Storage   port.DataStorage
+	Clock     port.Clock
+	Publisher port.EventPublisher
+}
+
+func NewRenameTagUC(storage port.DataStorage, clock port.Clock, publisher port.EventPublisher) *RenameTagUC {
+	return &RenameTagUC{Storage: storage, Clock: clock, Publisher: publisher}
+}
+
+// Execute replaces in.Tag with in.Name on every todo. Renaming to a tag that
+// is already in use merges the two. Each todo is written on its own, so a
+// failure part way leaves the earlier todos renamed; repeating the request
+// finishes the job.
+func (uc *RenameTagUC) Execute(ctx context.Context, in dto.RenameTag) (dto.RenameTagResponse, error) {
+	from, err := normalizeTag(in.Tag)
+	if err != nil {
+		return dto.RenameTagResponse{}, err
+	}
+	to, err := normalizeTag(in.Name)
+	if err != nil {
+		return dto.RenameTagResponse{}, err
+	}
+
+	todos, err := uc.Storage.QueryTodos(ctx, query.Query{Filter: query.HasTag{Tag: from}})
+	if err != nil {
+		return dto.RenameTagResponse{}, uc_errors.Wrap(uc_errors.RenameTagError, err)
+	}
+	if len(todos) == 0 {
+		return dto.RenameTagResponse{}, uc_errors.TagNotFoundError
+	}
+
+	response := dto.RenameTagResponse{Tag: to}
+	if from == to {
+		return response, nil
+	}
+
+	rename := func(tags []string) ([]string, error) {
+		i, found := slices.BinarySearch(tags, from)
+		if !found {
+			return tags, nil
+		}
+		renamed := slices.Delete(slices.Clone(tags), i, i+1)
+		if j, exists := slices.BinarySearch(renamed, to); !exists {
+			renamed = slices.Insert(renamed, j, to)
+		}
+		return renamed, nil
+	}
+
+	for _, todo := range todos {
+		_, err := retag(ctx, uc.Storage, uc.Clock, uc.Publisher, todo.ID, 0, rename)
+		if errors.Is(err, uc_errors.TodoNotFoundError) {
+			continue
+		}
+		if err != nil {
+			return response, uc_errors.Wrap(uc_errors.RenameTagError, err)
+		}
+		response.Updated++
+	}
+
+	return response, nil
+}
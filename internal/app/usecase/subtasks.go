@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+	"todo-api/internal/domain/query"
+)
+
+// SubtaskCompletePolicy decides what happens when a todo is completed while
+// some of its subtasks are still open.
+type SubtaskCompletePolicy string
+
+const (
+	// SubtaskCompleteAllow completes the todo and leaves the subtasks open.
+	SubtaskCompleteAllow SubtaskCompletePolicy = "allow"
+	// SubtaskCompleteRefuse fails with uc_errors.OpenSubtasksError.
+	SubtaskCompleteRefuse SubtaskCompletePolicy = "refuse"
+	// SubtaskCompleteCascade completes every open descendant first, together
+	// with the todo: a write that fails undoes the ones before it.
+	SubtaskCompleteCascade SubtaskCompletePolicy = "cascade"
+)
+
+func ParseSubtaskCompletePolicy(s string) (SubtaskCompletePolicy, error) {
+	switch p := SubtaskCompletePolicy(s); p {
+	case SubtaskCompleteAllow, SubtaskCompleteRefuse, SubtaskCompleteCascade:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown subtask complete policy %q", s)
+	}
+}
+
+// cascadeWrite is an open descendant completed along with its ancestor:
+// the todo as stored, as it is to be stored, and its next occurrence.
+type cascadeWrite struct {
+	current, todo, next *entity.Todo
+}
+
+// beforeComplete applies the policy to the subtasks of todo id, which is
+// about to be completed. Cascading writes nothing: it returns the open
+// descendants, deepest first, completed and checked the way a write of each
+// would be, so that the caller stores them only once everything passed.
+func (p SubtaskCompletePolicy) beforeComplete(ctx context.Context, storage port.DataStorage, id int64) ([]cascadeWrite, error) {
+	switch p {
+	case SubtaskCompleteRefuse:
+		open, err := storage.CountTodos(ctx, query.And{
+			query.Predicate{Field: query.FieldParentID, Op: query.OpEq, Value: id},
+			query.Predicate{Field: query.FieldCompleted, Op: query.OpEq, Value: false},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if open > 0 {
+			return nil, uc_errors.OpenSubtasksError
+		}
+	case SubtaskCompleteCascade:
+		levels, err := descendants(ctx, storage, id)
+		if err != nil {
+			return nil, err
+		}
+
+		// Blockers completed by the same cascade do not count as open.
+		completing := map[int64]bool{id: true}
+		for _, level := range levels {
+			for _, todo := range level {
+				completing[todo.ID] = true
+			}
+		}
+
+		var writes []cascadeWrite
+		for i := len(levels) - 1; i >= 0; i-- {
+			for _, current := range levels[i] {
+				if current.Completed {
+					continue
+				}
+				var blockers []int64
+				for _, blocker := range current.BlockedBy {
+					if !completing[blocker] {
+						blockers = append(blockers, blocker)
+					}
+				}
+				if open, err := isBlocked(ctx, storage, blockers); err != nil || open {
+					if err == nil {
+						err = uc_errors.BlockedTodoError
+					}
+					return nil, err
+				}
+
+				todo := *current
+				todo.Completed = true
+				next, err := completeOccurrence(current, &todo)
+				if err != nil {
+					return nil, err
+				}
+				writes = append(writes, cascadeWrite{current: current, todo: &todo, next: next})
+			}
+		}
+		return writes, nil
+	}
+	return nil, nil
+}
+
+// checkSubtasks guards the hierarchy when current is about to be replaced by
+// next: a new parent must exist and must not make a cycle, and completing
+// applies policy to the subtasks. It returns the descendants to complete
+// first.
+func checkSubtasks(
+	ctx context.Context,
+	storage port.DataStorage,
+	policy SubtaskCompletePolicy,
+	current, next *entity.Todo,
+) ([]cascadeWrite, error) {
+	if next.ParentID != current.ParentID {
+		if err := checkParentRef(ctx, storage, current.ID, next.ParentID); err != nil {
+			return nil, err
+		}
+	}
+	if next.Completed && !current.Completed {
+		return policy.beforeComplete(ctx, storage, current.ID)
+	}
+	return nil, nil
+}
+
+func isSubtaskError(err error) bool {
+	return errors.Is(err, uc_errors.UnknownParentError) ||
+		errors.Is(err, uc_errors.ParentCycleError) ||
+		errors.Is(err, uc_errors.OpenSubtasksError)
+}
+
+// checkParentRef verifies that parentID, when set, names an existing todo
+// that is neither todo id nor one of its descendants.
+func checkParentRef(ctx context.Context, storage port.DataStorage, id, parentID int64) error {
+	if parentID == 0 {
+		return nil
+	}
+	if parentID < 0 {
+		return uc_errors.UnknownParentError
+	}
+
+	seen := make(map[int64]bool)
+	for ancestor := parentID; ancestor != 0 && !seen[ancestor]; {
+		if ancestor == id {
+			return uc_errors.ParentCycleError
+		}
+		seen[ancestor] = true
+
+		todo, err := storage.GetTodo(ctx, ancestor)
+		if errors.Is(err, uc_errors.TodoNotFoundError) {
+			if ancestor == parentID {
+				return uc_errors.UnknownParentError
+			}
+			// The chain ends at a deleted todo higher up.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ancestor = todo.ParentID
+	}
+	return nil
+}
+
+// descendants returns the subtasks of todo id level by level, each level in
+// id order. It makes one query per level.
+func descendants(ctx context.Context, storage port.DataStorage, id int64) ([][]*entity.Todo, error) {
+	var levels [][]*entity.Todo
+
+	seen := map[int64]bool{id: true}
+	parents := []int64{id}
+	for len(parents) > 0 {
+		filter := make(query.Or, len(parents))
+		for i, parent := range parents {
+			filter[i] = query.Predicate{Field: query.FieldParentID, Op: query.OpEq, Value: parent}
+		}
+
+		todos, err := storage.QueryTodos(ctx, query.Query{Filter: filter})
+		if err != nil {
+			return nil, err
+		}
+
+		var level []*entity.Todo
+		parents = parents[:0]
+		for _, todo := range todos {
+			if seen[todo.ID] {
+				continue
+			}
+			seen[todo.ID] = true
+			level = append(level, todo)
+			parents = append(parents, todo.ID)
+		}
+		if len(level) > 0 {
+			levels = append(levels, level)
+		}
+	}
+	return levels, nil
+}
+
+// progress counts the direct subtasks of todo id; it is nil for todos
+// without any.
+func progress(ctx context.Context, storage port.DataStorage, id int64) (*dto.Progress, error) {
+	children := query.Predicate{Field: query.FieldParentID, Op: query.OpEq, Value: id}
+
+	total, err := storage.CountTodos(ctx, children)
+	if err != nil || total == 0 {
+		return nil, err
+	}
+	done, err := storage.CountTodos(ctx, query.And{
+		children,
+		query.Predicate{Field: query.FieldCompleted, Op: query.OpEq, Value: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Progress{Done: done, Total: total}, nil
+}
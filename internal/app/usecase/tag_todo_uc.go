@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+type AddTodoTagUC struct {
+	Storage   port.DataStorage
+	Clock     port.Clock
+	Publisher port.EventPublisher
+}
+
+func NewAddTodoTagUC(storage port.DataStorage, clock port.Clock, publisher port.EventPublisher) *AddTodoTagUC {
+	return &AddTodoTagUC{Storage: storage, Clock: clock, Publisher: publisher}
+}
+
+// Execute adds in.Tag to the todo; adding a tag it already has is a no-op.
+func (uc *AddTodoTagUC) Execute(ctx context.Context, in dto.TagTodo) (dto.TagTodoResponse, error) {
+	return executeTagTodo(ctx, uc.Storage, uc.Clock, uc.Publisher, in, func(tags []string, tag string) ([]string, error) {
+		i, found := slices.BinarySearch(tags, tag)
+		if found {
+			return tags, nil
+		}
+		if len(tags) >= MaxTodoTags {
+			return nil, uc_errors.TooManyTagsError
+		}
+		return slices.Insert(slices.Clone(tags), i, tag), nil
+	})
+}
+
+type RemoveTodoTagUC struct {
+	Storage   port.DataStorage
+	Clock     port.Clock
+	Publisher port.EventPublisher
+}
+
+func NewRemoveTodoTagUC(storage port.DataStorage, clock port.Clock, publisher port.EventPublisher) *RemoveTodoTagUC {
+	return &RemoveTodoTagUC{Storage: storage, Clock: clock, Publisher: publisher}
+}
+
+// Execute removes in.Tag from the todo; removing a tag it lacks is a no-op.
+func (uc *RemoveTodoTagUC) Execute(ctx context.Context, in dto.TagTodo) (dto.TagTodoResponse, error) {
+	return executeTagTodo(ctx, uc.Storage, uc.Clock, uc.Publisher, in, func(tags []string, tag string) ([]string, error) {
+		i, found := slices.BinarySearch(tags, tag)
+		if !found {
+			return tags, nil
+		}
+		return slices.Delete(slices.Clone(tags), i, i+1), nil
+	})
+}
+
+func executeTagTodo(
+	ctx context.Context,
+	storage port.DataStorage,
+	clock port.Clock,
+	publisher port.EventPublisher,
+	in dto.TagTodo,
+	change func(tags []string, tag string) ([]string, error),
+) (dto.TagTodoResponse, error) {
+	failed := dto.TagTodoResponse{GetTodoResponse: dto.GetTodoResponse{Todo: dto.Todo{ID: in.ID}}}
+	if in.ID <= 0 {
+		return failed, uc_errors.InvalidTodoIDError
+	}
+	tag, err := normalizeTag(in.Tag)
+	if err != nil {
+		return failed, err
+	}
+
+	todo, err := retag(ctx, storage, clock, publisher, in.ID, in.Version, func(tags []string) ([]string, error) {
+		return change(tags, tag)
+	})
+	if err != nil {
+		if !errors.Is(err, uc_errors.TodoNotFoundError) &&
+			!errors.Is(err, uc_errors.TodoVersionConflictError) &&
+			!errors.Is(err, uc_errors.TooManyTagsError) {
+			return failed, uc_errors.Wrap(uc_errors.TagTodoError, err)
+		}
+		return failed, err
+	}
+
+	view, err := viewTodo(ctx, storage, todo)
+	if err != nil {
+		return failed, uc_errors.Wrap(uc_errors.TagTodoError, err)
+	}
+	return dto.TagTodoResponse{GetTodoResponse: view}, nil
+}
@@ -0,0 +1,136 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/entity"
+)
+
+func TestTagTodoUC(t *testing.T) {
+	store := storage.NewDataStorage()
+	clock := newFakeClock()
+	add := usecase.NewAddTodoTagUC(store, clock, nil)
+	remove := usecase.NewRemoveTodoTagUC(store, clock, nil)
+	ctx := context.Background()
+
+	todo := entity.Todo{Title: "Buy milk", Tags: []string{"shop"}}
+	_ = store.CreateTodo(ctx, &todo)
+
+	t.Run("Success", func(t *testing.T) {
+		clock.Advance(time.Hour)
+		result, err := add.Execute(ctx, dto.TagTodo{ID: todo.ID, Tag: "  Home "})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !slices.Equal(result.Tags, []string{"home", "shop"}) {
+			t.Errorf("expected [home shop], got %v", result.Tags)
+		}
+		if result.Version != 2 || !result.UpdatedAt.Equal(clock.Now()) {
+			t.Errorf("expected version 2 updated now, got %d at %v", result.Version, result.UpdatedAt)
+		}
+	})
+
+	t.Run("Success - adding a present tag is a no-op", func(t *testing.T) {
+		result, err := add.Execute(ctx, dto.TagTodo{ID: todo.ID, Tag: "SHOP"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result.Version != 2 {
+			t.Errorf("expected version 2, got %d", result.Version)
+		}
+	})
+
+	t.Run("Success - remove", func(t *testing.T) {
+		result, err := remove.Execute(ctx, dto.TagTodo{ID: todo.ID, Tag: "shop"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !slices.Equal(result.Tags, []string{"home"}) {
+			t.Errorf("expected [home], got %v", result.Tags)
+		}
+	})
+
+	t.Run("Error - stale version", func(t *testing.T) {
+		in := dto.TagTodo{ID: todo.ID, Tag: "work", Version: 1}
+		if _, err := add.Execute(ctx, in); !errors.Is(err, uc_errors.TodoVersionConflictError) {
+			t.Errorf("expected TodoVersionConflictError, got %v", err)
+		}
+	})
+
+	t.Run("Error - invalid tag", func(t *testing.T) {
+		for _, tag := range []string{" ", strings.Repeat("x", usecase.MaxTagLength+1), "a\tb"} {
+			if _, err := add.Execute(ctx, dto.TagTodo{ID: todo.ID, Tag: tag}); !errors.Is(err, uc_errors.InvalidTagError) {
+				t.Errorf("tag %q: expected InvalidTagError, got %v", tag, err)
+			}
+		}
+	})
+
+	t.Run("Error - too many tags", func(t *testing.T) {
+		full := entity.Todo{Title: "Tagged"}
+		for i := range usecase.MaxTodoTags {
+			full.Tags = append(full.Tags, fmt.Sprintf("tag%02d", i))
+		}
+		_ = store.CreateTodo(ctx, &full)
+
+		if _, err := add.Execute(ctx, dto.TagTodo{ID: full.ID, Tag: "one-more"}); !errors.Is(err, uc_errors.TooManyTagsError) {
+			t.Errorf("expected TooManyTagsError, got %v", err)
+		}
+	})
+
+	t.Run("Error - not found", func(t *testing.T) {
+		if _, err := remove.Execute(ctx, dto.TagTodo{ID: 999, Tag: "home"}); !errors.Is(err, uc_errors.TodoNotFoundError) {
+			t.Errorf("expected TodoNotFoundError, got %v", err)
+		}
+	})
+}
+
+func TestRenameTagUC(t *testing.T) {
+	store := storage.NewDataStorage()
+	uc := usecase.NewRenameTagUC(store, newFakeClock(), nil)
+	ctx := context.Background()
+
+	todos := []entity.Todo{
+		{Title: "Buy milk", Tags: []string{"groceries", "shop"}},
+		{Title: "Buy bread", Tags: []string{"groceries"}},
+		{Title: "Fix sink", Tags: []string{"home"}},
+	}
+	for i := range todos {
+		_ = store.CreateTodo(ctx, &todos[i])
+	}
+
+	t.Run("Success - merge", func(t *testing.T) {
+		result, err := uc.Execute(ctx, dto.RenameTag{Tag: "Groceries", Name: "shop"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result.Tag != "shop" || result.Updated != 2 {
+			t.Errorf("expected 2 todos moved to shop, got %+v", result)
+		}
+
+		tags, _ := store.ListTags(ctx)
+		if fmt.Sprint(tags) != "[{home 1} {shop 2}]" {
+			t.Errorf("expected home and shop, got %v", tags)
+		}
+	})
+
+	t.Run("Error - unknown tag", func(t *testing.T) {
+		if _, err := uc.Execute(ctx, dto.RenameTag{Tag: "groceries", Name: "food"}); !errors.Is(err, uc_errors.TagNotFoundError) {
+			t.Errorf("expected TagNotFoundError, got %v", err)
+		}
+	})
+
+	t.Run("Error - invalid name", func(t *testing.T) {
+		if _, err := uc.Execute(ctx, dto.RenameTag{Tag: "home", Name: ""}); !errors.Is(err, uc_errors.InvalidTagError) {
+			t.Errorf("expected InvalidTagError, got %v", err)
+		}
+	})
+}
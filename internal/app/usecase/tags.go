@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+	"todo-api/internal/domain/query"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	MaxTagLength = 32
+	MaxTodoTags  = 20
+)
+
+// normalizeTag trims and case-folds a tag so that "Home " and "home" are the
+// same tag everywhere, including in storage indexes.
+func normalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength || strings.ContainsFunc(tag, unicode.IsControl) {
+		return "", uc_errors.InvalidTagError
+	}
+	return tag, nil
+}
+
+// normalizeTags returns the tags normalised, sorted and without duplicates.
+func normalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag, err := normalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, tag)
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized), nil
+}
+
+// normalizeTodoTags normalises the tags a client sent for one todo.
+func normalizeTodoTags(tags []string) ([]string, error) {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) > MaxTodoTags {
+		return nil, uc_errors.TooManyTagsError
+	}
+	return tags, nil
+}
+
+// tagsFilter matches todos with all of tags, or with any of them.
+func tagsFilter(tags []string, anyTag bool) (query.Filter, error) {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := make([]query.Filter, len(tags))
+	for i, tag := range tags {
+		filters[i] = query.HasTag{Tag: tag}
+	}
+	if anyTag {
+		return query.Or(filters), nil
+	}
+	return query.And(filters), nil
+}
+
+// retag replaces the tags of one todo with change(tags), which must return a
+// new sorted slice; see modifyTodo.
+func retag(
+	ctx context.Context,
+	storage port.DataStorage,
+	clock port.Clock,
+	publisher port.EventPublisher,
+	id, version int64,
+	change func(tags []string) ([]string, error),
+) (*entity.Todo, error) {
+	return modifyTodo(ctx, storage, clock, publisher, id, version, func(todo *entity.Todo) (bool, error) {
+		tags, err := change(todo.Tags)
+		if err != nil || slices.Equal(tags, todo.Tags) {
+			return false, err
+		}
+		todo.Tags = tags
+		return true, nil
+	})
+}
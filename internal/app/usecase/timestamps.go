@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"time"
+	"todo-api/internal/domain/entity"
+)
+
+// writeAttempts bounds how often a read-modify-write without a client version
+// is retried when a concurrent write wins the race between load and store.
+const writeAttempts = 3
+
+// stamp sets the timestamps the server maintains on a todo about to be
+// written. before is the stored todo, or nil on create.
+func stamp(todo *entity.Todo, before *entity.Todo, now time.Time) {
+	todo.CreatedAt = now
+	if before != nil {
+		todo.CreatedAt = before.CreatedAt
+	}
+	todo.UpdatedAt = now
+
+	switch {
+	case !todo.Completed:
+		todo.CompletedAt = time.Time{}
+	case before != nil && before.Completed:
+		todo.CompletedAt = before.CompletedAt
+	default:
+		todo.CompletedAt = now
+	}
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/port"
+)
+
+// todoWriter is the write pipeline shared by UpdateTodoUC and PatchTodoUC,
+// which only differ in how they arrive at the edited todo.
+type todoWriter struct {
+	Storage   port.DataStorage
+	Projects  port.ProjectStorage
+	Clock     port.Clock
+	Subtasks  SubtaskCompletePolicy
+	Publisher port.EventPublisher
+
+	// failure wraps the unexpected errors.
+	failure error
+}
+
+// applyWrite checks todo against current, the stored todo it replaces, and
+// stores it guarded by the version of current. When that completes a
+// recurring todo it also creates the next occurrence and returns it.
+func (w *todoWriter) applyWrite(ctx context.Context, current, todo *entity.Todo) (*entity.Todo, error) {
+	// The project is only checked when it changes, so that todos left in a
+	// project being deleted can still be edited.
+	if todo.ProjectID != current.ProjectID {
+		if err := checkProjectRef(ctx, w.Projects, todo.ProjectID); err != nil {
+			if !errors.Is(err, uc_errors.UnknownProjectError) {
+				return nil, uc_errors.Wrap(w.failure, err)
+			}
+			return nil, err
+		}
+	}
+	if err := checkBlockers(ctx, w.Storage, current, todo); err != nil {
+		if !isBlockerError(err) {
+			return nil, uc_errors.Wrap(w.failure, err)
+		}
+		return nil, err
+	}
+	cascade, err := checkSubtasks(ctx, w.Storage, w.Subtasks, current, todo)
+	if err != nil {
+		if !isSubtaskError(err) && !isBlockerError(err) {
+			return nil, uc_errors.Wrap(w.failure, err)
+		}
+		return nil, err
+	}
+	next, err := completeOccurrence(current, todo)
+	if err != nil {
+		return nil, uc_errors.Wrap(w.failure, err)
+	}
+
+	if len(cascade) > 0 {
+		// The subtasks are completed first, so they must not be written for
+		// a todo whose own write is bound to fail.
+		stored, err := w.Storage.GetTodo(ctx, current.ID)
+		if err != nil {
+			if !errors.Is(err, uc_errors.TodoNotFoundError) {
+				return nil, uc_errors.Wrap(w.failure, err)
+			}
+			return nil, err
+		}
+		if stored.Version != current.Version {
+			return nil, uc_errors.TodoVersionConflictError
+		}
+	}
+
+	// A write that fails undoes the ones before it, so the cascade and the
+	// todo are stored together or not at all, and nothing is published
+	// until they all are.
+	writes := append(cascade, cascadeWrite{current: current, todo: todo, next: next})
+	var done []cascadeWrite
+	for i, write := range writes {
+		if err := w.store(ctx, write.current, write.todo, write.next); err != nil {
+			if i < len(cascade) && errors.Is(err, uc_errors.TodoNotFoundError) {
+				continue // deleted meanwhile, so there is nothing to complete
+			}
+			if undoErr := w.undo(ctx, done); undoErr != nil {
+				return nil, uc_errors.Wrap(w.failure, errors.Join(err, undoErr))
+			}
+			if !errors.Is(err, uc_errors.TodoNotFoundError) && !errors.Is(err, uc_errors.TodoVersionConflictError) {
+				return nil, uc_errors.Wrap(w.failure, err)
+			}
+			return nil, err
+		}
+		done = append(done, write)
+	}
+
+	for _, write := range done {
+		publishWrite(ctx, w.Publisher, write.current, write.todo)
+		if write.next != nil {
+			publishWrite(ctx, w.Publisher, nil, write.next)
+		}
+	}
+	return next, nil
+}
+
+// store writes todo over current, guarded by the version of current, along
+// with next, the occurrence that follows it, if any. The next occurrence is
+// created first and removed again when the update fails, so a series is
+// never left without its open occurrence.
+func (w *todoWriter) store(ctx context.Context, current, todo, next *entity.Todo) error {
+	now := w.Clock.Now()
+	stamp(todo, current, now)
+
+	if next != nil {
+		stamp(next, nil, now)
+		if err := w.Storage.CreateTodo(ctx, next); err != nil {
+			return err
+		}
+	}
+	if err := w.Storage.UpdateTodo(ctx, todo, current.Version); err != nil {
+		if next != nil {
+			if delErr := w.Storage.DeleteTodo(ctx, next.ID, 0); delErr != nil {
+				err = errors.Join(err, delErr)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// undo puts back what store wrote for writes, the latest first.
+func (w *todoWriter) undo(ctx context.Context, writes []cascadeWrite) error {
+	var errs []error
+	for i := len(writes) - 1; i >= 0; i-- {
+		write := writes[i]
+		if write.next != nil {
+			if err := w.Storage.DeleteTodo(ctx, write.next.ID, 0); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		restored := *write.current
+		if err := w.Storage.UpdateTodo(ctx, &restored, write.todo.Version); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+type UpdateProjectUC struct {
+	Projects port.ProjectStorage
+	Clock    port.Clock
+}
+
+func NewUpdateProjectUC(projects port.ProjectStorage, clock port.Clock) *UpdateProjectUC {
+	return &UpdateProjectUC{Projects: projects, Clock: clock}
+}
+
+func (uc *UpdateProjectUC) Execute(ctx context.Context, in dto.UpdateProject) (dto.UpdateProjectResponse, error) {
+	failed := dto.UpdateProjectResponse{Project: dto.Project{ID: in.ID}}
+	if in.ID <= 0 {
+		return failed, uc_errors.InvalidProjectIDError
+	}
+	if strings.TrimSpace(in.Name) == "" {
+		return failed, uc_errors.EmptyProjectNameError
+	}
+
+	for attempt := 1; ; attempt++ {
+		out, err := uc.update(ctx, in)
+		if errors.Is(err, uc_errors.ProjectVersionConflictError) && in.Version == 0 && attempt < writeAttempts {
+			continue
+		}
+		if err != nil && !errors.Is(err, uc_errors.ProjectNotFoundError) && !errors.Is(err, uc_errors.ProjectVersionConflictError) {
+			return failed, uc_errors.Wrap(uc_errors.UpdateProjectError, err)
+		}
+		return out, err
+	}
+}
+
+func (uc *UpdateProjectUC) update(ctx context.Context, in dto.UpdateProject) (dto.UpdateProjectResponse, error) {
+	current, err := uc.Projects.GetProject(ctx, in.ID)
+	if err != nil {
+		return dto.UpdateProjectResponse{Project: dto.Project{ID: in.ID}}, err
+	}
+
+	expectedVersion := in.Version
+	if expectedVersion == 0 {
+		expectedVersion = current.Version
+	}
+
+	project := mappers.MapProjectDTOToDomainProject(in.Project)
+	project.CreatedAt = current.CreatedAt
+	project.UpdatedAt = uc.Clock.Now()
+
+	if err := uc.Projects.UpdateProject(ctx, project, expectedVersion); err != nil {
+		return dto.UpdateProjectResponse{Project: dto.Project{ID: in.ID}}, err
+	}
+
+	return dto.UpdateProjectResponse{Project: mappers.MapDomainProjectToProjectDTO(project)}, nil
+}
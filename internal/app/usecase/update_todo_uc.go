@@ -10,11 +10,24 @@ import (
 )
 
 type UpdateTodoUC struct {
-	Storage port.DataStorage
+	todoWriter
 }
 
-func NewUpdateTodoUC(storage port.DataStorage) *UpdateTodoUC {
-	return &UpdateTodoUC{Storage: storage}
+func NewUpdateTodoUC(
+	storage port.DataStorage,
+	projects port.ProjectStorage,
+	clock port.Clock,
+	publisher port.EventPublisher,
+	subtasks SubtaskCompletePolicy,
+) *UpdateTodoUC {
+	return &UpdateTodoUC{todoWriter{
+		Storage:   storage,
+		Projects:  projects,
+		Clock:     clock,
+		Subtasks:  subtasks,
+		Publisher: publisher,
+		failure:   uc_errors.UpdateTodoError,
+	}}
 }
 
 func (uc *UpdateTodoUC) Execute(ctx context.Context, in dto.UpdateTodo) (dto.UpdateTodoResponse, error) {
@@ -24,18 +37,62 @@ func (uc *UpdateTodoUC) Execute(ctx context.Context, in dto.UpdateTodo) (dto.Upd
 	if in.Title == "" {
 		return dto.UpdateTodoResponse{ID: in.ID}, uc_errors.EmptyTitleError
 	}
+	if err := validateSchedule(in.Todo); err != nil {
+		return dto.UpdateTodoResponse{ID: in.ID}, err
+	}
+	if err := validatePriority(in.Todo); err != nil {
+		return dto.UpdateTodoResponse{ID: in.ID}, err
+	}
+	if err := normalizeRecurrence(&in.Todo); err != nil {
+		return dto.UpdateTodoResponse{ID: in.ID}, err
+	}
+	tags, err := normalizeTodoTags(in.Tags)
+	if err != nil {
+		return dto.UpdateTodoResponse{ID: in.ID}, err
+	}
+	in.Tags = tags
+	in.BlockedBy = normalizeBlockers(in.BlockedBy)
 
-	todo := mappers.MapTodoDTOToDomainTodo(in.Todo)
+	// A replacement is not retried on a conflict, not even without a
+	// version: it was built without seeing the concurrent write, and
+	// storing it anyway would silently undo that write.
+	return uc.update(ctx, in)
+}
 
-	if err := uc.Storage.UpdateTodo(ctx, todo); err != nil {
+// update carries the timestamps over from the stored todo, so the write is
+// guarded by the version that was read even when the client sent none.
+func (uc *UpdateTodoUC) update(ctx context.Context, in dto.UpdateTodo) (dto.UpdateTodoResponse, error) {
+	current, err := uc.Storage.GetTodo(ctx, in.ID)
+	if err != nil {
 		if !errors.Is(err, uc_errors.TodoNotFoundError) {
-			return dto.UpdateTodoResponse{ID: todo.ID}, uc_errors.Wrap(uc_errors.UpdateTodoError, err)
+			return dto.UpdateTodoResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.UpdateTodoError, err)
 		}
-		return dto.UpdateTodoResponse{ID: todo.ID}, err
+		return dto.UpdateTodoResponse{ID: in.ID}, err
+	}
+
+	// Checked early so that a stale request does not cascade to subtasks.
+	if in.Version != 0 && current.Version != in.Version {
+		return dto.UpdateTodoResponse{ID: in.ID}, uc_errors.TodoVersionConflictError
+	}
+
+	todo := mappers.MapTodoDTOToDomainTodo(in.Todo)
+	next, err := uc.applyWrite(ctx, current, todo)
+	if err != nil {
+		return dto.UpdateTodoResponse{ID: in.ID}, err
 	}
 
-	return dto.UpdateTodoResponse{
+	view, err := viewTodo(ctx, uc.Storage, todo)
+	if err != nil {
+		return dto.UpdateTodoResponse{ID: in.ID}, uc_errors.Wrap(uc_errors.UpdateTodoError, err)
+	}
+	out := dto.UpdateTodoResponse{
 		ID:      todo.ID,
 		Updated: true,
-	}, nil
+		Version: todo.Version,
+		View:    view,
+	}
+	if next != nil {
+		out.NextID = next.ID
+	}
+	return out, nil
 }
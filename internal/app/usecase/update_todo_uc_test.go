@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 	"todo-api/internal/adapter/out/storage"
 	"todo-api/internal/app/dto"
 	"todo-api/internal/app/uc_errors"
@@ -11,9 +12,40 @@ import (
 	"todo-api/internal/domain/entity"
 )
 
+// concurrentWrite changes todo id right after it is first read.
+type concurrentWrite struct {
+	*storage.DataStorage
+	id   int64
+	done bool
+}
+
+func (s *concurrentWrite) GetTodo(ctx context.Context, id int64) (*entity.Todo, error) {
+	todo, err := s.DataStorage.GetTodo(ctx, id)
+	if err == nil && id == s.id && !s.done {
+		s.done = true
+		changed := *todo
+		changed.Description = "changed meanwhile"
+		_ = s.DataStorage.UpdateTodo(ctx, &changed, 0)
+	}
+	return todo, err
+}
+
+// conflictOn fails the updates of todo id with a version conflict.
+type conflictOn struct {
+	*storage.DataStorage
+	id int64
+}
+
+func (s conflictOn) UpdateTodo(ctx context.Context, todo *entity.Todo, version int64) error {
+	if todo.ID == s.id {
+		return uc_errors.TodoVersionConflictError
+	}
+	return s.DataStorage.UpdateTodo(ctx, todo, version)
+}
+
 func TestUpdateTodoUC(t *testing.T) {
 	store := storage.NewDataStorage()
-	uc := usecase.NewUpdateTodoUC(store)
+	uc := usecase.NewUpdateTodoUC(store, store, newFakeClock(), nil, usecase.SubtaskCompleteAllow)
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -64,6 +96,39 @@ func TestUpdateTodoUC(t *testing.T) {
 		}
 	})
 
+	t.Run("Error - version conflict", func(t *testing.T) {
+		todo := &entity.Todo{Title: "Wash clothes"}
+		_ = store.CreateTodo(ctx, todo)
+
+		first := dto.UpdateTodo{Todo: dto.Todo{ID: todo.ID, Title: "Wash dishes", Version: todo.Version}}
+		result, err := uc.Execute(ctx, first)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result.Version != todo.Version+1 {
+			t.Errorf("expected version %d, got %d", todo.Version+1, result.Version)
+		}
+
+		second := dto.UpdateTodo{Todo: dto.Todo{ID: todo.ID, Title: "Wash windows", Version: todo.Version}}
+		if _, err := uc.Execute(ctx, second); !errors.Is(err, uc_errors.TodoVersionConflictError) {
+			t.Errorf("expected TodoVersionConflictError, got %v", err)
+		}
+	})
+
+	t.Run("Error - concurrent write without a version", func(t *testing.T) {
+		concurrent := &concurrentWrite{DataStorage: storage.NewDataStorage(), id: 1}
+		_ = concurrent.CreateTodo(ctx, &entity.Todo{Title: "Wash clothes"})
+		uc := usecase.NewUpdateTodoUC(concurrent, concurrent, newFakeClock(), nil, usecase.SubtaskCompleteAllow)
+
+		in := dto.UpdateTodo{Todo: dto.Todo{ID: 1, Title: "Wash dishes"}}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.TodoVersionConflictError) {
+			t.Fatalf("expected TodoVersionConflictError, got %v", err)
+		}
+		if todo, _ := concurrent.DataStorage.GetTodo(ctx, 1); todo.Description != "changed meanwhile" {
+			t.Errorf("expected the concurrent write kept, got %+v", todo)
+		}
+	})
+
 	t.Run("Error - canceled context", func(t *testing.T) {
 		cancelCtx, cancel := context.WithCancel(context.Background())
 		cancel()
@@ -74,3 +139,247 @@ func TestUpdateTodoUC(t *testing.T) {
 		}
 	})
 }
+
+func TestUpdateTodoUC_Timestamps(t *testing.T) {
+	store := storage.NewDataStorage()
+	clock := newFakeClock()
+	create := usecase.NewCreateTodoUC(store, store, clock, nil)
+	update := usecase.NewUpdateTodoUC(store, store, clock, nil, usecase.SubtaskCompleteAllow)
+	patch := usecase.NewPatchTodoUC(store, store, clock, nil, usecase.SubtaskCompleteAllow)
+	ctx := context.Background()
+
+	created, err := create.Execute(ctx, dto.CreateTodo{Todo: dto.Todo{Title: "Wash clothes"}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	createdAt := clock.Now()
+
+	check := func(t *testing.T, updatedAt, completedAt time.Time) {
+		t.Helper()
+
+		todo, _ := store.GetTodo(ctx, created.ID)
+		if !todo.CreatedAt.Equal(createdAt) {
+			t.Errorf("expected created_at %v, got %v", createdAt, todo.CreatedAt)
+		}
+		if !todo.UpdatedAt.Equal(updatedAt) {
+			t.Errorf("expected updated_at %v, got %v", updatedAt, todo.UpdatedAt)
+		}
+		if !todo.CompletedAt.Equal(completedAt) {
+			t.Errorf("expected completed_at %v, got %v", completedAt, todo.CompletedAt)
+		}
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		check(t, createdAt, time.Time{})
+	})
+
+	clock.Advance(time.Hour)
+	completedAt := clock.Now()
+
+	t.Run("Completing stamps completed_at", func(t *testing.T) {
+		in := dto.UpdateTodo{Todo: dto.Todo{ID: created.ID, Title: "Wash clothes", Completed: true}}
+		if _, err := update.Execute(ctx, in); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		check(t, completedAt, completedAt)
+	})
+
+	clock.Advance(time.Hour)
+
+	t.Run("Client timestamps are ignored", func(t *testing.T) {
+		forged := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+		in := dto.UpdateTodo{Todo: dto.Todo{
+			ID: created.ID, Title: "Wash dishes", Completed: true,
+			CreatedAt: &forged, UpdatedAt: &forged, CompletedAt: &forged,
+		}}
+		if _, err := update.Execute(ctx, in); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		check(t, clock.Now(), completedAt)
+	})
+
+	clock.Advance(time.Hour)
+
+	t.Run("Reopening clears completed_at", func(t *testing.T) {
+		in := dto.PatchTodo{ID: created.ID, Format: dto.PatchFormatMerge, Patch: []byte(`{"completed": false}`)}
+		if _, err := patch.Execute(ctx, in); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		check(t, clock.Now(), time.Time{})
+	})
+
+	t.Run("Error - patching a timestamp", func(t *testing.T) {
+		in := dto.PatchTodo{ID: created.ID, Format: dto.PatchFormatMerge, Patch: []byte(`{"created_at": "2000-01-01T00:00:00Z"}`)}
+		if _, err := patch.Execute(ctx, in); !errors.Is(err, uc_errors.ReadOnlyFieldError) {
+			t.Errorf("expected ReadOnlyFieldError, got %v", err)
+		}
+	})
+}
+
+func TestUpdateTodoUC_Subtasks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Error - parent cycle", func(t *testing.T) {
+		store := newSubtaskStore()
+		uc := usecase.NewUpdateTodoUC(store, store, newFakeClock(), nil, usecase.SubtaskCompleteAllow)
+
+		for _, parentID := range []int64{1, 2, 4} {
+			in := dto.UpdateTodo{Todo: dto.Todo{ID: 1, Title: "Move out", ParentID: parentID}}
+			if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.ParentCycleError) {
+				t.Errorf("parent %d: expected ParentCycleError, got %v", parentID, err)
+			}
+		}
+	})
+
+	t.Run("Error - unknown parent", func(t *testing.T) {
+		store := newSubtaskStore()
+		create := usecase.NewCreateTodoUC(store, store, newFakeClock(), nil)
+
+		in := dto.CreateTodo{Todo: dto.Todo{Title: "Sell sofa", ParentID: 42}}
+		if _, err := create.Execute(ctx, in); !errors.Is(err, uc_errors.UnknownParentError) {
+			t.Errorf("expected UnknownParentError, got %v", err)
+		}
+	})
+
+	t.Run("Success - re-parent", func(t *testing.T) {
+		store := newSubtaskStore()
+		uc := usecase.NewUpdateTodoUC(store, store, newFakeClock(), nil, usecase.SubtaskCompleteAllow)
+
+		in := dto.UpdateTodo{Todo: dto.Todo{ID: 5, Title: "Pack dishes", ParentID: 3}}
+		if _, err := uc.Execute(ctx, in); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	})
+
+	complete := func(uc *usecase.UpdateTodoUC) error {
+		_, err := uc.Execute(ctx, dto.UpdateTodo{Todo: dto.Todo{ID: 1, Title: "Move out", Completed: true}})
+		return err
+	}
+
+	t.Run("Error - refuse with open subtasks", func(t *testing.T) {
+		store := newSubtaskStore()
+		uc := usecase.NewUpdateTodoUC(store, store, newFakeClock(), nil, usecase.SubtaskCompleteRefuse)
+
+		if err := complete(uc); !errors.Is(err, uc_errors.OpenSubtasksError) {
+			t.Errorf("expected OpenSubtasksError, got %v", err)
+		}
+	})
+
+	t.Run("Success - cascade", func(t *testing.T) {
+		store := newSubtaskStore()
+		uc := usecase.NewUpdateTodoUC(store, store, newFakeClock(), nil, usecase.SubtaskCompleteCascade)
+
+		if err := complete(uc); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		for id := int64(1); id <= 5; id++ {
+			if todo, _ := store.GetTodo(ctx, id); !todo.Completed {
+				t.Errorf("expected todo %d completed", id)
+			}
+		}
+		if todo, _ := store.GetTodo(ctx, 5); todo.CompletedAt.IsZero() {
+			t.Error("expected the cascade to stamp completed_at")
+		}
+	})
+
+	t.Run("Error - cascade to a blocked subtask", func(t *testing.T) {
+		store := newSubtaskStore()
+		_ = store.CreateTodo(ctx, &entity.Todo{Title: "Buy boxes"})
+		dishes, _ := store.GetTodo(ctx, 5)
+		dishes.BlockedBy = []int64{6}
+		_ = store.UpdateTodo(ctx, dishes, 0)
+		uc := usecase.NewUpdateTodoUC(store, store, newFakeClock(), nil, usecase.SubtaskCompleteCascade)
+
+		if err := complete(uc); !errors.Is(err, uc_errors.BlockedTodoError) {
+			t.Fatalf("expected BlockedTodoError, got %v", err)
+		}
+		if todo, _ := store.GetTodo(ctx, 2); todo.Completed {
+			t.Error("expected no subtask completed")
+		}
+	})
+
+	t.Run("Success - cascade to a subtask blocked within the cascade", func(t *testing.T) {
+		store := newSubtaskStore()
+		dishes, _ := store.GetTodo(ctx, 5)
+		dishes.BlockedBy = []int64{1, 2}
+		_ = store.UpdateTodo(ctx, dishes, 0)
+		uc := usecase.NewUpdateTodoUC(store, store, newFakeClock(), nil, usecase.SubtaskCompleteCascade)
+
+		if err := complete(uc); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("Success - cascade to a recurring subtask", func(t *testing.T) {
+		store := newSubtaskStore()
+		dishes, _ := store.GetTodo(ctx, 5)
+		dishes.DueAt = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+		dishes.Recurrence = entity.Recurrence{Rule: "FREQ=DAILY"}
+		_ = store.UpdateTodo(ctx, dishes, 0)
+		uc := usecase.NewUpdateTodoUC(store, store, newFakeClock(), nil, usecase.SubtaskCompleteCascade)
+
+		if err := complete(uc); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		next, err := store.GetTodo(ctx, 6)
+		if err != nil {
+			t.Fatalf("expected the next occurrence, got %v", err)
+		}
+		if next.Completed || next.ParentID != 2 || !next.DueAt.Equal(dishes.DueAt.AddDate(0, 0, 1)) {
+			t.Errorf("unexpected next occurrence %+v", next)
+		}
+	})
+
+	t.Run("Error - cascade under a concurrent write", func(t *testing.T) {
+		store := &concurrentWrite{DataStorage: newSubtaskStore(), id: 1}
+		uc := usecase.NewUpdateTodoUC(store, store, newFakeClock(), nil, usecase.SubtaskCompleteCascade)
+		parent, _ := store.DataStorage.GetTodo(ctx, 1)
+
+		in := dto.UpdateTodo{Todo: dto.Todo{ID: 1, Title: "Move out", Completed: true, Version: parent.Version}}
+		if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.TodoVersionConflictError) {
+			t.Fatalf("expected TodoVersionConflictError, got %v", err)
+		}
+		if todo, _ := store.GetTodo(ctx, 5); todo.Completed {
+			t.Error("expected no subtask completed")
+		}
+	})
+
+	t.Run("Error - cascade undone when a later write fails", func(t *testing.T) {
+		// The cascade writes todo 5 and then todo 2, before the parent.
+		for _, failing := range []int64{2, 1} {
+			store := newSubtaskStore()
+			dishes, _ := store.GetTodo(ctx, 5)
+			dishes.DueAt = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+			dishes.Recurrence = entity.Recurrence{Rule: "FREQ=DAILY"}
+			_ = store.UpdateTodo(ctx, dishes, 0)
+			events := &recordedEvents{}
+			uc := usecase.NewUpdateTodoUC(conflictOn{store, failing}, store, newFakeClock(), events, usecase.SubtaskCompleteCascade)
+
+			in := dto.UpdateTodo{Todo: dto.Todo{ID: 1, Title: "Move out", Completed: true, Version: 1}}
+			if _, err := uc.Execute(ctx, in); !errors.Is(err, uc_errors.TodoVersionConflictError) {
+				t.Fatalf("failing %d: expected TodoVersionConflictError, got %v", failing, err)
+			}
+			if todo, _ := store.GetTodo(ctx, 5); todo.Completed || todo.Recurrence.IsZero() {
+				t.Errorf("failing %d: expected todo 5 restored, got %+v", failing, todo)
+			}
+			if _, err := store.GetTodo(ctx, 6); !errors.Is(err, uc_errors.TodoNotFoundError) {
+				t.Errorf("failing %d: expected the next occurrence removed, got %v", failing, err)
+			}
+			if len(events.events) != 0 {
+				t.Errorf("failing %d: expected no events, got %v", failing, events.types())
+			}
+		}
+	})
+
+	t.Run("Success - allow", func(t *testing.T) {
+		store := newSubtaskStore()
+		uc := usecase.NewUpdateTodoUC(store, store, newFakeClock(), nil, usecase.SubtaskCompleteAllow)
+
+		if err := complete(uc); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if todo, _ := store.GetTodo(ctx, 5); todo.Completed {
+			t.Error("expected the subtask to stay open")
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/mappers"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/port"
+)
+
+type UpdateWebhookUC struct {
+	Webhooks port.WebhookStorage
+	Clock    port.Clock
+}
+
+func NewUpdateWebhookUC(webhooks port.WebhookStorage, clock port.Clock) *UpdateWebhookUC {
+	return &UpdateWebhookUC{Webhooks: webhooks, Clock: clock}
+}
+
+// Execute replaces the URL and the events; the secret only changes when a
+// new one is sent, and is then echoed back.
+func (uc *UpdateWebhookUC) Execute(ctx context.Context, in dto.UpdateWebhook) (dto.UpdateWebhookResponse, error) {
+	if in.ID <= 0 {
+		return dto.UpdateWebhookResponse{}, uc_errors.InvalidWebhookIDError
+	}
+	events, err := checkWebhook(in.Webhook)
+	if err != nil {
+		return dto.UpdateWebhookResponse{}, err
+	}
+
+	webhook, err := uc.Webhooks.GetWebhook(ctx, in.ID)
+	if err == nil {
+		webhook.URL = in.URL
+		webhook.Events = events
+		if in.Secret != "" {
+			webhook.Secret = in.Secret
+		}
+		webhook.UpdatedAt = uc.Clock.Now()
+		err = uc.Webhooks.UpdateWebhook(ctx, webhook)
+	}
+	if err != nil {
+		if !errors.Is(err, uc_errors.WebhookNotFoundError) {
+			return dto.UpdateWebhookResponse{}, uc_errors.Wrap(uc_errors.UpdateWebhookError, err)
+		}
+		return dto.UpdateWebhookResponse{}, err
+	}
+
+	out := dto.UpdateWebhookResponse{Webhook: mappers.MapDomainWebhookToWebhookDTO(webhook)}
+	out.Secret = in.Secret
+	return out, nil
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+)
+
+func validateSchedule(todo dto.Todo) error {
+	if todo.StartAt != nil && todo.DueAt != nil && todo.StartAt.After(*todo.DueAt) {
+		return uc_errors.InvalidScheduleError
+	}
+	return nil
+}
+
+func validatePriority(todo dto.Todo) error {
+	if todo.Priority == "" {
+		return nil
+	}
+	if _, ok := entity.ParsePriority(todo.Priority); !ok {
+		return uc_errors.InvalidPriorityError
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"todo-api/internal/adapter/out/storage"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/app/usecase"
+	"todo-api/internal/domain/entity"
+)
+
+type fakeSender struct {
+	replayed []string
+}
+
+func (s *fakeSender) Replay(_ context.Context, _ *entity.Webhook, letter *entity.DeadLetter) error {
+	s.replayed = append(s.replayed, letter.EventID)
+	return nil
+}
+
+func TestWebhookUC(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewDataStorage()
+	clock := newFakeClock()
+	create := usecase.NewCreateWebhookUC(store, clock)
+	update := usecase.NewUpdateWebhookUC(store, clock)
+
+	t.Run("Success", func(t *testing.T) {
+		in := dto.CreateWebhook{Webhook: dto.Webhook{
+			URL:    "https://bot.example.com/todos",
+			Events: []string{"todo.deleted", "todo.created", "todo.created"},
+		}}
+		created, err := create.Execute(ctx, in)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(created.Secret) != 64 || !slices.Equal(created.Events, []string{"todo.created", "todo.deleted"}) {
+			t.Errorf("expected a generated secret and sorted events, got %+v", created.Webhook)
+		}
+
+		updated, err := update.Execute(ctx, dto.UpdateWebhook{Webhook: dto.Webhook{ID: created.ID, URL: "https://bot.example.com/v2"}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		stored, _ := store.GetWebhook(ctx, created.ID)
+		if updated.Secret != "" || stored.Secret != created.Secret || stored.Events != nil {
+			t.Errorf("expected the secret kept and all events, got %+v", stored)
+		}
+	})
+
+	t.Run("Error - invalid webhook", func(t *testing.T) {
+		for _, in := range []dto.Webhook{
+			{URL: "ftp://bot.example.com"},
+			{URL: "/todos"},
+			{URL: "https://bot.example.com", Events: []string{"todo.renamed"}},
+		} {
+			if _, err := create.Execute(ctx, dto.CreateWebhook{Webhook: in}); !errors.Is(err, uc_errors.InvalidWebhookError) {
+				t.Errorf("%+v: expected InvalidWebhookError, got %v", in, err)
+			}
+		}
+	})
+
+	t.Run("Error - not found", func(t *testing.T) {
+		in := dto.UpdateWebhook{Webhook: dto.Webhook{ID: 42, URL: "https://bot.example.com"}}
+		if _, err := update.Execute(ctx, in); !errors.Is(err, uc_errors.WebhookNotFoundError) {
+			t.Errorf("expected WebhookNotFoundError, got %v", err)
+		}
+	})
+}
+
+func TestDeadLettersUC(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewDataStorage()
+	sender := &fakeSender{}
+	replay := usecase.NewReplayDeadLetterUC(store, sender)
+
+	kept := &entity.Webhook{URL: "https://bot.example.com"}
+	dropped := &entity.Webhook{URL: "https://old.example.com"}
+	_ = store.CreateWebhook(ctx, kept)
+	_ = store.CreateWebhook(ctx, dropped)
+	for _, letter := range []entity.DeadLetter{
+		{WebhookID: kept.ID, EventID: "a"},
+		{WebhookID: dropped.ID, EventID: "b"},
+		{WebhookID: kept.ID, EventID: "c"},
+	} {
+		_ = store.CreateDeadLetter(ctx, &letter)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		if _, err := usecase.NewDeleteWebhookUC(store).Execute(ctx, dto.DeleteWebhook{ID: dropped.ID}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		result, err := replay.Execute(ctx, dto.ReplayDeadLetter{ID: 1})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !result.Replayed || !slices.Equal(sender.replayed, []string{"a"}) {
+			t.Errorf("expected dead letter a replayed, got %+v, %v", result, sender.replayed)
+		}
+
+		list, _ := usecase.NewGetDeadLettersUC(store).Execute(ctx)
+		if len(list.DeadLetters) != 1 || list.DeadLetters[0].EventID != "c" {
+			t.Errorf("expected only dead letter c left, got %+v", list.DeadLetters)
+		}
+	})
+
+	t.Run("Error - not found", func(t *testing.T) {
+		if _, err := replay.Execute(ctx, dto.ReplayDeadLetter{ID: 1}); !errors.Is(err, uc_errors.DeadLetterNotFoundError) {
+			t.Errorf("expected DeadLetterNotFoundError, got %v", err)
+		}
+	})
+}
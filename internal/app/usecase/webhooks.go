@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"todo-api/internal/app/dto"
+	"todo-api/internal/app/uc_errors"
+	"todo-api/internal/domain/entity"
+)
+
+const webhookSecretBytes = 32
+
+// checkWebhook validates a webhook from a client and returns its events
+// sorted and without duplicates.
+func checkWebhook(in dto.Webhook) ([]entity.TodoEventType, error) {
+	u, err := url.Parse(in.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: url must be an http or https URL", uc_errors.InvalidWebhookError)
+	}
+
+	var events []entity.TodoEventType
+	for _, name := range in.Events {
+		event := entity.TodoEventType(name)
+		if !slices.Contains(entity.TodoEventTypes, event) {
+			return nil, fmt.Errorf("%w: unknown event %q", uc_errors.InvalidWebhookError, name)
+		}
+		events = append(events, event)
+	}
+	slices.Sort(events)
+	return slices.Compact(events), nil
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,12 @@
+package entity
+
+type TodoEventType string
+
+const (
+	TodoEventCreated   TodoEventType = "todo.created"
+	TodoEventUpdated   TodoEventType = "todo.updated"
+	TodoEventCompleted TodoEventType = "todo.completed"
+	TodoEventDeleted   TodoEventType = "todo.deleted"
+)
+
+var TodoEventTypes = []TodoEventType{TodoEventCreated, TodoEventUpdated, TodoEventCompleted, TodoEventDeleted}
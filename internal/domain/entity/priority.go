@@ -0,0 +1,30 @@
+package entity
+
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+var priorityNames = [...]string{"none", "low", "medium", "high", "urgent"}
+
+func (p Priority) String() string {
+	if p < PriorityNone || p > PriorityUrgent {
+		return "unknown"
+	}
+	return priorityNames[p]
+}
+
+// ParsePriority maps a priority name to its level; ok is false for unknown names.
+func ParsePriority(name string) (p Priority, ok bool) {
+	for i, n := range priorityNames {
+		if n == name {
+			return Priority(i), true
+		}
+	}
+	return PriorityNone, false
+}
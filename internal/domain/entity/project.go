@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+type Project struct {
+	ID          int64
+	Name        string
+	Description string
+	Version     int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
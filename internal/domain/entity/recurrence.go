@@ -0,0 +1,14 @@
+package entity
+
+// Recurrence repeats a todo. The todo's DueAt is the occurrence it stands for;
+// completing it creates the todo for the next one.
+type Recurrence struct {
+	// Rule is an RFC 5545 RRULE; empty for one-off todos.
+	Rule string
+	// TimeZone is the IANA zone the rule is expanded in; empty means UTC.
+	TimeZone string
+}
+
+func (r Recurrence) IsZero() bool {
+	return r.Rule == ""
+}
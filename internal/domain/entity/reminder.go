@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+type ReminderStatus string
+
+const (
+	ReminderPending ReminderStatus = "pending"
+	ReminderSent    ReminderStatus = "sent"
+	// ReminderFailed means every delivery attempt failed.
+	ReminderFailed ReminderStatus = "failed"
+	// ReminderSkipped means the todo was completed before the reminder fired.
+	ReminderSkipped ReminderStatus = "skipped"
+)
+
+type Reminder struct {
+	ID     int64
+	TodoID int64
+	// At fires the reminder at a fixed time. When it is zero the reminder
+	// fires Before the todo's DueAt and follows it when the due date moves.
+	At     time.Time
+	Before time.Duration
+	// Channel names the notifier that delivers the reminder, Target is where
+	// it delivers to, such as a URL or an email address.
+	Channel   string
+	Target    string
+	Status    ReminderStatus
+	Attempts  []DeliveryAttempt
+	CreatedAt time.Time
+	SentAt    time.Time
+}
+
+type DeliveryAttempt struct {
+	At    time.Time
+	Error string
+}
+
+// FireAt returns when the reminder is due for todo; ok is false for a
+// relative reminder of a todo without a due date.
+func (r *Reminder) FireAt(todo *Todo) (t time.Time, ok bool) {
+	if !r.At.IsZero() {
+		return r.At, true
+	}
+	if todo.DueAt.IsZero() {
+		return time.Time{}, false
+	}
+	return todo.DueAt.Add(-r.Before), true
+}
+
+// Clone copies the reminder with its own attempts slice.
+func (r Reminder) Clone() Reminder {
+	r.Attempts = slices.Clone(r.Attempts)
+	return r
+}
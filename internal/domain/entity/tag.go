@@ -0,0 +1,7 @@
+package entity
+
+// TagCount is a tag together with the number of todos labelled with it.
+type TagCount struct {
+	Tag   string
+	Count int
+}
@@ -1,8 +1,29 @@
 package entity
 
+import "time"
+
 type Todo struct {
 	ID          int64
 	Title       string
 	Description string
 	Completed   bool
+	Priority    Priority
+	Tags        []string
+	// ProjectID is 0 for todos outside any project.
+	ProjectID int64
+	// ParentID is 0 for top-level todos.
+	ParentID int64
+	// BlockedBy holds the ids of the todos that must be completed first, in
+	// ascending order. A deleted blocker stays listed but no longer blocks.
+	BlockedBy  []int64
+	Recurrence Recurrence
+	Version    int64
+	// DueAt and StartAt are zero when unset.
+	DueAt   time.Time
+	StartAt time.Time
+	// CreatedAt, UpdatedAt and CompletedAt are stamped by the use cases;
+	// CompletedAt is zero unless Completed is set.
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt time.Time
 }
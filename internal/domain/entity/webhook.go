@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+// Webhook subscribes URL to todo events. Deliveries are signed with Secret.
+type Webhook struct {
+	ID     int64
+	URL    string
+	Secret string
+	// Events filters the deliveries; empty means every event.
+	Events    []TodoEventType
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w *Webhook) Wants(event TodoEventType) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, event)
+}
+
+// Clone copies the webhook with its own events slice.
+func (w Webhook) Clone() Webhook {
+	w.Events = slices.Clone(w.Events)
+	return w
+}
+
+// DeadLetter is a webhook delivery that ran out of attempts. Payload is the
+// exact body that was signed, so a replay delivers the same event.
+type DeadLetter struct {
+	ID        int64
+	WebhookID int64
+	EventID   string
+	Event     TodoEventType
+	Payload   []byte
+	Attempts  []DeliveryAttempt
+	CreatedAt time.Time
+}
+
+// Clone copies the dead letter with its own payload and attempts.
+func (d DeadLetter) Clone() DeadLetter {
+	d.Payload = slices.Clone(d.Payload)
+	d.Attempts = slices.Clone(d.Attempts)
+	return d
+}
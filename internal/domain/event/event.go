@@ -0,0 +1,41 @@
+package event
+
+import "todo-api/internal/domain/entity"
+
+// Event is a change to a todo. It is published only once the change is
+// stored.
+type Event interface {
+	Type() entity.TodoEventType
+	// Subject is the todo after the change, or the last stored state of a
+	// deleted one.
+	Subject() entity.Todo
+}
+
+type TodoCreated struct {
+	Todo entity.Todo
+}
+
+type TodoUpdated struct {
+	Before entity.Todo
+	After  entity.Todo
+}
+
+// TodoCompleted follows the TodoUpdated of the write that completed the
+// todo.
+type TodoCompleted struct {
+	Todo entity.Todo
+}
+
+type TodoDeleted struct {
+	Todo entity.Todo
+}
+
+func (e TodoCreated) Type() entity.TodoEventType   { return entity.TodoEventCreated }
+func (e TodoUpdated) Type() entity.TodoEventType   { return entity.TodoEventUpdated }
+func (e TodoCompleted) Type() entity.TodoEventType { return entity.TodoEventCompleted }
+func (e TodoDeleted) Type() entity.TodoEventType   { return entity.TodoEventDeleted }
+
+func (e TodoCreated) Subject() entity.Todo   { return e.Todo }
+func (e TodoUpdated) Subject() entity.Todo   { return e.After }
+func (e TodoCompleted) Subject() entity.Todo { return e.Todo }
+func (e TodoDeleted) Subject() entity.Todo   { return e.Todo }
@@ -0,0 +1,7 @@
+package port
+
+import "time"
+
+type Clock interface {
+	Now() time.Time
+}
@@ -3,12 +3,22 @@ package port
 import (
 	"context"
 	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/query"
 )
 
 type DataStorage interface {
 	CreateTodo(ctx context.Context, todo *entity.Todo) error
 	GetTodo(ctx context.Context, id int64) (*entity.Todo, error)
 	GetTodoList(ctx context.Context, limit, offset int) ([]*entity.Todo, error)
-	UpdateTodo(ctx context.Context, todo *entity.Todo) error
-	DeleteTodo(ctx context.Context, id int64) error
+	// QueryTodos returns the todos matching q.Filter (all when nil), ordered by
+	// q.Sort and then by id, and paged like GetTodoList.
+	QueryTodos(ctx context.Context, q query.Query) ([]*entity.Todo, error)
+	// CountTodos returns how many todos match filter (all when nil).
+	CountTodos(ctx context.Context, filter query.Filter) (int, error)
+	// ListTags returns every tag in use with its todo count, ordered by tag.
+	ListTags(ctx context.Context) ([]entity.TagCount, error)
+	// UpdateTodo and DeleteTodo fail with uc_errors.TodoVersionConflictError
+	// unless expectedVersion is 0 or matches the stored version.
+	UpdateTodo(ctx context.Context, todo *entity.Todo, expectedVersion int64) error
+	DeleteTodo(ctx context.Context, id int64, expectedVersion int64) error
 }
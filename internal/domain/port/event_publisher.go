@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"todo-api/internal/domain/event"
+)
+
+// EventPublisher is given the events of a write once it is stored.
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...event.Event)
+}
@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+	"todo-api/internal/domain/entity"
+)
+
+// Notifier delivers reminders over one channel, such as a webhook or email.
+type Notifier interface {
+	// CheckTarget validates a reminder target before the reminder is stored.
+	CheckTarget(target string) error
+	Notify(ctx context.Context, reminder *entity.Reminder, todo *entity.Todo) error
+}
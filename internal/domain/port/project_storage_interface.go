@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+	"todo-api/internal/domain/entity"
+)
+
+// ProjectStorage keeps projects only; todos refer to them by
+// entity.Todo.ProjectID and are moved or removed by the use cases.
+type ProjectStorage interface {
+	CreateProject(ctx context.Context, project *entity.Project) error
+	GetProject(ctx context.Context, id int64) (*entity.Project, error)
+	// ListProjects returns projects ordered by id; a limit of 0 means no limit.
+	ListProjects(ctx context.Context, limit, offset int) ([]*entity.Project, error)
+	CountProjects(ctx context.Context) (int, error)
+	// UpdateProject and DeleteProject fail with
+	// uc_errors.ProjectVersionConflictError unless expectedVersion is 0 or
+	// matches the stored version.
+	UpdateProject(ctx context.Context, project *entity.Project, expectedVersion int64) error
+	DeleteProject(ctx context.Context, id int64, expectedVersion int64) error
+}
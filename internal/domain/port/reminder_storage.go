@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+	"todo-api/internal/domain/entity"
+)
+
+// ReminderStorage keeps the reminders of todos. Reminders outlive a deleted
+// todo until the reminder worker drops them.
+type ReminderStorage interface {
+	CreateReminder(ctx context.Context, reminder *entity.Reminder) error
+	// GetReminder fails with uc_errors.ReminderNotFoundError.
+	GetReminder(ctx context.Context, id int64) (*entity.Reminder, error)
+	// ListReminders returns the reminders of todo todoID ordered by id.
+	ListReminders(ctx context.Context, todoID int64) ([]*entity.Reminder, error)
+	// PendingReminders returns every reminder waiting for delivery, ordered
+	// by id.
+	PendingReminders(ctx context.Context) ([]*entity.Reminder, error)
+	UpdateReminder(ctx context.Context, reminder *entity.Reminder) error
+	DeleteReminder(ctx context.Context, id int64) error
+}
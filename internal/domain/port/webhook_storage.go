@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+	"todo-api/internal/domain/entity"
+)
+
+// WebhookStorage keeps webhook subscriptions and the deliveries that failed
+// for good.
+type WebhookStorage interface {
+	CreateWebhook(ctx context.Context, webhook *entity.Webhook) error
+	// GetWebhook, UpdateWebhook and DeleteWebhook fail with
+	// uc_errors.WebhookNotFoundError.
+	GetWebhook(ctx context.Context, id int64) (*entity.Webhook, error)
+	// ListWebhooks returns every webhook ordered by id.
+	ListWebhooks(ctx context.Context) ([]*entity.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *entity.Webhook) error
+	DeleteWebhook(ctx context.Context, id int64) error
+
+	CreateDeadLetter(ctx context.Context, letter *entity.DeadLetter) error
+	// GetDeadLetter and DeleteDeadLetter fail with
+	// uc_errors.DeadLetterNotFoundError.
+	GetDeadLetter(ctx context.Context, id int64) (*entity.DeadLetter, error)
+	// ListDeadLetters returns every dead letter ordered by id.
+	ListDeadLetters(ctx context.Context) ([]*entity.DeadLetter, error)
+	DeleteDeadLetter(ctx context.Context, id int64) error
+}
+
+// WebhookSender delivers a dead letter again in the background; the letter
+// comes back under a new id if it fails again.
+type WebhookSender interface {
+	Replay(ctx context.Context, webhook *entity.Webhook, letter *entity.DeadLetter) error
+}
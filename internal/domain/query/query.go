@@ -0,0 +1,298 @@
+package query
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+	"todo-api/internal/domain/entity"
+)
+
+type Field string
+
+const (
+	FieldID          Field = "id"
+	FieldTitle       Field = "title"
+	FieldDescription Field = "description"
+	FieldCompleted   Field = "completed"
+	FieldPriority    Field = "priority"
+	FieldProjectID   Field = "project_id"
+	FieldParentID    Field = "parent_id"
+	FieldDueAt       Field = "due_at"
+	FieldStartAt     Field = "start_at"
+	FieldCreatedAt   Field = "created_at"
+	FieldUpdatedAt   Field = "updated_at"
+	FieldCompletedAt Field = "completed_at"
+)
+
+type Kind int
+
+const (
+	KindInt Kind = iota + 1
+	KindString
+	KindBool
+	// KindTime values are time.Time. An unset time is the zero time, which
+	// orders before every set one.
+	KindTime
+)
+
+var fields = map[Field]Kind{
+	FieldID:          KindInt,
+	FieldTitle:       KindString,
+	FieldDescription: KindString,
+	FieldCompleted:   KindBool,
+	FieldPriority:    KindInt,
+	FieldProjectID:   KindInt,
+	FieldParentID:    KindInt,
+	FieldDueAt:       KindTime,
+	FieldStartAt:     KindTime,
+	FieldCreatedAt:   KindTime,
+	FieldUpdatedAt:   KindTime,
+	FieldCompletedAt: KindTime,
+}
+
+// Kind reports the type of the field's values; ok is false for unknown fields.
+func (f Field) Kind() (kind Kind, ok bool) {
+	kind, ok = fields[f]
+	return kind, ok
+}
+
+// Value returns the todo's value of the field as an int64, string, bool or
+// time.Time.
+func (f Field) Value(todo *entity.Todo) any {
+	switch f {
+	case FieldID:
+		return todo.ID
+	case FieldTitle:
+		return todo.Title
+	case FieldDescription:
+		return todo.Description
+	case FieldCompleted:
+		return todo.Completed
+	case FieldPriority:
+		return int64(todo.Priority)
+	case FieldProjectID:
+		return todo.ProjectID
+	case FieldParentID:
+		return todo.ParentID
+	case FieldDueAt:
+		return todo.DueAt
+	case FieldStartAt:
+		return todo.StartAt
+	case FieldCreatedAt:
+		return todo.CreatedAt
+	case FieldUpdatedAt:
+		return todo.UpdatedAt
+	case FieldCompletedAt:
+		return todo.CompletedAt
+	}
+	return nil
+}
+
+type Op string
+
+const (
+	OpEq       Op = "="
+	OpNe       Op = "!="
+	OpLt       Op = "<"
+	OpLe       Op = "<="
+	OpGt       Op = ">"
+	OpGe       Op = ">="
+	OpContains Op = "~"
+)
+
+// Supports reports whether op can be applied to values of the given kind.
+func (op Op) Supports(kind Kind) bool {
+	switch op {
+	case OpEq, OpNe:
+		return true
+	case OpLt, OpLe, OpGt, OpGe:
+		return kind != KindBool
+	case OpContains:
+		return kind == KindString
+	}
+	return false
+}
+
+// Filter is a node of a boolean expression over todo fields.
+type Filter interface {
+	Match(todo *entity.Todo) bool
+}
+
+// Predicate compares a field with a constant. Value holds an int64, string,
+// bool or time.Time matching the field's Kind.
+type Predicate struct {
+	Field Field
+	Op    Op
+	Value any
+}
+
+func (p Predicate) Match(todo *entity.Todo) bool {
+	actual := p.Field.Value(todo)
+
+	if p.Op == OpContains {
+		haystack, _ := actual.(string)
+		needle, _ := p.Value.(string)
+		return Contains(haystack, needle)
+	}
+
+	c := compare(actual, p.Value)
+	switch p.Op {
+	case OpEq:
+		return c == 0
+	case OpNe:
+		return c != 0
+	case OpLt:
+		return c < 0
+	case OpLe:
+		return c <= 0
+	case OpGt:
+		return c > 0
+	case OpGe:
+		return c >= 0
+	}
+	return false
+}
+
+// IsSet matches todos whose time field has a value.
+func IsSet(field Field) Filter {
+	return Predicate{Field: field, Op: OpGt, Value: time.Time{}}
+}
+
+// HasTag matches todos labelled with Tag, which must already be normalised.
+type HasTag struct {
+	Tag string
+}
+
+func (h HasTag) Match(todo *entity.Todo) bool {
+	return slices.Contains(todo.Tags, h.Tag)
+}
+
+type And []Filter
+
+func (a And) Match(todo *entity.Todo) bool {
+	for _, f := range a {
+		if !f.Match(todo) {
+			return false
+		}
+	}
+	return true
+}
+
+type Or []Filter
+
+func (o Or) Match(todo *entity.Todo) bool {
+	for _, f := range o {
+		if f.Match(todo) {
+			return true
+		}
+	}
+	return false
+}
+
+type Not struct {
+	Filter Filter
+}
+
+func (n Not) Match(todo *entity.Todo) bool {
+	return !n.Filter.Match(todo)
+}
+
+// Contains is the case-insensitive substring match behind OpContains. Storage
+// adapters that evaluate filters themselves must use the same semantics.
+func Contains(haystack, needle string) bool {
+	return strings.Contains(strings.ToLower(haystack), strings.ToLower(needle))
+}
+
+type SortKey struct {
+	Field Field
+	Desc  bool
+}
+
+// Order returns a comparison function for the given sort keys. Ties are broken
+// by ascending id so that pages are stable.
+func Order(keys []SortKey) func(a, b *entity.Todo) int {
+	return func(a, b *entity.Todo) int {
+		for _, key := range keys {
+			c := compare(key.Field.Value(a), key.Field.Value(b))
+			if key.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.ID, b.ID)
+	}
+}
+
+// Reverse flips the direction of every key.
+func Reverse(keys []SortKey) []SortKey {
+	reversed := make([]SortKey, len(keys))
+	for i, key := range keys {
+		reversed[i] = SortKey{Field: key.Field, Desc: !key.Desc}
+	}
+	return reversed
+}
+
+// Seek matches the todos that come strictly after the position given by
+// values (one per key) in the order defined by keys. Together with a sort on
+// the same keys it implements keyset pagination; keys must end with a unique
+// field such as id for the position to be exact.
+func Seek(keys []SortKey, values []any) Filter {
+	var after Or
+	var equal And
+
+	for i, key := range keys {
+		op := OpGt
+		if key.Desc {
+			op = OpLt
+		}
+
+		// Booleans are only compared for equality: "after false" in
+		// ascending order is true and nothing comes after true.
+		if kind, _ := key.Field.Kind(); kind == KindBool {
+			if b, _ := values[i].(bool); b == key.Desc {
+				after = append(after, append(slices.Clone(equal), Predicate{Field: key.Field, Op: OpEq, Value: !b}))
+			}
+		} else {
+			after = append(after, append(slices.Clone(equal), Predicate{Field: key.Field, Op: op, Value: values[i]}))
+		}
+
+		equal = append(equal, Predicate{Field: key.Field, Op: OpEq, Value: values[i]})
+	}
+
+	return after
+}
+
+type Query struct {
+	Filter Filter
+	Sort   []SortKey
+	Limit  int
+	Offset int
+}
+
+func compare(a, b any) int {
+	switch x := a.(type) {
+	case int64:
+		y, _ := b.(int64)
+		return cmp.Compare(x, y)
+	case string:
+		y, _ := b.(string)
+		return strings.Compare(x, y)
+	case time.Time:
+		y, _ := b.(time.Time)
+		return x.Compare(y)
+	case bool:
+		y, _ := b.(bool)
+		switch {
+		case x == y:
+			return 0
+		case !x:
+			return -1
+		default:
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,64 @@
+package query_test
+
+import (
+	"slices"
+	"testing"
+	"todo-api/internal/domain/entity"
+	"todo-api/internal/domain/query"
+)
+
+func TestFilter_Match(t *testing.T) {
+	todo := &entity.Todo{ID: 7, Title: "Купить Молоко", Description: "2 liters", Completed: true}
+
+	cases := []struct {
+		name     string
+		filter   query.Filter
+		expected bool
+	}{
+		{"Eq", query.Predicate{Field: query.FieldCompleted, Op: query.OpEq, Value: true}, true},
+		{"Ne", query.Predicate{Field: query.FieldID, Op: query.OpNe, Value: int64(7)}, false},
+		{"Lt", query.Predicate{Field: query.FieldID, Op: query.OpLt, Value: int64(8)}, true},
+		{"Ge", query.Predicate{Field: query.FieldID, Op: query.OpGe, Value: int64(8)}, false},
+		{"String compare", query.Predicate{Field: query.FieldDescription, Op: query.OpGt, Value: "1"}, true},
+		{"Contains ignores case", query.Predicate{Field: query.FieldTitle, Op: query.OpContains, Value: "молоко"}, true},
+		{"And", query.And{
+			query.Predicate{Field: query.FieldCompleted, Op: query.OpEq, Value: true},
+			query.Predicate{Field: query.FieldTitle, Op: query.OpContains, Value: "хлеб"},
+		}, false},
+		{"Or", query.Or{
+			query.Predicate{Field: query.FieldCompleted, Op: query.OpEq, Value: false},
+			query.Predicate{Field: query.FieldDescription, Op: query.OpContains, Value: "LITERS"},
+		}, true},
+		{"Not", query.Not{Filter: query.Predicate{Field: query.FieldID, Op: query.OpEq, Value: int64(7)}}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.filter.Match(todo); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestOrder(t *testing.T) {
+	todos := []*entity.Todo{
+		{ID: 1, Title: "b", Completed: true},
+		{ID: 2, Title: "a"},
+		{ID: 3, Title: "b"},
+		{ID: 4, Title: "a", Completed: true},
+	}
+
+	slices.SortStableFunc(todos, query.Order([]query.SortKey{
+		{Field: query.FieldCompleted, Desc: true},
+		{Field: query.FieldTitle},
+	}))
+
+	var ids []int64
+	for _, todo := range todos {
+		ids = append(ids, todo.ID)
+	}
+	if expected := []int64{4, 1, 2, 3}; !slices.Equal(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
@@ -0,0 +1,388 @@
+// Package recurrence implements the subset of RFC 5545 recurrence rules that
+// recurring todos accept: FREQ, INTERVAL, BYDAY, BYMONTHDAY, COUNT and UNTIL.
+package recurrence
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Freq int
+
+const (
+	Daily Freq = iota + 1
+	Weekly
+	Monthly
+	Yearly
+)
+
+var freqNames = map[Freq]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+var dayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Weekday is a BYDAY entry. N picks the Nth weekday of the month, counting
+// from the end when negative; 0 means every such weekday.
+type Weekday struct {
+	N   int
+	Day time.Weekday
+}
+
+type Rule struct {
+	Freq       Freq
+	Interval   int
+	ByDay      []Weekday
+	ByMonthDay []int
+	// Count is the number of occurrences in the series; 0 means no limit.
+	Count int
+	// Until is the last moment an occurrence may fall on; zero means no
+	// limit. With UntilDate set only its date counts, taken in the series'
+	// time zone.
+	Until     time.Time
+	UntilDate bool
+}
+
+// maxPeriods bounds the search for the next occurrence of rules that match
+// rarely or never, such as the 31st of every February.
+const maxPeriods = 10000
+
+const (
+	untilLayout     = "20060102T150405Z"
+	untilDateLayout = "20060102"
+)
+
+// Parse reads a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH". The
+// "RRULE:" prefix is optional and names are case-insensitive.
+func Parse(s string) (Rule, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.TrimPrefix(s, "RRULE:")
+
+	rule := Rule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return Rule{}, fmt.Errorf("malformed rule part %q", part)
+		}
+		if seen[name] {
+			return Rule{}, fmt.Errorf("%s is given twice", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Freq, err = parseFreq(value)
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(name, value)
+		case "COUNT":
+			rule.Count, err = parsePositive(name, value)
+		case "UNTIL":
+			rule.Until, rule.UntilDate, err = parseUntil(value)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseByMonthDay(value)
+		default:
+			err = fmt.Errorf("%s is not supported", name)
+		}
+		if err != nil {
+			return Rule{}, err
+		}
+	}
+
+	if err := rule.check(); err != nil {
+		return Rule{}, err
+	}
+	return rule, nil
+}
+
+func (r Rule) check() error {
+	if r.Freq == 0 {
+		return fmt.Errorf("FREQ is required")
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return fmt.Errorf("COUNT and UNTIL must not be combined")
+	}
+
+	ordinals := slices.ContainsFunc(r.ByDay, func(d Weekday) bool { return d.N != 0 })
+	switch r.Freq {
+	case Daily:
+		if ordinals {
+			return fmt.Errorf("BYDAY ordinals need FREQ=MONTHLY")
+		}
+	case Weekly:
+		if ordinals {
+			return fmt.Errorf("BYDAY ordinals need FREQ=MONTHLY")
+		}
+		if len(r.ByMonthDay) > 0 {
+			return fmt.Errorf("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+		}
+	case Yearly:
+		if len(r.ByDay) > 0 || len(r.ByMonthDay) > 0 {
+			return fmt.Errorf("BYDAY and BYMONTHDAY are not supported with FREQ=YEARLY")
+		}
+	}
+	return nil
+}
+
+func parseFreq(value string) (Freq, error) {
+	for freq, name := range freqNames {
+		if name == value {
+			return freq, nil
+		}
+	}
+	return 0, fmt.Errorf("FREQ must be DAILY, WEEKLY, MONTHLY or YEARLY")
+}
+
+func parsePositive(name, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s must be a positive number", name)
+	}
+	return n, nil
+}
+
+func parseUntil(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(untilLayout, value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse(untilDateLayout, value); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("UNTIL must look like 20060102T150405Z or 20060102")
+}
+
+func parseByDay(value string) ([]Weekday, error) {
+	var days []Weekday
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY entry %q", item)
+		}
+		code, ordinal := item[len(item)-2:], item[:len(item)-2]
+
+		day := slices.Index(dayNames[:], code)
+		if day < 0 {
+			return nil, fmt.Errorf("invalid BYDAY entry %q", item)
+		}
+
+		var n int
+		if ordinal != "" {
+			var err error
+			n, err = strconv.Atoi(ordinal)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("invalid BYDAY entry %q", item)
+			}
+		}
+		days = append(days, Weekday{N: n, Day: time.Weekday(day)})
+	}
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	var days []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || n < -31 || n > 31 {
+			return nil, fmt.Errorf("invalid BYMONTHDAY entry %q", item)
+		}
+		days = append(days, n)
+	}
+	return days, nil
+}
+
+// String formats the rule in its canonical form, which Parse reads back.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + freqNames[r.Freq]}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = dayNames[d.Day]
+			if d.N != 0 {
+				days[i] = strconv.Itoa(d.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		layout := untilLayout
+		if r.UntilDate {
+			layout = untilDateLayout
+		}
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(layout))
+	}
+	return strings.Join(parts, ";")
+}
+
+// All yields the occurrences of the series that starts at start, in order.
+// start is always the first one. Every occurrence keeps start's wall clock
+// time in start's location, so a daily 09:00 stays at 09:00 across daylight
+// saving changes.
+func (r Rule) All(start time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if r.ended(start) || !yield(start) {
+			return
+		}
+
+		hour, minute, sec := start.Clock()
+		n := 1
+		for period := 0; period < maxPeriods; period++ {
+			for _, date := range r.dates(start, period) {
+				t := time.Date(date.Year(), date.Month(), date.Day(), hour, minute, sec, start.Nanosecond(), start.Location())
+				if !t.After(start) {
+					continue
+				}
+				if r.Count > 0 && n >= r.Count || r.ended(t) {
+					return
+				}
+				if !yield(t) {
+					return
+				}
+				n++
+			}
+		}
+	}
+}
+
+// Next returns the occurrence after start together with the rule that
+// continues the series from there; ok is false when start is the last one.
+func (r Rule) Next(start time.Time) (next time.Time, rest Rule, ok bool) {
+	for t := range r.All(start) {
+		if t.Equal(start) {
+			continue
+		}
+		rest = r
+		if rest.Count > 0 {
+			rest.Count--
+		}
+		return t, rest, true
+	}
+	return time.Time{}, Rule{}, false
+}
+
+func (r Rule) ended(t time.Time) bool {
+	if r.Until.IsZero() {
+		return false
+	}
+	if r.UntilDate {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).After(r.Until)
+	}
+	return t.After(r.Until)
+}
+
+// dates returns the candidate dates of one period, as UTC midnights in
+// ascending order. Period 0 is the one holding start.
+func (r Rule) dates(start time.Time, period int) []time.Time {
+	y, m, d := start.Date()
+	first := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	step := period * r.Interval
+
+	switch r.Freq {
+	case Daily:
+		date := first.AddDate(0, 0, step)
+		if r.matchesDay(date) && r.matchesMonthDay(date) {
+			return []time.Time{date}
+		}
+		return nil
+
+	case Weekly:
+		monday := first.AddDate(0, 0, 7*step-(int(first.Weekday())+6)%7)
+		days := []time.Weekday{first.Weekday()}
+		if len(r.ByDay) > 0 {
+			days = days[:0]
+			for _, wd := range r.ByDay {
+				days = append(days, wd.Day)
+			}
+		}
+		var dates []time.Time
+		for _, day := range days {
+			dates = append(dates, monday.AddDate(0, 0, (int(day)+6)%7))
+		}
+		slices.SortFunc(dates, time.Time.Compare)
+		return slices.CompactFunc(dates, time.Time.Equal)
+
+	case Monthly:
+		month := time.Date(y, m+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+		return r.monthDates(month, d)
+
+	case Yearly:
+		date := time.Date(y+step, m, d, 0, 0, 0, 0, time.UTC)
+		if date.Day() != d {
+			// February 29th outside leap years.
+			return nil
+		}
+		return []time.Time{date}
+	}
+	return nil
+}
+
+// monthDates lists the days of month matched by BYMONTHDAY and BYDAY, which
+// narrow each other when both are set; without either it is day.
+func (r Rule) monthDates(month time.Time, day int) []time.Time {
+	length := month.AddDate(0, 1, -1).Day()
+
+	var dates []time.Time
+	for d := 1; d <= length; d++ {
+		date := month.AddDate(0, 0, d-1)
+		switch {
+		case len(r.ByMonthDay) == 0 && len(r.ByDay) == 0:
+			if d != day {
+				continue
+			}
+		case !r.matchesMonthDay(date) || !r.matchesDayInMonth(date, length):
+			continue
+		}
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+func (r Rule) matchesDay(date time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(r.ByDay, func(wd Weekday) bool { return wd.Day == date.Weekday() })
+}
+
+func (r Rule) matchesDayInMonth(date time.Time, length int) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	nth := (date.Day()-1)/7 + 1
+	nthLast := -((length-date.Day())/7 + 1)
+	return slices.ContainsFunc(r.ByDay, func(wd Weekday) bool {
+		return wd.Day == date.Weekday() && (wd.N == 0 || wd.N == nth || wd.N == nthLast)
+	})
+}
+
+func (r Rule) matchesMonthDay(date time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	length := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return slices.ContainsFunc(r.ByMonthDay, func(d int) bool {
+		return d == date.Day() || d < 0 && length+d+1 == date.Day()
+	})
+}
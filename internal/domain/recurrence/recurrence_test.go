@@ -0,0 +1,153 @@
+package recurrence_test
+
+import (
+	"testing"
+	"time"
+	"todo-api/internal/domain/recurrence"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in, canonical string
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"rrule:freq=weekly;byday=mo,th;interval=2", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH"},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3"},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,-1;UNTIL=20261231", "FREQ=MONTHLY;BYMONTHDAY=1,-1;UNTIL=20261231"},
+		{"FREQ=YEARLY;UNTIL=20300101T000000Z", "FREQ=YEARLY;UNTIL=20300101T000000Z"},
+	}
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			rule, err := recurrence.Parse(c.in)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got := rule.String(); got != c.canonical {
+				t.Errorf("expected %q, got %q", c.canonical, got)
+			}
+		})
+	}
+
+	for _, in := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20261231",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=YEARLY;BYDAY=MO",
+		"FREQ=DAILY;BYHOUR=9",
+	} {
+		t.Run("Error - "+in, func(t *testing.T) {
+			if _, err := recurrence.Parse(in); err == nil {
+				t.Errorf("expected an error for %q", in)
+			}
+		})
+	}
+}
+
+func TestRule_All(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		rule     string
+		start    time.Time
+		expected []string
+	}{
+		{
+			name:     "Weekly every other week",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=5",
+			start:    time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), // Thursday
+			expected: []string{"2026-01-01", "2026-01-12", "2026-01-15", "2026-01-26", "2026-01-29"},
+		},
+		{
+			name:     "Monthly on the last Friday",
+			rule:     "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			start:    time.Date(2026, 1, 30, 9, 0, 0, 0, time.UTC),
+			expected: []string{"2026-01-30", "2026-02-27", "2026-03-27"},
+		},
+		{
+			name:     "Monthly on the 31st skips short months",
+			rule:     "FREQ=MONTHLY;COUNT=3",
+			start:    time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC),
+			expected: []string{"2026-01-31", "2026-03-31", "2026-05-31"},
+		},
+		{
+			name:     "Monthly on the first and last day",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=1,-1;UNTIL=20260301",
+			start:    time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+			expected: []string{"2026-01-01", "2026-01-31", "2026-02-01", "2026-02-28", "2026-03-01"},
+		},
+		{
+			name:     "Daily on weekdays",
+			rule:     "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;COUNT=4",
+			start:    time.Date(2026, 1, 8, 9, 0, 0, 0, time.UTC), // Thursday
+			expected: []string{"2026-01-08", "2026-01-09", "2026-01-12", "2026-01-13"},
+		},
+		{
+			name:     "Yearly on February 29th",
+			rule:     "FREQ=YEARLY;COUNT=2",
+			start:    time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+			expected: []string{"2024-02-29", "2028-02-29"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rule, err := recurrence.Parse(c.rule)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			var got []string
+			for occurrence := range rule.All(c.start) {
+				got = append(got, occurrence.Format(time.DateOnly))
+				if len(got) > len(c.expected) {
+					break
+				}
+			}
+			if len(got) != len(c.expected) {
+				t.Fatalf("expected %v, got %v", c.expected, got)
+			}
+			for i := range got {
+				if got[i] != c.expected[i] {
+					t.Errorf("expected %v, got %v", c.expected, got)
+					break
+				}
+			}
+		})
+	}
+
+	t.Run("Keeps the wall clock across DST", func(t *testing.T) {
+		rule, _ := recurrence.Parse("FREQ=DAILY")
+		start := time.Date(2026, 3, 28, 9, 0, 0, 0, berlin)
+
+		next, _, ok := rule.Next(start)
+		if !ok {
+			t.Fatal("expected a next occurrence")
+		}
+		if next.Hour() != 9 || next.Sub(start) != 23*time.Hour {
+			t.Errorf("expected 09:00 local 23 hours later, got %v", next)
+		}
+	})
+
+	t.Run("Next counts down", func(t *testing.T) {
+		rule, _ := recurrence.Parse("FREQ=DAILY;COUNT=2")
+		start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+		next, rest, ok := rule.Next(start)
+		if !ok || !next.Equal(start.AddDate(0, 0, 1)) || rest.Count != 1 {
+			t.Fatalf("expected the next day with COUNT=1, got %v, %v, %v", next, rest, ok)
+		}
+		if _, _, ok := rest.Next(next); ok {
+			t.Error("expected the series to end")
+		}
+	})
+}